@@ -18,6 +18,8 @@ package helper
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"github.com/aldelo/common/rest"
@@ -25,58 +27,650 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
-// GetNetListener triggers the specified port to listen via tcp
+// ParsePort parses the port string into port number, valid port is within 1 to 65535,
+// error is returned if port string is blank, not numeric, zero, negative, or out of range
+func ParsePort(s string) (uint, error) {
+	s = Trim(s)
+
+	if len(s) == 0 {
+		return 0, fmt.Errorf("Port is Required")
+	}
+
+	p, err := strconv.ParseInt(s, 10, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("Port '%s' Must Be Numeric", s)
+	}
+
+	if p <= 0 || p > 65535 {
+		return 0, fmt.Errorf("Port '%s' Must Be Within 1 to 65535", s)
+	}
+
+	return uint(p), nil
+}
+
+// GetNetListener triggers the specified port to listen via tcp,
+// port 0 indicates the system will choose an available port
 func GetNetListener(port uint) (net.Listener, error) {
-	if l, e := net.Listen("tcp", fmt.Sprintf(":%d", port)); e != nil {
-		return nil, fmt.Errorf("Listen Tcp on Port %d Failed: %v", port, e)
+	return GetNetListenerOn("tcp", "", port)
+}
+
+// GetNetListenerOn triggers the specified host and port to listen via network,
+// network = tcp, tcp4 or tcp6 (blank defaults to tcp),
+// host = ip address or host name to bind, such as 127.0.0.1 for loopback only, blank binds all interfaces,
+// port 0 indicates the system will choose an available port
+func GetNetListenerOn(network string, host string, port uint) (net.Listener, error) {
+	network = strings.ToLower(Trim(network))
+	host = Trim(host)
+
+	if len(network) == 0 {
+		network = "tcp"
+	}
+
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		// supported network
+	default:
+		return nil, fmt.Errorf("Listen on Network '%s' Not Supported: Expects tcp, tcp4 or tcp6", network)
+	}
+
+	where := fmt.Sprintf("Port %d", port)
+
+	if len(host) > 0 {
+		where = fmt.Sprintf("Host %s Port %d", host, port)
+	}
+
+	if port != 0 {
+		if _, e := ParsePort(UintToStr(port)); e != nil {
+			return nil, fmt.Errorf("Listen %s on %s Failed: %v", network, where, e)
+		}
+	}
+
+	if l, e := net.Listen(network, net.JoinHostPort(strings.Trim(host, "[]"), UintToStr(port))); e != nil {
+		return nil, fmt.Errorf("Listen %s on %s Failed: %v", network, where, e)
+	} else {
+		return l, nil
+	}
+}
+
+// GetNetListenerEx triggers the address to listen via network, where network and address are:
+//		1) tcp, tcp4, tcp6 = address as host:port, such as 127.0.0.1:8080 for loopback only, or :0 for all interfaces with system chosen port
+//		2) unix = address as unix domain socket file path, such as /tmp/app.sock,
+//		   stale socket file left at address (one that no process is listening on) is removed prior to listen
+// use GetListenerPort to obtain the actual port assigned when port 0 is requested
+func GetNetListenerEx(network string, address string) (net.Listener, error) {
+	network = strings.ToLower(Trim(network))
+	address = Trim(address)
+
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		if _, port, err := net.SplitHostPort(address); err != nil {
+			return nil, fmt.Errorf("Listen %s on %s Failed: %v", network, address, err)
+		} else if port != "0" {
+			if _, e := ParsePort(port); e != nil {
+				return nil, fmt.Errorf("Listen %s on %s Failed: %v", network, address, e)
+			}
+		}
+	case "unix":
+		if len(address) == 0 {
+			return nil, fmt.Errorf("Listen unix Failed: Socket Path is Required")
+		}
+
+		if err := removeStaleUnixSocket(address); err != nil {
+			return nil, fmt.Errorf("Listen unix on %s Failed: %v", address, err)
+		}
+	default:
+		return nil, fmt.Errorf("Listen on Network '%s' Not Supported: Expects tcp, tcp4, tcp6 or unix", network)
+	}
+
+	if l, e := net.Listen(network, address); e != nil {
+		return nil, fmt.Errorf("Listen %s on %s Failed: %v", network, address, e)
 	} else {
 		return l, nil
 	}
 }
 
+// removeStaleUnixSocket removes the unix domain socket file at path if no process is listening on it,
+// error is returned if path exists but is not a socket file, or the socket is still in use
+func removeStaleUnixSocket(path string) error {
+	fi, err := os.Stat(path)
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if fi.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("Path Exists and Is Not a Socket File")
+	}
+
+	if c, e := net.DialTimeout("unix", path, time.Second); e == nil {
+		_ = c.Close()
+		return fmt.Errorf("Socket Is Already In Use")
+	}
+
+	return os.Remove(path)
+}
+
+// GetListenerPort returns the actual tcp port that listener l is listening on,
+// such as the system assigned port when listening on port 0, 0 is returned if l is nil or not tcp listener
+func GetListenerPort(l net.Listener) uint {
+	if l == nil || l.Addr() == nil {
+		return 0
+	}
+
+	if a, ok := l.Addr().(*net.TCPAddr); ok {
+		return uint(a.Port)
+	}
+
+	if _, port, err := net.SplitHostPort(l.Addr().String()); err == nil {
+		if p, e := ParsePort(port); e == nil {
+			return p
+		}
+	}
+
+	return 0
+}
+
+// GetTLSNetListener triggers the specified port to listen via tcp with tls,
+// using the certificate and private key pem files given (tls 1.2 minimum),
+// bindHost is optional ip address or host name to bind (such as 127.0.0.1), if not specified, all interfaces are bound,
+// port 0 indicates the system will choose an available port
+func GetTLSNetListener(port uint, certFile string, keyFile string, bindHost ...string) (net.Listener, error) {
+	if LenTrim(certFile) == 0 || LenTrim(keyFile) == 0 {
+		return nil, fmt.Errorf("TLS Cert Load Failed: Cert File and Key File Are Required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("TLS Cert Load Failed: %v", err)
+	}
+
+	return GetTLSNetListenerFromConfig(port, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, bindHost...)
+}
+
+// GetTLSNetListenerFromConfig triggers the specified port to listen via tcp with tls, using the tls config given,
+// if cfg does not specify MinVersion, tls 1.2 is used as minimum version,
+// bindHost is optional ip address or host name to bind (such as 127.0.0.1), if not specified, all interfaces are bound,
+// port 0 indicates the system will choose an available port
+func GetTLSNetListenerFromConfig(port uint, cfg *tls.Config, bindHost ...string) (net.Listener, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("TLS Config is Required")
+	}
+
+	if len(cfg.Certificates) == 0 && cfg.GetCertificate == nil && cfg.GetConfigForClient == nil {
+		return nil, fmt.Errorf("TLS Cert Load Failed: TLS Config Has No Certificate")
+	}
+
+	if cfg.MinVersion == 0 {
+		cfg = cfg.Clone()
+		cfg.MinVersion = tls.VersionTLS12
+	}
+
+	host := ""
+
+	if len(bindHost) > 0 {
+		host = bindHost[0]
+	}
+
+	if l, err := GetNetListenerOn("tcp", host, port); err != nil {
+		return nil, fmt.Errorf("TLS Bind Failed: %v", err)
+	} else {
+		return tls.NewListener(l, cfg), nil
+	}
+}
+
+// IsPortAvailable checks if the specified port is free to listen via tcp on host (blank host = all interfaces),
+// by listening on the host and port and immediately closing the listener upon success,
+// port 0 or port out of range returns false
+func IsPortAvailable(host string, port uint) bool {
+	if port == 0 {
+		return false
+	}
+
+	if l, e := GetNetListenerOn("tcp", host, port); e != nil {
+		return false
+	} else {
+		_ = l.Close()
+		return true
+	}
+}
+
+// GetFreePort returns a tcp port currently free to listen, as assigned by the os upon listening on port 0,
+// the listener is closed before return, so the port may be taken by another process before caller listens on it
+func GetFreePort() (uint, error) {
+	l, err := GetNetListenerEx("tcp", ":0")
+
+	if err != nil {
+		return 0, fmt.Errorf("Get Free Port Failed: %s", err)
+	}
+
+	port := GetListenerPort(l)
+	_ = l.Close()
+
+	if port == 0 {
+		return 0, fmt.Errorf("Get Free Port Failed: Assigned Port Not Determined")
+	}
+
+	return port, nil
+}
+
+// WaitForPortOpen dials host and port via tcp every pollInterval (default 100ms if 0 or less),
+// until the dial succeeds (nil is returned), or ctx is done (ctx.Err() is returned),
+// such as waiting for a dependent service to start listening, use context.WithTimeout to bound the wait
+func WaitForPortOpen(ctx context.Context, host string, port uint, pollInterval time.Duration) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if port == 0 || port > 65535 {
+		return fmt.Errorf("Wait For Port Open Failed: Port %d Must Be Within 1 to 65535", port)
+	}
+
+	if pollInterval <= 0 {
+		pollInterval = 100 * time.Millisecond
+	}
+
+	address := net.JoinHostPort(strings.TrimSpace(host), strconv.Itoa(int(port)))
+	dialer := &net.Dialer{}
+
+	for {
+		if conn, err := dialer.DialContext(ctx, "tcp", address); err == nil {
+			_ = conn.Close()
+			return nil
+		}
+
+		timer := time.NewTimer(pollInterval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// FindAvailablePort scans ports from startPort to endPort (inclusive) in order,
+// and returns the first port that is free to listen via tcp, error is returned if no port within range is available
+func FindAvailablePort(startPort uint, endPort uint) (uint, error) {
+	if startPort == 0 || endPort == 0 || startPort > 65535 || endPort > 65535 {
+		return 0, fmt.Errorf("Find Available Port Failed: Port Range %d to %d Must Be Within 1 to 65535", startPort, endPort)
+	}
+
+	if startPort > endPort {
+		return 0, fmt.Errorf("Find Available Port Failed: Start Port %d Greater Than End Port %d", startPort, endPort)
+	}
+
+	for p := startPort; p <= endPort; p++ {
+		if IsPortAvailable("", p) {
+			return p, nil
+		}
+	}
+
+	return 0, fmt.Errorf("Find Available Port Failed: No Port Available Within %d to %d", startPort, endPort)
+}
+
 // IsHttpsEndpoint returns true if url is https, false if otherwise
 func IsHttpsEndpoint(url string) bool {
 	return strings.ToLower(Left(url, 8)) == "https://"
 }
 
-// GetLocalIP returns the first non loopback ip
+// LocalIPOptions defines the optional address selection rules used by GetLocalIPs
+type LocalIPOptions struct {
+	// PreferIPv6 orders ipv6 addresses ahead of ipv4 addresses, by default ipv4 addresses are ordered first
+	PreferIPv6 bool
+
+	// InterfaceName restricts address selection to the named network interface only, such as eth0
+	InterfaceName string
+
+	// ExcludeInterfacePrefixes excludes network interfaces whose name begins with any of the given prefixes, such as docker, br-, veth
+	ExcludeInterfacePrefixes []string
+}
+
+// localInterfaceAddrs contains the network interface name and its assigned addresses
+type localInterfaceAddrs struct {
+	Name  string
+	Addrs []net.Addr
+}
+
+// GetLocalIP returns the first non loopback ip, ipv4 address is returned ahead of ipv6 address
 func GetLocalIP() string {
-	if addrs, err := net.InterfaceAddrs(); err != nil {
-		return ""
+	if ips := GetLocalIPs(); len(ips) > 0 {
+		return ips[0]
 	} else {
-		for _, a := range addrs {
-			if ip, ok := a.(*net.IPNet); ok && !ip.IP.IsLoopback() && !ip.IP.IsInterfaceLocalMulticast() && !ip.IP.IsLinkLocalMulticast() && !ip.IP.IsLinkLocalUnicast() && !ip.IP.IsMulticast() && !ip.IP.IsUnspecified() {
-				if ip.IP.To4() != nil {
-					return ip.IP.String()
-				}
+		return ""
+	}
+}
+
+// GetLocalIPv6 returns the first non loopback, non link local ipv6 address, blank is returned if no such ipv6 address is found
+func GetLocalIPv6() string {
+	for _, ip := range GetLocalIPs() {
+		if v := net.ParseIP(ip); v != nil && v.To4() == nil {
+			return ip
+		}
+	}
+
+	return ""
+}
+
+// GetLocalIPs returns all non loopback, non link local unicast ips (both ipv4 and ipv6) found on the host's network interfaces that are up,
+// by default ipv4 addresses are ordered ahead of ipv6 addresses,
+// opts is optional, to prefer ipv6, restrict to a named interface, or exclude interfaces by name prefix (such as docker or bridge interfaces)
+func GetLocalIPs(opts ...*LocalIPOptions) []string {
+	var opt *LocalIPOptions
+
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	ifaces, err := net.Interfaces()
+
+	if err != nil {
+		return []string{}
+	}
+
+	var list []localInterfaceAddrs
+
+	for _, iface := range ifaces {
+		if iface.Flags&net.FlagUp == 0 {
+			// interface is down
+			continue
+		}
+
+		if addrs, e := iface.Addrs(); e == nil {
+			list = append(list, localInterfaceAddrs{Name: iface.Name, Addrs: addrs})
+		}
+	}
+
+	return selectLocalIPs(list, opt)
+}
+
+// selectLocalIPs filters the given interface addresses per local ip rules and opt, and returns the qualified ips in preferred order
+func selectLocalIPs(ifaces []localInterfaceAddrs, opt *LocalIPOptions) []string {
+	if opt == nil {
+		opt = &LocalIPOptions{}
+	}
+
+	var ipv4List []string
+	var ipv6List []string
+
+	for _, iface := range ifaces {
+		if LenTrim(opt.InterfaceName) > 0 && iface.Name != Trim(opt.InterfaceName) {
+			continue
+		}
+
+		excluded := false
+
+		for _, prefix := range opt.ExcludeInterfacePrefixes {
+			if LenTrim(prefix) > 0 && strings.HasPrefix(strings.ToLower(iface.Name), strings.ToLower(Trim(prefix))) {
+				excluded = true
+				break
 			}
 		}
 
-		return ""
+		if excluded {
+			continue
+		}
+
+		for _, a := range iface.Addrs {
+			var ip net.IP
+
+			switch v := a.(type) {
+			case *net.IPNet:
+				ip = v.IP
+			case *net.IPAddr:
+				ip = v.IP
+			}
+
+			if ip == nil || ip.IsLoopback() || ip.IsInterfaceLocalMulticast() || ip.IsLinkLocalMulticast() || ip.IsLinkLocalUnicast() || ip.IsMulticast() || ip.IsUnspecified() {
+				continue
+			}
+
+			if ip.To4() != nil {
+				ipv4List = append(ipv4List, ip.String())
+			} else {
+				ipv6List = append(ipv6List, ip.String())
+			}
+		}
+	}
+
+	if opt.PreferIPv6 {
+		return append(ipv6List, ipv4List...)
+	} else {
+		return append(ipv4List, ipv6List...)
+	}
+}
+
+// privateIPNets contains rfc 1918 (ipv4) and rfc 4193 (ipv6 unique local) private address ranges
+var privateIPNets = func() []*net.IPNet {
+	list := []*net.IPNet{}
+
+	for _, v := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"} {
+		if _, n, err := net.ParseCIDR(v); err == nil {
+			list = append(list, n)
+		}
+	}
+
+	return list
+}()
+
+// parseIPAndCIDR parses ip address and cidr notation such as 10.0.0.0/8 or fd00::/8, error is returned if either is malformed
+func parseIPAndCIDR(ip string, cidr string) (net.IP, *net.IPNet, error) {
+	addr := net.ParseIP(strings.TrimSpace(ip))
+
+	if addr == nil {
+		return nil, nil, fmt.Errorf("IP '%s' is Not a Valid IP Address", ip)
 	}
+
+	_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("CIDR '%s' is Not Valid: %s", cidr, err)
+	}
+
+	return addr, ipNet, nil
+}
+
+// IsIPInCIDR returns true if ip (ipv4 or ipv6) is within cidr range, such as 192.168.1.10 in 192.168.1.0/24,
+// error is returned if ip or cidr is malformed
+func IsIPInCIDR(ip string, cidr string) (bool, error) {
+	addr, ipNet, err := parseIPAndCIDR(ip, cidr)
+
+	if err != nil {
+		return false, err
+	}
+
+	return ipNet.Contains(addr), nil
+}
+
+// IsIPInAnyCIDR returns true if ip (ipv4 or ipv6) is within any of the cidr ranges,
+// error is returned if ip or any cidr is malformed, all cidrs are validated even after a match is found
+func IsIPInAnyCIDR(ip string, cidrs []string) (bool, error) {
+	found := false
+
+	for _, cidr := range cidrs {
+		in, err := IsIPInCIDR(ip, cidr)
+
+		if err != nil {
+			return false, err
+		}
+
+		if in {
+			found = true
+		}
+	}
+
+	if len(cidrs) == 0 && net.ParseIP(strings.TrimSpace(ip)) == nil {
+		return false, fmt.Errorf("IP '%s' is Not a Valid IP Address", ip)
+	}
+
+	return found, nil
+}
+
+// IsPrivateIP returns true if ip is a private address (rfc 1918 for ipv4, rfc 4193 for ipv6),
+// loopback address (127.0.0.0/8, ::1), or link-local unicast address (169.254.0.0/16, fe80::/10),
+// false is returned if ip is public or not a valid ip address
+func IsPrivateIP(ip string) bool {
+	addr := net.ParseIP(strings.TrimSpace(ip))
+
+	if addr == nil {
+		return false
+	}
+
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() {
+		return true
+	}
+
+	for _, n := range privateIPNets {
+		if n.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CIDRToIPRange returns the first (network) and last (broadcast for ipv4) ip address of cidr range,
+// such as 192.168.1.0/24 returns 192.168.1.0 and 192.168.1.255, both ipv4 and ipv6 are supported,
+// error is returned if cidr is malformed
+func CIDRToIPRange(cidr string) (first string, last string, err error) {
+	_, ipNet, e := net.ParseCIDR(strings.TrimSpace(cidr))
+
+	if e != nil {
+		return "", "", fmt.Errorf("CIDR '%s' is Not Valid: %s", cidr, e)
+	}
+
+	start := ipNet.IP
+	end := make(net.IP, len(start))
+
+	for i := range start {
+		end[i] = start[i] | ^ipNet.Mask[i]
+	}
+
+	return start.String(), end.String(), nil
 }
 
 // DnsLookupIps returns list of IPs for the given host
 // if host is private on aws route 53, then lookup ip will work only when within given aws vpc that host was registered with
 func DnsLookupIps(host string) (ipList []net.IP) {
-	if ips, err := net.LookupIP(host); err != nil {
+	return DnsLookupIpsContext(context.Background(), host)
+}
+
+// DnsLookupIpsContext returns list of IPs for the given host, using ctx to enforce deadline or cancellation of the lookup
+// if host is private on aws route 53, then lookup ip will work only when within given aws vpc that host was registered with
+func DnsLookupIpsContext(ctx context.Context, host string) (ipList []net.IP) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host); err != nil {
 		return []net.IP{}
 	} else {
-		for _, ip := range ips {
-			ipList = append(ipList, ip)
+		for _, addr := range addrs {
+			ipList = append(ipList, addr.IP)
 		}
 		return ipList
 	}
 }
 
+// DnsLookupIpsBatch resolves IPs of hosts concurrently, using worker pool bounded by concurrency (1 if 0 or less),
+// ctx deadline or cancellation stops pending lookups, results are keyed by host,
+// hosts that failed to resolve (or not resolved before ctx is done) are excluded from results, duplicate hosts are resolved once
+func DnsLookupIpsBatch(ctx context.Context, hosts []string, concurrency int) map[string][]net.IP {
+	return dnsLookupIpsBatch(ctx, hosts, concurrency, DnsLookupIpsContext)
+}
+
+// dnsLookupIpsBatch performs DnsLookupIpsBatch using the given lookup func
+func dnsLookupIpsBatch(ctx context.Context, hosts []string, concurrency int, lookup func(ctx context.Context, host string) []net.IP) map[string][]net.IP {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	results := make(map[string][]net.IP)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan string)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for host := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+
+				if ips := lookup(ctx, host); len(ips) > 0 {
+					mu.Lock()
+					results[host] = ips
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+
+	for _, host := range hosts {
+		if host = strings.TrimSpace(host); len(host) == 0 || seen[host] {
+			continue
+		}
+
+		seen[host] = true
+
+		select {
+		case <-ctx.Done():
+		case jobs <- host:
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
 // DnsLookupSrvs returns list of IP and port addresses based on host
 // if host is private on aws route 53, then lookup ip will work only when within given aws vpc that host was registered with
 func DnsLookupSrvs(host string) (ipList []string) {
-	if _, addrs, err := net.LookupSRV("", "", host); err != nil {
+	return DnsLookupSrvsContext(context.Background(), host)
+}
+
+// DnsLookupSrvsContext returns list of IP and port addresses based on host, using ctx to enforce deadline or cancellation of the lookup
+// if host is private on aws route 53, then lookup ip will work only when within given aws vpc that host was registered with
+func DnsLookupSrvsContext(ctx context.Context, host string) (ipList []string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, addrs, err := net.DefaultResolver.LookupSRV(ctx, "", "", host); err != nil {
 		return []string{}
 	} else {
 		for _, v := range addrs {
@@ -87,41 +681,524 @@ func DnsLookupSrvs(host string) (ipList []string) {
 	}
 }
 
-// ParseHostFromURL will parse out the host name from url
-func ParseHostFromURL(url string) string {
-	parts := strings.Split(strings.ReplaceAll(strings.ReplaceAll(strings.ToLower(url), "https://", ""), "http://", ""), "/")
+// DnsLookupTxt returns list of TXT records for the given host, each record's strings are concatenated into one entry,
+// if host has no TXT records, empty slice and nil error is returned
+func DnsLookupTxt(host string) ([]string, error) {
+	return DnsLookupTxtContext(context.Background(), host)
+}
+
+// DnsLookupTxtContext returns list of TXT records for the given host, using ctx to enforce deadline or cancellation of the lookup,
+// each record's strings are concatenated into one entry, if host has no TXT records, empty slice and nil error is returned
+func DnsLookupTxtContext(ctx context.Context, host string) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if txts, err := net.DefaultResolver.LookupTXT(ctx, host); err != nil {
+		return []string{}, fmt.Errorf("DnsLookupTxt for '%s' Failed: %s", host, err)
+	} else if txts == nil {
+		return []string{}, nil
+	} else {
+		return txts, nil
+	}
+}
+
+// DnsLookupCNAME returns the canonical name for the given host
+func DnsLookupCNAME(host string) (string, error) {
+	return DnsLookupCNAMEContext(context.Background(), host)
+}
+
+// DnsLookupCNAMEContext returns the canonical name for the given host, using ctx to enforce deadline or cancellation of the lookup
+func DnsLookupCNAMEContext(ctx context.Context, host string) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if cname, err := net.DefaultResolver.LookupCNAME(ctx, host); err != nil {
+		return "", fmt.Errorf("DnsLookupCNAME for '%s' Failed: %s", host, err)
+	} else {
+		return cname, nil
+	}
+}
+
+// DnsLookupMX returns list of mail exchange records for the given host, formatted as host:preference, sorted by preference,
+// if host has no MX records, empty slice and nil error is returned
+func DnsLookupMX(host string) ([]string, error) {
+	return DnsLookupMXContext(context.Background(), host)
+}
 
-	if len(parts) >= 0 {
-		return parts[0]
+// DnsLookupMXContext returns list of mail exchange records for the given host, using ctx to enforce deadline or cancellation of the lookup,
+// formatted as host:preference, sorted by preference, if host has no MX records, empty slice and nil error is returned
+func DnsLookupMXContext(ctx context.Context, host string) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	mxs, err := net.DefaultResolver.LookupMX(ctx, host)
+
+	if err != nil {
+		return []string{}, fmt.Errorf("DnsLookupMX for '%s' Failed: %s", host, err)
+	}
+
+	sort.SliceStable(mxs, func(i, j int) bool {
+		return mxs[i].Pref < mxs[j].Pref
+	})
+
+	mxList := []string{}
+
+	for _, v := range mxs {
+		mxList = append(mxList, fmt.Sprintf("%s:%d", v.Host, v.Pref))
+	}
+
+	return mxList, nil
+}
+
+// parseURL parses rawUrl via url.Parse, if rawUrl has no scheme (such as example.com:8080/path), it is parsed as if prefixed with //,
+// so that host, port and path are properly recognized
+func parseURL(rawUrl string) (*url.URL, error) {
+	rawUrl = Trim(rawUrl)
+
+	if len(rawUrl) == 0 {
+		return nil, fmt.Errorf("URL is Required")
+	}
+
+	if !strings.Contains(rawUrl, "://") && !strings.HasPrefix(rawUrl, "//") {
+		rawUrl = "//" + rawUrl
+	}
+
+	if u, err := url.Parse(rawUrl); err != nil {
+		return nil, fmt.Errorf("URL '%s' Not Valid: %s", rawUrl, err)
+	} else {
+		return u, nil
+	}
+}
+
+// ParseHostFromURL will parse out the host name from url (in lower case, excluding userinfo and port),
+// IPv6 host such as [::1]:8080 returns ::1 without brackets, blank is returned if url is not valid
+func ParseHostFromURL(url string) string {
+	if u, err := parseURL(url); err != nil {
+		return ""
 	} else {
+		return strings.ToLower(u.Hostname())
+	}
+}
+
+// ParsePortFromURL will parse out the port number from url,
+// 0 is returned if url does not specify port, error is returned if url or its port is not valid
+func ParsePortFromURL(url string) (uint, error) {
+	_, port, err := ParseHostPortFromURL(url)
+	return port, err
+}
+
+// ParseHostPortFromURL will parse out both host name (in lower case, excluding userinfo) and port number from url,
+// IPv6 host such as [::1]:8080 returns ::1 without brackets,
+// port 0 is returned if url does not specify port, error is returned if url or its port is not valid
+func ParseHostPortFromURL(url string) (host string, port uint, err error) {
+	u, e := parseURL(url)
+
+	if e != nil {
+		return "", 0, e
+	}
+
+	host = strings.ToLower(u.Hostname())
+
+	if p := u.Port(); len(p) > 0 {
+		if port, err = ParsePort(p); err != nil {
+			return "", 0, err
+		}
+	}
+
+	return host, port, nil
+}
+
+// ParseURLParts will parse url into its scheme (lower case, blank if url lacks scheme), host (lower case, excluding userinfo and port),
+// port (blank if url does not specify port), and path (excluding query and fragment),
+// such as https://user:pw@example.com:8443/a?b=c returns https, example.com, 8443, /a,
+// IPv6 host such as [::1]:8080 returns ::1 without brackets, error is returned if url or its port is not valid
+func ParseURLParts(raw string) (scheme string, host string, port string, path string, err error) {
+	u, e := parseURL(raw)
+
+	if e != nil {
+		return "", "", "", "", e
+	}
+
+	if port = u.Port(); len(port) > 0 {
+		if _, err = ParsePort(port); err != nil {
+			return "", "", "", "", err
+		}
+	}
+
+	return strings.ToLower(u.Scheme), strings.ToLower(u.Hostname()), port, u.Path, nil
+}
+
+// ParsePathFromURL will parse out the path from url (excluding query and fragment), such as /api/v1/items,
+// blank is returned if url has no path or is not valid
+func ParsePathFromURL(url string) string {
+	if u, err := parseURL(url); err != nil {
 		return ""
+	} else {
+		return u.Path
+	}
+}
+
+// ParseQuerySemicolon parses query string into url.Values, same as url.ParseQuery,
+// except both & and ; are treated as key value pair separators (legacy query string form, such as a=1;b=2&c=3),
+// leading ? is ignored, parsing continues past malformed pairs, and the first escape error encountered is returned
+func ParseQuerySemicolon(query string) (url.Values, error) {
+	values := make(url.Values)
+	var err error
+
+	query = strings.TrimPrefix(Trim(query), "?")
+
+	for _, pair := range strings.FieldsFunc(query, func(r rune) bool { return r == '&' || r == ';' }) {
+		key, value := pair, ""
+
+		if i := strings.Index(pair, "="); i >= 0 {
+			key, value = pair[:i], pair[i+1:]
+		}
+
+		k, e := url.QueryUnescape(key)
+
+		if e != nil {
+			if err == nil {
+				err = fmt.Errorf("Parse Query Key '%s' Failed: %s", key, e)
+			}
+
+			continue
+		}
+
+		v, e := url.QueryUnescape(value)
+
+		if e != nil {
+			if err == nil {
+				err = fmt.Errorf("Parse Query Value '%s' Failed: %s", value, e)
+			}
+
+			continue
+		}
+
+		values.Add(k, v)
 	}
+
+	return values, err
+}
+
+// ParseByteRange parses http Range header value (single range only) into inclusive start and end byte offsets,
+// validated against totalSize of the content being served, supported forms are:
+//		1) bytes=start-end		// from start to end inclusive, end beyond content is trimmed to last byte
+//		2) bytes=start-			// from start to last byte
+//		3) bytes=-suffix		// last suffix number of bytes
+// error is returned if header is malformed, or the range is unsatisfiable for totalSize
+func ParseByteRange(header string, totalSize int64) (start int64, end int64, err error) {
+	if totalSize <= 0 {
+		return 0, 0, fmt.Errorf("Byte Range Not Satisfiable: Total Size Must Be Greater Than Zero")
+	}
+
+	header = strings.TrimSpace(header)
+
+	if !strings.HasPrefix(strings.ToLower(header), "bytes=") {
+		return 0, 0, fmt.Errorf("Byte Range Malformed: Expects 'bytes=' Prefix")
+	}
+
+	spec := strings.TrimSpace(header[6:])
+
+	if strings.Contains(spec, ",") {
+		return 0, 0, fmt.Errorf("Byte Range Malformed: Multiple Ranges Not Supported")
+	}
+
+	pos := strings.Index(spec, "-")
+
+	if pos < 0 {
+		return 0, 0, fmt.Errorf("Byte Range Malformed: Expects '-' Separator")
+	}
+
+	startStr := strings.TrimSpace(spec[:pos])
+	endStr := strings.TrimSpace(spec[pos+1:])
+
+	if len(startStr) == 0 {
+		// suffix range, last n bytes
+		suffix, e := strconv.ParseInt(endStr, 10, 64)
+
+		if e != nil || suffix < 0 {
+			return 0, 0, fmt.Errorf("Byte Range Malformed: Suffix Length '%s' Invalid", endStr)
+		}
+
+		if suffix == 0 {
+			return 0, 0, fmt.Errorf("Byte Range Not Satisfiable: Suffix Length Is Zero")
+		}
+
+		if suffix > totalSize {
+			suffix = totalSize
+		}
+
+		return totalSize - suffix, totalSize - 1, nil
+	}
+
+	if start, err = strconv.ParseInt(startStr, 10, 64); err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("Byte Range Malformed: Start '%s' Invalid", startStr)
+	}
+
+	if start >= totalSize {
+		return 0, 0, fmt.Errorf("Byte Range Not Satisfiable: Start %d Beyond Total Size %d", start, totalSize)
+	}
+
+	if len(endStr) == 0 {
+		return start, totalSize - 1, nil
+	}
+
+	if end, err = strconv.ParseInt(endStr, 10, 64); err != nil || end < 0 {
+		return 0, 0, fmt.Errorf("Byte Range Malformed: End '%s' Invalid", endStr)
+	}
+
+	if end < start {
+		return 0, 0, fmt.Errorf("Byte Range Malformed: End %d Less Than Start %d", end, start)
+	}
+
+	if end >= totalSize {
+		end = totalSize - 1
+	}
+
+	return start, end, nil
+}
+
+// ParseLinkHeader parses http Link header value (RFC 5988) into map of rel to url,
+// such as <https://api/items?page=3>; rel="next", <https://api/items?page=9>; rel="last",
+// multiple comma separated links are supported, rel value may be quoted or unquoted,
+// rel containing multiple space separated values (rel="next last") maps each value to the same url,
+// rel keys are lowercased, links without rel are ignored, first occurrence of a rel wins
+func ParseLinkHeader(header string) map[string]string {
+	result := make(map[string]string)
+
+	for _, link := range splitLinkHeader(header) {
+		link = strings.TrimSpace(link)
+
+		if !strings.HasPrefix(link, "<") {
+			continue
+		}
+
+		pos := strings.Index(link, ">")
+
+		if pos < 0 {
+			continue
+		}
+
+		linkUrl := strings.TrimSpace(link[1:pos])
+		rels := ""
+
+		for _, param := range splitLinkParams(link[pos+1:]) {
+			kv := strings.SplitN(param, "=", 2)
+
+			if len(kv) != 2 || strings.ToLower(strings.TrimSpace(kv[0])) != "rel" {
+				continue
+			}
+
+			rels = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+			break
+		}
+
+		for _, rel := range strings.Fields(rels) {
+			rel = strings.ToLower(rel)
+
+			if _, ok := result[rel]; !ok {
+				result[rel] = linkUrl
+			}
+		}
+	}
+
+	return result
+}
+
+// splitLinkHeader splits link header value by comma, ignoring commas within <url> or quoted values
+func splitLinkHeader(header string) []string {
+	return splitOutsideQuotes(header, ',', true)
+}
+
+// splitLinkParams splits link params portion (following the <url>) by semi-colon, ignoring semi-colons within quoted values
+func splitLinkParams(params string) []string {
+	output := []string{}
+
+	for _, v := range splitOutsideQuotes(params, ';', false) {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			output = append(output, v)
+		}
+	}
+
+	return output
+}
+
+// splitOutsideQuotes splits s by sep, ignoring sep within double quotes, and within angle brackets if angleBrackets is true
+func splitOutsideQuotes(s string, sep rune, angleBrackets bool) []string {
+	output := []string{}
+	buf := strings.Builder{}
+	inQuote := false
+	inAngle := false
+
+	for _, c := range s {
+		switch {
+		case c == '"' && !inAngle:
+			inQuote = !inQuote
+		case c == '<' && angleBrackets && !inQuote:
+			inAngle = true
+		case c == '>' && angleBrackets && !inQuote:
+			inAngle = false
+		case c == sep && !inQuote && !inAngle:
+			output = append(output, buf.String())
+			buf.Reset()
+			continue
+		}
+
+		buf.WriteRune(c)
+	}
+
+	if buf.Len() > 0 {
+		output = append(output, buf.String())
+	}
+
+	return output
+}
+
+// ReCAPTCHAVerifyOptions defines the verify call behavior used by VerifyGoogleReCAPTCHAv2WithContext
+type ReCAPTCHAVerifyOptions struct {
+	// http timeout enforced on each verify attempt to google server (0 = no timeout)
+	Timeout time.Duration
+
+	// number of retries after the first attempt, retry only occurs on network error or http 5xx (0 = no retry)
+	RetryCount int
+
+	// wait duration between attempts (0 = retry immediately)
+	Backoff time.Duration
+
+	// optional end user ip address passed to google for verification (must be valid ip if specified)
+	RemoteIp string
+
+	// optional http client used to call google server, such as one with custom transport, proxy or resolver (nil = rest package client)
+	HttpClient *http.Client
 }
 
 // VerifyGoogleReCAPTCHAv2 will verify recaptcha v2 response data against given secret and obtain a response from google server
 func VerifyGoogleReCAPTCHAv2(response string, secret string) (success bool, challengeTs time.Time, hostName string, err error) {
+	return VerifyGoogleReCAPTCHAv2WithContext(context.Background(), response, secret, nil)
+}
+
+// VerifyGoogleReCAPTCHAv2Ex will verify recaptcha v2 response data against given secret and obtain a response from google server,
+// remoteIp = optional end user ip address passed to google for verification (must be valid ip if specified),
+// timeout = http timeout enforced on the verify call to google server (0 = no timeout)
+func VerifyGoogleReCAPTCHAv2Ex(response string, secret string, remoteIp string, timeout time.Duration) (success bool, challengeTs time.Time, hostName string, err error) {
+	return VerifyGoogleReCAPTCHAv2WithContext(context.Background(), response, secret, &ReCAPTCHAVerifyOptions{
+		Timeout:  timeout,
+		RemoteIp: remoteIp,
+	})
+}
+
+// VerifyGoogleReCAPTCHAv2WithRetry will verify recaptcha v2 response data against given secret and obtain a response from google server,
+// retrying the verify call up to attempts times (minimum 1), waiting backoff duration between attempts,
+// retry only occurs on network error or http 5xx status code from google server,
+// definitive verify result (such as success = false with error codes) is returned without retry
+func VerifyGoogleReCAPTCHAv2WithRetry(response string, secret string, attempts int, backoff time.Duration) (success bool, challengeTs time.Time, hostName string, err error) {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return VerifyGoogleReCAPTCHAv2WithContext(context.Background(), response, secret, &ReCAPTCHAVerifyOptions{
+		RetryCount: attempts - 1,
+		Backoff:    backoff,
+	})
+}
+
+// VerifyGoogleReCAPTCHAv2WithContext will verify recaptcha v2 response data against given secret and obtain a response from google server,
+// the secret and response are sent to google server as post form body,
+// ctx = cancels the verify call and any pending retry when done (nil = context.Background),
+// options = timeout, retry and remote ip settings (nil = single attempt without timeout),
+// retry only occurs on network error or http 5xx status code from google server, http 4xx and definitive verify results are returned without retry,
+// when all attempts fail, the last error is returned wrapped with the attempt count
+func VerifyGoogleReCAPTCHAv2WithContext(ctx context.Context, response string, secret string, options *ReCAPTCHAVerifyOptions) (success bool, challengeTs time.Time, hostName string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if options == nil {
+		options = &ReCAPTCHAVerifyOptions{}
+	}
+
+	attempts := options.RetryCount + 1
+
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	retryable := false
+
+	for i := 1; i <= attempts; i++ {
+		if success, challengeTs, hostName, retryable, err = verifyGoogleReCAPTCHAv2(ctx, options.HttpClient, response, secret, options.RemoteIp, options.Timeout); err == nil || !retryable {
+			return success, challengeTs, hostName, err
+		}
+
+		if attempts == 1 {
+			return false, time.Time{}, "", err
+		}
+
+		if i < attempts {
+			if ctx.Err() != nil {
+				return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Verify Failed After %d Attempts: %w", i, err)
+			}
+
+			if options.Backoff > 0 {
+				select {
+				case <-ctx.Done():
+					return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Verify Failed After %d Attempts: %w", i, err)
+				case <-time.After(options.Backoff):
+				}
+			}
+		}
+	}
+
+	return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Verify Failed After %d Attempts: %w", attempts, err)
+}
+
+// verifyGoogleReCAPTCHAv2 performs a single recaptcha v2 verify call to google server, see VerifyGoogleReCAPTCHAv2WithContext,
+// client = optional http client used for the verify call (nil = rest package client),
+// retryable is true when err is due to network error or http 5xx status code, which may succeed upon retry
+func verifyGoogleReCAPTCHAv2(ctx context.Context, client *http.Client, response string, secret string, remoteIp string, timeout time.Duration) (success bool, challengeTs time.Time, hostName string, retryable bool, err error) {
 	if LenTrim(response) == 0 {
-		return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Response From CLient is Required")
+		return false, time.Time{}, "", false, fmt.Errorf("ReCAPTCHA Response From CLient is Required")
 	}
 
 	if LenTrim(secret) == 0 {
-		return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Secret Key is Required")
+		return false, time.Time{}, "", false, fmt.Errorf("ReCAPTCHA Secret Key is Required")
+	}
+
+	remoteIp = strings.TrimSpace(remoteIp)
+
+	if len(remoteIp) > 0 && net.ParseIP(remoteIp) == nil {
+		return false, time.Time{}, "", false, fmt.Errorf("ReCAPTCHA Remote IP '%s' is Not a Valid IP Address", remoteIp)
 	}
 
-	u := fmt.Sprintf("https://www.google.com/recaptcha/api/siteverify?secret=%s&response=%s", url.PathEscape(secret), url.PathEscape(response))
+	form := url.Values{}
+	form.Set("secret", secret)
+	form.Set("response", response)
 
-	if statusCode, responseBody, e := rest.POST(u, []*rest.HeaderKeyValue{}, ""); e != nil {
-		return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Service Failed: %s", e)
+	if len(remoteIp) > 0 {
+		form.Set("remoteip", remoteIp)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if statusCode, responseBody, e := postGoogleReCAPTCHAv2(ctx, client, form.Encode()); e != nil {
+		return false, time.Time{}, "", statusCode >= 500, fmt.Errorf("ReCAPTCHA Service Failed: %w", e)
 	} else {
 		if statusCode != 200 {
-			return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Service Failed: Status Code %d", statusCode)
+			return false, time.Time{}, "", statusCode >= 500, fmt.Errorf("ReCAPTCHA Service Failed: Status Code %d", statusCode)
 		} else {
 			m := make(map[string]json.RawMessage)
 			if err = json.Unmarshal([]byte(responseBody), &m); err != nil {
-				return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Service Response Failed: (Parse Json Response Error) %s", err)
+				return false, time.Time{}, "", false, fmt.Errorf("ReCAPTCHA Service Response Failed: (Parse Json Response Error) %s", err)
 			} else {
 				if m == nil {
-					return false, time.Time{}, "", fmt.Errorf("ReCAPTCHA Service Response Failed: %s", "Json Response Map Nil")
+					return false, time.Time{}, "", false, fmt.Errorf("ReCAPTCHA Service Response Failed: %s", "Json Response Map Nil")
 				} else {
 					// response json from google is valid
 					if strings.ToLower(string(m["success"])) == "true" {
@@ -154,13 +1231,50 @@ func VerifyGoogleReCAPTCHAv2(response string, secret string) (success bool, chal
 						}
 					}
 
-					return success, challengeTs, hostName, err
+					return success, challengeTs, hostName, false, err
 				}
 			}
 		}
 	}
 }
 
+// postGoogleReCAPTCHAv2 posts form body to google recaptcha verify endpoint, using client if given, otherwise rest.POSTWithContext,
+// network error yields status code 500 (same as rest package), so that it is treated as retryable
+func postGoogleReCAPTCHAv2(ctx context.Context, client *http.Client, form string) (statusCode int, responseBody string, err error) {
+	const verifyUrl = "https://www.google.com/recaptcha/api/siteverify"
+
+	if client == nil {
+		return rest.POSTWithContext(ctx, verifyUrl, []*rest.HeaderKeyValue{}, form)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", verifyUrl, strings.NewReader(form))
+
+	if err != nil {
+		return 0, "", fmt.Errorf("Create New Http Post Request Failed: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return 500, "", fmt.Errorf("[500 - Http Post Error] %w", err)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return resp.StatusCode, "", err
+	}
+
+	if resp.StatusCode != 200 {
+		return resp.StatusCode, "", fmt.Errorf("[%d - Post Resp] %s", resp.StatusCode, string(body))
+	}
+
+	return resp.StatusCode, string(body), nil
+}
+
 // ReadHttpRequestBody reads raw body from http request body object,
 // and then sets the read body back to the request (once reading will remove the body content if not restored)
 func ReadHttpRequestBody(req *http.Request) ([]byte, error) {
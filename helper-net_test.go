@@ -0,0 +1,435 @@
+package helper
+
+/*
+ * Copyright 2020-2021 Aldelo, LP
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIsPortAvailable_HostAndGetFreePort(t *testing.T) {
+	port, err := GetFreePort()
+
+	if err != nil {
+		t.Fatalf("GetFreePort failed: %s", err)
+	}
+
+	if port == 0 || port > 65535 {
+		t.Fatalf("GetFreePort returned invalid port %d", port)
+	}
+
+	if !IsPortAvailable("127.0.0.1", port) {
+		t.Fatalf("expected port %d to be available on 127.0.0.1", port)
+	}
+
+	l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(port))))
+
+	if err != nil {
+		t.Fatalf("listen on port %d failed: %s", port, err)
+	}
+
+	defer l.Close()
+
+	if IsPortAvailable("127.0.0.1", port) {
+		t.Fatalf("expected port %d held by listener to be unavailable", port)
+	}
+
+	if IsPortAvailable("127.0.0.1", 0) {
+		t.Fatalf("expected port 0 to be unavailable")
+	}
+}
+
+func TestGetNetListenerEx_ErrorKeepsNetworkName(t *testing.T) {
+	if _, err := GetNetListenerEx("tcp6", "127.0.0.1"); err == nil || !strings.Contains(err.Error(), "Listen tcp6 on") {
+		t.Fatalf("expected error naming network tcp6, got %v", err)
+	}
+
+	if _, err := GetNetListenerEx("unix", ""); err == nil || !strings.Contains(err.Error(), "Listen unix") {
+		t.Fatalf("expected error naming network unix, got %v", err)
+	}
+}
+
+// redirectTransport sends every request to target server, used to stand in for google recaptcha server
+type redirectTransport struct {
+	target *url.URL
+	err    error
+}
+
+func (rt *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if rt.err != nil {
+		return nil, rt.err
+	}
+
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestVerifyGoogleReCAPTCHAv2_RetryTransientFailure(t *testing.T) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.FormValue("secret") != "s" || r.FormValue("response") != "r" || len(r.URL.RawQuery) > 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"success": true, "hostname": "example.com"}`))
+	}))
+	defer srv.Close()
+
+	target, _ := url.Parse(srv.URL)
+	client := &http.Client{Transport: &redirectTransport{target: target}}
+
+	success, _, _, err := VerifyGoogleReCAPTCHAv2WithContext(context.Background(), "r", "s", &ReCAPTCHAVerifyOptions{RetryCount: 2, Backoff: time.Millisecond, HttpClient: client})
+
+	if err != nil || !success {
+		t.Fatalf("expected success after transient failure, got %v, %v", success, err)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Fatalf("expected 2 verify calls, got %d", n)
+	}
+}
+
+func TestVerifyGoogleReCAPTCHAv2_FailureWrapsLastError(t *testing.T) {
+	errDown := errors.New("resolver down")
+	client := &http.Client{Transport: &redirectTransport{err: errDown}}
+
+	_, _, _, err := VerifyGoogleReCAPTCHAv2WithContext(context.Background(), "r", "s", &ReCAPTCHAVerifyOptions{RetryCount: 2, HttpClient: client})
+
+	if err == nil || !strings.Contains(err.Error(), "After 3 Attempts") {
+		t.Fatalf("expected failure after 3 attempts, got %v", err)
+	}
+
+	var urlErr *url.Error
+
+	if !errors.Is(err, errDown) || !errors.As(err, &urlErr) {
+		t.Fatalf("expected last error wrapped, got %v", err)
+	}
+}
+
+func TestDnsLookupIpsBatch_FakeResolverBoundedConcurrency(t *testing.T) {
+	var active, maxActive, calls int32
+
+	lookup := func(ctx context.Context, host string) []net.IP {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxActive)
+
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		if strings.HasPrefix(host, "bad") {
+			return nil
+		}
+
+		return []net.IP{net.ParseIP("10.0.0." + strings.TrimPrefix(host, "h"))}
+	}
+
+	hosts := []string{"h1", "h2", "h3", "h4", "h5", "h6", "bad1", "h1", " ", "h2"}
+	results := dnsLookupIpsBatch(context.Background(), hosts, 3, lookup)
+
+	if len(results) != 6 {
+		t.Fatalf("expected 6 resolved hosts, got %d: %v", len(results), results)
+	}
+
+	if ips := results["h4"]; len(ips) != 1 || !ips[0].Equal(net.ParseIP("10.0.0.4")) {
+		t.Fatalf("unexpected ips for h4: %v", ips)
+	}
+
+	if _, ok := results["bad1"]; ok {
+		t.Fatalf("failed host expected excluded from results")
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 7 {
+		t.Fatalf("expected duplicate hosts resolved once, got %d lookups", n)
+	}
+
+	if m := atomic.LoadInt32(&maxActive); m > 3 || m < 2 {
+		t.Fatalf("expected concurrency bounded by 3 and used, got %d", m)
+	}
+}
+
+func TestDnsLookupIpsBatch_CancelReturnsPartialResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lookup := func(ctx context.Context, host string) []net.IP {
+		if host == "slow" {
+			cancel()
+			<-ctx.Done()
+			return nil
+		}
+
+		return []net.IP{net.ParseIP("10.0.0.1")}
+	}
+
+	results := dnsLookupIpsBatch(ctx, []string{"fast", "slow", "after1", "after2"}, 1, lookup)
+
+	if len(results) != 1 || results["fast"] == nil {
+		t.Fatalf("expected only hosts resolved before cancel, got %v", results)
+	}
+}
+
+func TestParseLinkHeader_MultipleRels(t *testing.T) {
+	header := `<https://api.example.com/items?page=2&per=10,20>; rel="next", <https://api.example.com/items?page=9>; rel=last, ` +
+		`<https://api.example.com/items?page=1>; title="a, b"; rel="first prev", <https://api.example.com/nowhere>`
+
+	links := ParseLinkHeader(header)
+
+	want := map[string]string{
+		"next":  "https://api.example.com/items?page=2&per=10,20",
+		"last":  "https://api.example.com/items?page=9",
+		"first": "https://api.example.com/items?page=1",
+		"prev":  "https://api.example.com/items?page=1",
+	}
+
+	if len(links) != len(want) {
+		t.Fatalf("expected %d rels, got %v", len(want), links)
+	}
+
+	for rel, u := range want {
+		if links[rel] != u {
+			t.Fatalf("rel %s expected %s, got %s", rel, u, links[rel])
+		}
+	}
+
+	if len(ParseLinkHeader("")) != 0 {
+		t.Fatalf("expected no rels for blank header")
+	}
+}
+
+func TestParseURLParts_Table(t *testing.T) {
+	cases := []struct {
+		raw                      string
+		scheme, host, port, path string
+		wantErr                  bool
+	}{
+		{"https://user:pw@example.com:8443/a?b=c", "https", "example.com", "8443", "/a", false},
+		{"example.com:8080/path", "", "example.com", "8080", "/path", false},
+		{"wss://Chat.Example.com/socket", "wss", "chat.example.com", "", "/socket", false},
+		{"http://[::1]:8080/x", "http", "::1", "8080", "/x", false},
+		{"[2001:db8::1]:443", "", "2001:db8::1", "443", "", false},
+		{"http://[fe80::1]/", "http", "fe80::1", "", "/", false},
+		{"https://example.com:99999/", "", "", "", "", true},
+		{"", "", "", "", "", true},
+	}
+
+	for _, c := range cases {
+		scheme, host, port, path, err := ParseURLParts(c.raw)
+
+		if (err != nil) != c.wantErr {
+			t.Fatalf("%q: unexpected error state %v", c.raw, err)
+		}
+
+		if scheme != c.scheme || host != c.host || port != c.port || path != c.path {
+			t.Fatalf("%q: got %q %q %q %q, want %q %q %q %q", c.raw, scheme, host, port, path, c.scheme, c.host, c.port, c.path)
+		}
+
+		if !c.wantErr && ParseHostFromURL(c.raw) != c.host {
+			t.Fatalf("%q: ParseHostFromURL got %q, want %q", c.raw, ParseHostFromURL(c.raw), c.host)
+		}
+	}
+}
+
+func TestCIDRHelpers_BoundaryAddresses(t *testing.T) {
+	first, last, err := CIDRToIPRange("192.168.1.77/24")
+
+	if err != nil || first != "192.168.1.0" || last != "192.168.1.255" {
+		t.Fatalf("ipv4 range got %s - %s, %v", first, last, err)
+	}
+
+	if first, last, err = CIDRToIPRange("2001:db8::/126"); err != nil || first != "2001:db8::" || last != "2001:db8::3" {
+		t.Fatalf("ipv6 range got %s - %s, %v", first, last, err)
+	}
+
+	cases := []struct {
+		ip, cidr string
+		want     bool
+	}{
+		{"192.168.1.0", "192.168.1.0/24", true},
+		{"192.168.1.255", "192.168.1.0/24", true},
+		{"192.168.0.255", "192.168.1.0/24", false},
+		{"192.168.2.0", "192.168.1.0/24", false},
+		{"2001:db8::3", "2001:db8::/126", true},
+		{"2001:db8::4", "2001:db8::/126", false},
+	}
+
+	for _, c := range cases {
+		if in, err := IsIPInCIDR(c.ip, c.cidr); err != nil || in != c.want {
+			t.Fatalf("IsIPInCIDR(%s, %s) got %v, %v", c.ip, c.cidr, in, err)
+		}
+	}
+
+	if _, err := IsIPInCIDR("300.1.1.1", "10.0.0.0/8"); err == nil {
+		t.Fatalf("expected error for malformed ip")
+	}
+
+	if _, err := IsIPInAnyCIDR("10.0.0.1", []string{"10.0.0.0/8", "bad"}); err == nil {
+		t.Fatalf("expected error for malformed cidr")
+	}
+
+	if in, err := IsIPInAnyCIDR("172.16.0.1", []string{"10.0.0.0/8", "172.16.0.0/12"}); err != nil || !in {
+		t.Fatalf("IsIPInAnyCIDR got %v, %v", in, err)
+	}
+
+	for ip, want := range map[string]bool{"10.0.0.1": true, "172.31.255.255": true, "172.32.0.0": false, "fd00::1": true, "::1": true, "fe80::1": true, "8.8.8.8": false, "bad": false} {
+		if IsPrivateIP(ip) != want {
+			t.Fatalf("IsPrivateIP(%s) expected %v", ip, want)
+		}
+	}
+}
+
+func TestParsePort_ValidZeroNegativeAndOutOfRange(t *testing.T) {
+	if p, err := ParsePort(" 8080 "); err != nil || p != 8080 {
+		t.Fatalf("expected 8080, got %d, %v", p, err)
+	}
+
+	if p, err := ParsePort("65535"); err != nil || p != 65535 {
+		t.Fatalf("expected 65535, got %d, %v", p, err)
+	}
+
+	for _, s := range []string{"0", "-1", "70000", "", "80a"} {
+		if p, err := ParsePort(s); err == nil || p != 0 {
+			t.Fatalf("expected error for port %q, got %d", s, p)
+		}
+	}
+}
+
+func TestParseByteRange_EachForm(t *testing.T) {
+	cases := []struct {
+		header     string
+		start, end int64
+	}{
+		{"bytes=0-499", 0, 499},
+		{"bytes=500-2000", 500, 999},
+		{"bytes=900-", 900, 999},
+		{"bytes=-100", 900, 999},
+		{"bytes=-5000", 0, 999},
+	}
+
+	for _, c := range cases {
+		if start, end, err := ParseByteRange(c.header, 1000); err != nil || start != c.start || end != c.end {
+			t.Fatalf("%s expected %d-%d, got %d-%d, %v", c.header, c.start, c.end, start, end, err)
+		}
+	}
+
+	for _, h := range []string{"bytes=1000-", "bytes=-0", "bytes=5-2", "items=0-1", "bytes=0-1,5-6", "bytes=a-b"} {
+		if _, _, err := ParseByteRange(h, 1000); err == nil {
+			t.Fatalf("%s expected error", h)
+		}
+	}
+}
+
+func TestSelectLocalIPs_IPv6AndInterfaceFiltering(t *testing.T) {
+	ipNet := func(s string) net.Addr {
+		return &net.IPNet{IP: net.ParseIP(s), Mask: net.CIDRMask(64, 128)}
+	}
+
+	ifaces := []localInterfaceAddrs{
+		{Name: "lo", Addrs: []net.Addr{ipNet("127.0.0.1"), ipNet("::1")}},
+		{Name: "eth0", Addrs: []net.Addr{ipNet("fe80::1"), ipNet("2001:db8::10"), ipNet("10.0.0.5")}},
+		{Name: "docker0", Addrs: []net.Addr{ipNet("172.17.0.1")}},
+		{Name: "eth1", Addrs: []net.Addr{&net.IPAddr{IP: net.ParseIP("192.168.1.20")}, ipNet("ff02::1")}},
+	}
+
+	equal := func(got []string, want ...string) bool {
+		if len(got) != len(want) {
+			return false
+		}
+
+		for i := range got {
+			if got[i] != want[i] {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if got := selectLocalIPs(ifaces, nil); !equal(got, "10.0.0.5", "172.17.0.1", "192.168.1.20", "2001:db8::10") {
+		t.Fatalf("default selection got %v", got)
+	}
+
+	if got := selectLocalIPs(ifaces, &LocalIPOptions{PreferIPv6: true}); !equal(got, "2001:db8::10", "10.0.0.5", "172.17.0.1", "192.168.1.20") {
+		t.Fatalf("prefer ipv6 selection got %v", got)
+	}
+
+	if got := selectLocalIPs(ifaces, &LocalIPOptions{InterfaceName: "eth0"}); !equal(got, "10.0.0.5", "2001:db8::10") {
+		t.Fatalf("interface eth0 selection got %v", got)
+	}
+
+	if got := selectLocalIPs(ifaces, &LocalIPOptions{ExcludeInterfacePrefixes: []string{"Docker", "eth1"}}); !equal(got, "10.0.0.5", "2001:db8::10") {
+		t.Fatalf("excluded prefix selection got %v", got)
+	}
+}
+
+func TestParseQuerySemicolon_MixedSeparators(t *testing.T) {
+	v, err := ParseQuerySemicolon("?a=1;b=2&c=3;a=x%20y&&;flag")
+
+	if err != nil {
+		t.Fatalf("ParseQuerySemicolon failed: %v", err)
+	}
+
+	if got := v["a"]; len(got) != 2 || got[0] != "1" || got[1] != "x y" {
+		t.Fatalf("a = %v, want [1 x y]", got)
+	}
+
+	if v.Get("b") != "2" || v.Get("c") != "3" {
+		t.Fatalf("b = %q, c = %q, want 2 and 3", v.Get("b"), v.Get("c"))
+	}
+
+	if got, ok := v["flag"]; !ok || len(got) != 1 || got[0] != "" {
+		t.Fatalf("flag = %v (present %v), want single blank value", got, ok)
+	}
+
+	if len(v) != 4 {
+		t.Fatalf("got %d keys %v, want 4", len(v), v)
+	}
+
+	v, err = ParseQuerySemicolon("a=1;b=%zz&c=3")
+
+	if err == nil {
+		t.Fatalf("expected escape error for b=%%zz")
+	}
+
+	if v.Get("a") != "1" || v.Get("c") != "3" || v.Get("b") != "" {
+		t.Fatalf("parsing should continue past malformed pair, got %v", v)
+	}
+}
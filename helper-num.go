@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -186,6 +187,21 @@ func ParseBool(s string) (bool, bool) {
 	return result, true
 }
 
+// ParseBoolExtended parses input string as boolean literal, case insensitive,
+// true forms: true, t, yes, y, on, 1, enabled,
+// false forms: false, f, no, n, off, 0, disabled,
+// error is returned if input string is not a recognized boolean literal (including blank)
+func ParseBoolExtended(s string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "t", "yes", "y", "on", "1", "enabled":
+		return true, nil
+	case "false", "f", "no", "n", "off", "0", "disabled":
+		return false, nil
+	default:
+		return false, fmt.Errorf("Bool Literal '%s' Not Recognized", s)
+	}
+}
+
 // ExponentialToNumber converts exponential representation of a number into actual number equivalent
 func ExponentialToNumber(exp string) string {
 	if strings.Index(strings.ToLower(exp), "e") >= 0 {
@@ -0,0 +1,39 @@
+package helper
+
+/*
+ * Copyright 2020-2021 Aldelo, LP
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import "testing"
+
+func TestParseBoolExtended_AcceptedForms(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected bool
+		ok       bool
+	}{
+		{"true", true, true}, {"T", true, true}, {"Yes", true, true}, {"y", true, true},
+		{"ON", true, true}, {"1", true, true}, {"enabled", true, true}, {" true ", true, true},
+		{"false", false, true}, {"F", false, true}, {"No", false, true}, {"n", false, true},
+		{"OFF", false, true}, {"0", false, true}, {"Disabled", false, true},
+		{"running", false, false}, {"started", false, false}, {"ture", false, false}, {"", false, false},
+	}
+
+	for _, c := range cases {
+		if b, err := ParseBoolExtended(c.in); b != c.expected || (err == nil) != c.ok {
+			t.Fatalf("ParseBoolExtended(%q) expected (%v, ok %v), got (%v, %v)", c.in, c.expected, c.ok, b, err)
+		}
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -28,6 +32,7 @@ import (
 // Custom Type Registry
 // ================================================================================================================
 var customTypeRegistry map[string]reflect.Type
+var customTypeRegistryMu sync.RWMutex
 
 // ReflectTypeRegistryAdd will accept a custom struct object, and add its type into custom type registry,
 // if customFullTypeName is not specified, the type name is inferred from the type itself,
@@ -56,6 +61,9 @@ func ReflectTypeRegistryAdd(customStructObj interface{}, customFullTypeName ...s
 		}
 	}
 
+	customTypeRegistryMu.Lock()
+	defer customTypeRegistryMu.Unlock()
+
 	if customTypeRegistry == nil {
 		customTypeRegistry = make(map[string]reflect.Type)
 	}
@@ -64,8 +72,70 @@ func ReflectTypeRegistryAdd(customStructObj interface{}, customFullTypeName ...s
 	return true
 }
 
+// ReflectTypeRegistryRegister will add the type of obj (pointer is dereferenced) into custom type registry,
+// registered under its full type name such as helper.MyStruct (reflect.Type String),
+// safe for concurrent use, typically called from init() so unmarshal helpers can resolve registered types,
+// note: unmarshal looks up interface struct fields by the interface's full type name, use ReflectTypeRegistryRegisterByName to register under that name
+func ReflectTypeRegistryRegister(obj interface{}) error {
+	if obj == nil {
+		return fmt.Errorf("Reflect Type Registry Register Requires Object")
+	}
+
+	t := reflect.TypeOf(obj)
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return ReflectTypeRegistryRegisterByName(t.String(), t)
+}
+
+// ReflectTypeRegistryRegisterByName will add type t (pointer is dereferenced) into custom type registry under the given name,
+// such as the full type name of interface struct field (helper.MyInterface) that unmarshal is to construct t for,
+// safe for concurrent use, an existing registration of the same name is replaced
+func ReflectTypeRegistryRegisterByName(name string, t reflect.Type) error {
+	if name = Trim(name); len(name) == 0 {
+		return fmt.Errorf("Reflect Type Registry Register Requires Name")
+	}
+
+	if t == nil {
+		return fmt.Errorf("Reflect Type Registry Register '%s' Requires Type", name)
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	customTypeRegistryMu.Lock()
+	defer customTypeRegistryMu.Unlock()
+
+	if customTypeRegistry == nil {
+		customTypeRegistry = make(map[string]reflect.Type)
+	}
+
+	customTypeRegistry[name] = t
+	return nil
+}
+
+// ReflectTypeRegistryList returns a copy of the custom type registry, keyed by registered name, for debugging purpose
+func ReflectTypeRegistryList() map[string]reflect.Type {
+	customTypeRegistryMu.RLock()
+	defer customTypeRegistryMu.RUnlock()
+
+	list := make(map[string]reflect.Type, len(customTypeRegistry))
+
+	for k, v := range customTypeRegistry {
+		list[k] = v
+	}
+
+	return list
+}
+
 // ReflectTypeRegistryRemove will remove a pre-registered custom type from type registry for the given type name
 func ReflectTypeRegistryRemove(customFullTypeName string) {
+	customTypeRegistryMu.Lock()
+	defer customTypeRegistryMu.Unlock()
+
 	if customTypeRegistry != nil {
 		delete(customTypeRegistry, customFullTypeName)
 	}
@@ -73,6 +143,9 @@ func ReflectTypeRegistryRemove(customFullTypeName string) {
 
 // ReflectTypeRegistryRemoveAll will clear all previously registered custom types from type registry
 func ReflectTypeRegistryRemoveAll() {
+	customTypeRegistryMu.Lock()
+	defer customTypeRegistryMu.Unlock()
+
 	if customTypeRegistry != nil {
 		customTypeRegistry = make(map[string]reflect.Type)
 	}
@@ -80,6 +153,9 @@ func ReflectTypeRegistryRemoveAll() {
 
 // ReflectTypeRegistryCount returns count of custom types registered in the type registry
 func ReflectTypeRegistryCount() int {
+	customTypeRegistryMu.RLock()
+	defer customTypeRegistryMu.RUnlock()
+
 	if customTypeRegistry != nil {
 		return len(customTypeRegistry)
 	} else {
@@ -89,6 +165,9 @@ func ReflectTypeRegistryCount() int {
 
 // ReflectTypeRegistryGet returns a previously registered custom type in the type registry, based on the given type name string
 func ReflectTypeRegistryGet(customFullTypeName string) reflect.Type {
+	customTypeRegistryMu.RLock()
+	defer customTypeRegistryMu.RUnlock()
+
 	if customTypeRegistry != nil {
 		if t, ok := customTypeRegistry[customFullTypeName]; ok {
 			return t
@@ -201,11 +280,38 @@ func ReflectCall(o reflect.Value, methodName string, paramValue ...interface{})
 	}
 }
 
+// reflectStringer returns String() result if o or its address implements fmt.Stringer,
+// nil pointer or nil interface is not invoked and returns false
+func reflectStringer(o reflect.Value) (string, bool) {
+	if !o.IsValid() {
+		return "", false
+	}
+
+	if (o.Kind() == reflect.Ptr || o.Kind() == reflect.Interface) && o.IsNil() {
+		return "", false
+	}
+
+	if o.CanInterface() {
+		if stringer, ok := o.Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+
+	if o.CanAddr() && o.Addr().CanInterface() {
+		if stringer, ok := o.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+
+	return "", false
+}
+
 // ReflectValueToString accepts reflect.Value and returns its underlying field value in string data type
 // boolTrue is the literal value to use for bool true condition, boolFalse is the false condition literal,
 // if boolTrue or boolFalse is not defined, then default 'true' or 'false' is used,
 // skipBlank and skipZero if true indicates if field value is blank (string) or Zero (int, float, time, pointer, bool) then skip render,
-// zeroBlank = will blank the value if it is 0, 0.00, or time.IsZero
+// zeroBlank = will blank the value if it is 0, 0.00, or time.IsZero,
+// useStringer = optional, if true and o (or its address) implements fmt.Stringer, String() result is used as value instead of default reflect handling
 //
 // timeFormat:
 // 		2006, 06 = year,
@@ -215,7 +321,18 @@ func ReflectCall(o reflect.Value, methodName string, paramValue ...interface{})
 //		04, 4 = minute
 //		05, 5 = second
 //		PM pm = AM PM
-func ReflectValueToString(o reflect.Value, boolTrue string, boolFalse string, skipBlank bool, skipZero bool, timeFormat string, zeroBlank bool) (valueStr string, skip bool, err error) {
+//		for time.Duration, timeFormat is instead the numeric unit (ns, us, ms, s, m, h) to output, blank outputs go duration string such as 1h30m0s
+func ReflectValueToString(o reflect.Value, boolTrue string, boolFalse string, skipBlank bool, skipZero bool, timeFormat string, zeroBlank bool, useStringer ...bool) (valueStr string, skip bool, err error) {
+	if len(useStringer) > 0 && useStringer[0] {
+		if str, ok := reflectStringer(o); ok {
+			if skipBlank && LenTrim(str) == 0 {
+				return "", true, nil
+			}
+
+			return str, false, nil
+		}
+	}
+
 	buf := ""
 
 	switch o.Kind() {
@@ -261,6 +378,8 @@ func ReflectValueToString(o reflect.Value, boolTrue string, boolFalse string, sk
 		} else {
 			if zeroBlank && o.Int() == 0 {
 				buf = ""
+			} else if o.Type() == reflect.TypeOf(time.Duration(0)) {
+				buf = formatDuration(time.Duration(o.Int()), timeFormat)
 			} else {
 				buf = Int64ToString(o.Int())
 			}
@@ -365,6 +484,16 @@ func ReflectValueToString(o reflect.Value, boolTrue string, boolFalse string, sk
 					buf = Int64ToString(f)
 				}
 			}
+		case time.Duration:
+			if skipZero && f == 0 {
+				return "", true, nil
+			} else {
+				if zeroBlank && f == 0 {
+					buf = ""
+				} else {
+					buf = formatDuration(f, timeFormat)
+				}
+			}
 		case int:
 			if skipZero && f == 0 {
 				return "", true, nil
@@ -578,6 +707,211 @@ func ReflectValueToString(o reflect.Value, boolTrue string, boolFalse string, sk
 	return buf, false, nil
 }
 
+// numeric overflow modes, see SetNumericOverflowMode
+const (
+	NumericOverflowSkip  = "skip"
+	NumericOverflowClamp = "clamp"
+	NumericOverflowError = "error"
+)
+
+// indicates how ReflectStringToField handles numeric values that overflow the target field type
+var numericOverflowMode = NumericOverflowSkip
+var numericOverflowModeMu sync.RWMutex
+
+// SetNumericOverflowMode sets how ReflectStringToField (and therefore the unmarshal helpers) handles numeric values
+// that overflow the target field type, such as 300 into int8:
+//		1) "skip" = overflowing value is ignored and the field is left unchanged (default)
+//		2) "clamp" = field is set to the max or min value of its type
+//		3) "error" = error is returned
+// blank mode resets to "skip", any other mode returns error
+func SetNumericOverflowMode(mode string) error {
+	mode = strings.ToLower(Trim(mode))
+
+	if len(mode) == 0 {
+		mode = NumericOverflowSkip
+	}
+
+	switch mode {
+	case NumericOverflowSkip, NumericOverflowClamp, NumericOverflowError:
+	default:
+		return fmt.Errorf("SetNumericOverflowMode Requires Mode skip, clamp or error: '%s' Not Valid", mode)
+	}
+
+	numericOverflowModeMu.Lock()
+	defer numericOverflowModeMu.Unlock()
+	numericOverflowMode = mode
+	return nil
+}
+
+// getNumericOverflowMode returns the mode as set by SetNumericOverflowMode
+func getNumericOverflowMode() string {
+	numericOverflowModeMu.RLock()
+	defer numericOverflowModeMu.RUnlock()
+	return numericOverflowMode
+}
+
+// reflectSetInt sets i64 into int kind o, overflow is handled per SetNumericOverflowMode
+func reflectSetInt(o reflect.Value, i64 int64) error {
+	if !o.OverflowInt(i64) {
+		o.SetInt(i64)
+		return nil
+	}
+
+	switch getNumericOverflowMode() {
+	case NumericOverflowClamp:
+		max := int64(1)<<uint(o.Type().Bits()-1) - 1
+
+		if i64 > 0 {
+			o.SetInt(max)
+		} else {
+			o.SetInt(-max - 1)
+		}
+	case NumericOverflowError:
+		return fmt.Errorf("Value %d Overflows %s", i64, o.Type().String())
+	}
+
+	return nil
+}
+
+// reflectSetUint sets u64 into uint kind o, overflow is handled per SetNumericOverflowMode
+func reflectSetUint(o reflect.Value, u64 uint64) error {
+	if !o.OverflowUint(u64) {
+		o.SetUint(u64)
+		return nil
+	}
+
+	switch getNumericOverflowMode() {
+	case NumericOverflowClamp:
+		o.SetUint(uint64(math.MaxUint64) >> uint(64-o.Type().Bits()))
+	case NumericOverflowError:
+		return fmt.Errorf("Value %d Overflows %s", u64, o.Type().String())
+	}
+
+	return nil
+}
+
+// reflectSetFloat sets f64 into float kind o, overflow is handled per SetNumericOverflowMode
+func reflectSetFloat(o reflect.Value, f64 float64) error {
+	if !o.OverflowFloat(f64) {
+		o.SetFloat(f64)
+		return nil
+	}
+
+	switch getNumericOverflowMode() {
+	case NumericOverflowClamp:
+		if f64 > 0 {
+			o.SetFloat(math.MaxFloat32)
+		} else {
+			o.SetFloat(-math.MaxFloat32)
+		}
+	case NumericOverflowError:
+		return fmt.Errorf("Value %v Overflows %s", f64, o.Type().String())
+	}
+
+	return nil
+}
+
+// durationUnits maps durationformat unit names to time.Duration units
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+// formatDuration returns d as go duration string (such as 1h30m0s) if unit is blank or not recognized,
+// otherwise d is returned as numeric value in the given unit (ns, us, ms, s, m, h), with fraction if d is not a whole number of unit
+func formatDuration(d time.Duration, unit string) string {
+	if u, ok := durationUnits[strings.ToLower(Trim(unit))]; ok {
+		if d%u == 0 {
+			return Int64ToString(int64(d / u))
+		} else {
+			return strconv.FormatFloat(float64(d)/float64(u), 'f', -1, 64)
+		}
+	}
+
+	return d.String()
+}
+
+// parseDuration parses v as numeric value in the given unit (ns, us, ms, s, m, h) if unit is recognized,
+// otherwise v is parsed as go duration string (such as 1h30m), or as integer nanoseconds for compatibility
+func parseDuration(v string, unit string) (time.Duration, error) {
+	v = Trim(v)
+
+	if len(v) == 0 {
+		return 0, nil
+	}
+
+	if u, ok := durationUnits[strings.ToLower(Trim(unit))]; ok {
+		if i64, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Duration(i64) * u, nil
+		} else if f64, ok := ParseFloat64(v); ok {
+			return time.Duration(math.Round(f64 * float64(u))), nil
+		} else {
+			return 0, fmt.Errorf("Duration '%s' Not Valid Numeric Value In Unit '%s'", v, unit)
+		}
+	}
+
+	if d, err := time.ParseDuration(v); err == nil {
+		return d, nil
+	} else if i64, e := strconv.ParseInt(v, 10, 64); e == nil {
+		return time.Duration(i64), nil
+	} else {
+		return 0, fmt.Errorf("Duration '%s' Not Valid: %s", v, err)
+	}
+}
+
+// timeInLocation re-interprets wall clock of t (parsed without zone info) as time in loc,
+// if loc is nil or t is zero, t is returned as is
+func timeInLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil || t.IsZero() {
+		return t
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// reflectTimeInLocation returns o with its time.Time, *time.Time or sql.NullTime value converted into loc,
+// for use prior to ReflectValueToString, so that time value is rendered in the given time zone,
+// if loc is nil, o is nil pointer, or o is not time value, o is returned as is
+func reflectTimeInLocation(o reflect.Value, loc *time.Location) reflect.Value {
+	if loc == nil || !o.IsValid() {
+		return o
+	}
+
+	if o.Kind() == reflect.Ptr {
+		if o.IsNil() {
+			return o
+		}
+
+		if t, ok := o.Elem().Interface().(time.Time); ok {
+			t = t.In(loc)
+			return reflect.ValueOf(&t)
+		}
+
+		return o
+	}
+
+	if !o.CanInterface() {
+		return o
+	}
+
+	switch f := o.Interface().(type) {
+	case time.Time:
+		return reflect.ValueOf(f.In(loc))
+	case sql.NullTime:
+		if f.Valid {
+			f.Time = f.Time.In(loc)
+		}
+
+		return reflect.ValueOf(f)
+	default:
+		return o
+	}
+}
+
 // ReflectStringToField accepts string value and reflects into reflect.Value field based on the field data type
 //
 // timeFormat:
@@ -588,12 +922,24 @@ func ReflectValueToString(o reflect.Value, boolTrue string, boolFalse string, sk
 //		04, 4 = minute
 //		05, 5 = second
 //		PM pm = AM PM
-func ReflectStringToField(o reflect.Value, v string, timeFormat string) error {
+//		for time.Duration, timeFormat is instead the numeric unit (ns, us, ms, s, m, h) of v, blank expects go duration string such as 1h30m
+//
+// timeZone:
+//		optional, if specified (not nil), time value without zone info is parsed as time in the given location
+//
+// numeric values overflowing the field type are handled per SetNumericOverflowMode
+func ReflectStringToField(o reflect.Value, v string, timeFormat string, timeZone ...*time.Location) error {
+	var loc *time.Location
+
+	if len(timeZone) > 0 {
+		loc = timeZone[0]
+	}
+
 	switch o.Kind() {
 	case reflect.String:
 		o.SetString(v)
 	case reflect.Bool:
-		b, _ := ParseBool(v)
+		b, _ := ParseBoolExtended(v)
 		o.SetBool(b)
 	case reflect.Int8:
 		fallthrough
@@ -604,16 +950,24 @@ func ReflectStringToField(o reflect.Value, v string, timeFormat string) error {
 	case reflect.Int32:
 		fallthrough
 	case reflect.Int64:
-		i64, _ := ParseInt64(v)
-		if !o.OverflowInt(i64) {
-			o.SetInt(i64)
+		if o.Type() == reflect.TypeOf(time.Duration(0)) {
+			if d, err := parseDuration(v, timeFormat); err != nil {
+				return err
+			} else {
+				o.SetInt(int64(d))
+			}
+		} else {
+			i64, _ := ParseInt64(v)
+			if err := reflectSetInt(o, i64); err != nil {
+				return err
+			}
 		}
 	case reflect.Float32:
 		fallthrough
 	case reflect.Float64:
 		f64, _ := ParseFloat64(v)
-		if !o.OverflowFloat(f64) {
-			o.SetFloat(f64)
+		if err := reflectSetFloat(o, f64); err != nil {
+			return err
 		}
 	case reflect.Uint8:
 		fallthrough
@@ -624,9 +978,8 @@ func ReflectStringToField(o reflect.Value, v string, timeFormat string) error {
 	case reflect.Uint32:
 		fallthrough
 	case reflect.Uint64:
-		ui64 := StrToUint64(v)
-		if !o.OverflowUint(ui64) {
-			o.SetUint(ui64)
+		if err := reflectSetUint(o, StrToUint64(v)); err != nil {
+			return err
 		}
 	case reflect.Ptr:
 		if o.IsZero() || o.IsNil() {
@@ -644,57 +997,63 @@ func ReflectStringToField(o reflect.Value, v string, timeFormat string) error {
 		switch o2.Interface().(type) {
 		case int:
 			i64, _ := ParseInt64(v)
-			if !o2.OverflowInt(i64) {
-				o2.SetInt(i64)
+			if err := reflectSetInt(o2, i64); err != nil {
+				return err
 			}
 		case int8:
 			i64, _ := ParseInt64(v)
-			if !o2.OverflowInt(i64) {
-				o2.SetInt(i64)
+			if err := reflectSetInt(o2, i64); err != nil {
+				return err
 			}
 		case int16:
 			i64, _ := ParseInt64(v)
-			if !o2.OverflowInt(i64) {
-				o2.SetInt(i64)
+			if err := reflectSetInt(o2, i64); err != nil {
+				return err
 			}
 		case int32:
 			i64, _ := ParseInt64(v)
-			if !o2.OverflowInt(i64) {
-				o2.SetInt(i64)
+			if err := reflectSetInt(o2, i64); err != nil {
+				return err
 			}
 		case int64:
 			i64, _ := ParseInt64(v)
-			if !o2.OverflowInt(i64) {
-				o2.SetInt(i64)
+			if err := reflectSetInt(o2, i64); err != nil {
+				return err
+			}
+		case time.Duration:
+			if d, err := parseDuration(v, timeFormat); err != nil {
+				return err
+			} else {
+				o2.SetInt(int64(d))
 			}
 		case float32:
 			f64, _ := ParseFloat64(v)
-			if !o2.OverflowFloat(f64) {
-				o2.SetFloat(f64)
+			if err := reflectSetFloat(o2, f64); err != nil {
+				return err
 			}
 		case float64:
 			f64, _ := ParseFloat64(v)
-			if !o2.OverflowFloat(f64) {
-				o2.SetFloat(f64)
+			if err := reflectSetFloat(o2, f64); err != nil {
+				return err
 			}
 		case uint:
-			if !o2.OverflowUint(StrToUint64(v)) {
-				o2.SetUint(StrToUint64(v))
+			if err := reflectSetUint(o2, StrToUint64(v)); err != nil {
+				return err
 			}
 		case uint64:
-			if !o2.OverflowUint(StrToUint64(v)) {
-				o2.SetUint(StrToUint64(v))
+			if err := reflectSetUint(o2, StrToUint64(v)); err != nil {
+				return err
 			}
 		case string:
 			o2.SetString(v)
 		case bool:
-			b, _ := ParseBool(v)
+			b, _ := ParseBoolExtended(v)
 			o2.SetBool(b)
 		case time.Time:
 			if LenTrim(timeFormat) == 0 {
-				o2.Set(reflect.ValueOf(ParseDate(v)))
+				o2.Set(reflect.ValueOf(timeInLocation(ParseDate(v), loc)))
 			} else {
-				o2.Set(reflect.ValueOf(ParseDateTimeCustom(v, timeFormat)))
+				o2.Set(reflect.ValueOf(ParseDateTimeCustom(v, timeFormat, loc)))
 			}
 		default:
 			return fmt.Errorf(o2.Type().Name() + " Unhandled [1]")
@@ -704,7 +1063,7 @@ func ReflectStringToField(o reflect.Value, v string, timeFormat string) error {
 		case sql.NullString:
 			o.Set(reflect.ValueOf(sql.NullString{String: v, Valid: true}))
 		case sql.NullBool:
-			b, _ := ParseBool(v)
+			b, _ := ParseBoolExtended(v)
 			o.Set(reflect.ValueOf(sql.NullBool{Bool: b, Valid: true}))
 		case sql.NullFloat64:
 			f64, _ := ParseFloat64(v)
@@ -719,17 +1078,17 @@ func ReflectStringToField(o reflect.Value, v string, timeFormat string) error {
 			var tv time.Time
 
 			if LenTrim(timeFormat) == 0 {
-				tv = ParseDateTime(v)
+				tv = timeInLocation(ParseDateTime(v), loc)
 			} else {
-				tv = ParseDateTimeCustom(v, timeFormat)
+				tv = ParseDateTimeCustom(v, timeFormat, loc)
 			}
 
 			o.Set(reflect.ValueOf(sql.NullTime{Time: tv, Valid: true}))
 		case time.Time:
 			if LenTrim(timeFormat) == 0 {
-				o.Set(reflect.ValueOf(ParseDateTime(v)))
+				o.Set(reflect.ValueOf(timeInLocation(ParseDateTime(v), loc)))
 			} else {
-				o.Set(reflect.ValueOf(ParseDateTimeCustom(v, timeFormat)))
+				o.Set(reflect.ValueOf(ParseDateTimeCustom(v, timeFormat, loc)))
 			}
 		case nil:
 			return nil
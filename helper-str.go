@@ -17,6 +17,7 @@ package helper
  */
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -538,6 +539,52 @@ func Base64UrlDecode(data string) (string, error) {
 	return string(b), nil
 }
 
+// ================================================================================================================
+// REDACT HELPERS
+// ================================================================================================================
+
+// RedactString returns value redacted per mode, for safe logging of sensitive values (such as card number or ssn), modes are:
+//		1) last4			= all but the last 4 characters are replaced with *, such as ************1111
+//		2) first6last4		= all but the first 6 and last 4 characters are replaced with *, such as 411111******1111
+//		3) full				= value is replaced with **** (length is not revealed)
+//		4) email			= local part is replaced with ****, domain is kept, such as ****@example.com
+//		5) hash				= sha256 hex of value
+// value too short to keep any characters under last4 or first6last4 is fully masked, unknown mode is treated as full,
+// blank value is returned as is
+func RedactString(value string, mode string) string {
+	if len(value) == 0 {
+		return value
+	}
+
+	r := []rune(value)
+
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "last4":
+		if len(r) <= 4 {
+			return strings.Repeat("*", len(r))
+		}
+
+		return strings.Repeat("*", len(r)-4) + string(r[len(r)-4:])
+	case "first6last4":
+		if len(r) <= 10 {
+			return strings.Repeat("*", len(r))
+		}
+
+		return string(r[:6]) + strings.Repeat("*", len(r)-10) + string(r[len(r)-4:])
+	case "email":
+		if i := strings.LastIndex(value, "@"); i >= 0 {
+			return "****" + value[i:]
+		}
+
+		return "****"
+	case "hash":
+		h := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(h[:])
+	default:
+		return "****"
+	}
+}
+
 // ================================================================================================================
 // HTML HELPERS
 // ================================================================================================================
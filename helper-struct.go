@@ -1,13 +1,29 @@
 package helper
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -27,1189 +43,1377 @@ import (
  * limitations under the License.
  */
 
-// src and dst both must be struct，and dst must be point
-// it will copy the src struct with same tag name as dst struct tag
-func Fill(src interface{}, dst interface{}) error {
-	srcType := reflect.TypeOf(src)
+// FillErrors contains one or more field errors encountered during Fill in strict mode
+type FillErrors []error
+
+// Error returns all field errors joined into one string
+func (e FillErrors) Error() string {
+	var msgs []string
+
+	for _, v := range e {
+		if v != nil {
+			msgs = append(msgs, v.Error())
+		}
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Fill copies src struct field values into dst struct pointer fields having the same field name,
+// src must be struct or pointer to struct, and dst must be pointer to struct,
+// if src and dst field types are not assignable but convertible (such as int to int64, string to []byte, named type to underlying type), the value is converted,
+// when a field is neither assignable nor convertible, the field is skipped silently,
+// unless strict is true, where such fields are collected and returned as FillErrors
+func Fill(src interface{}, dst interface{}, strict ...bool) error {
 	srcValue := reflect.ValueOf(src)
 	dstValue := reflect.ValueOf(dst)
 
-	if srcType.Kind() != reflect.Struct {
+	if srcValue.Kind() == reflect.Ptr && !srcValue.IsNil() {
+		srcValue = srcValue.Elem()
+	}
+
+	if srcValue.Kind() != reflect.Struct {
 		return errors.New("src must be struct")
 	}
 	if dstValue.Kind() != reflect.Ptr {
 		return errors.New("dst must be point")
 	}
 
+	isStrict := len(strict) > 0 && strict[0]
+	srcType := srcValue.Type()
+	var errs FillErrors
+
 	for i := 0; i < srcType.NumField(); i++ {
+		if len(srcType.Field(i).PkgPath) > 0 {
+			// unexported field
+			continue
+		}
+
 		dstField := dstValue.Elem().FieldByName(srcType.Field(i).Name)
 		if dstField.CanSet() {
-			dstField.Set(srcValue.Field(i))
+			if err := setConvertedValue(srcValue.Field(i), dstField, srcType.Name()+"."+srcType.Field(i).Name); err != nil && isStrict {
+				errs = append(errs, err)
+			}
 		}
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
 
-// MarshalStructToQueryParams marshals a struct pointer's fields to query params string,
-// output query param names are based on values given in tagName,
-// to exclude certain struct fields from being marshaled, use - as value in struct tag defined by tagName,
-// if there is a need to name the value of tagName, but still need to exclude from output, use the excludeTagName with -, such as `x:"-"`
+// FillByTag copies src struct field values into dst struct pointer fields,
+// src and dst fields are matched by the shared value of tagName struct tag, such as `copy:"customer_id"`,
+// if the field has no such tag, the field name is used, any field with tagName value of - is not copied,
+// if src and dst field types are not assignable, compatible values are converted (such as int to int64, float32 to float64),
+// and src field implementing fmt.Stringer is converted to string via its String() method when dst field is string,
+// fields that cannot be converted do not stop the fill, instead they are collected and returned as FillErrors
 //
-// special struct tags:
-//		1) `getter:"Key"`			// if field type is custom struct or enum,
-//									   specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
-//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
-//									   NOTE: if the method is to receive a parameter value, always in string data type, add '(x)' after the method name, such as 'XYZ(x)' or 'base.XYZ(x)'
-//		2) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-//		3) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-// 		4) `uniqueid:"xyz"`			// if two or more struct field is set with the same uniqueid, then only the first encountered field with the same uniqueid will be used in marshal
-//		5) `skipblank:"false"`		// if true, then any fields that is blank string will be excluded from marshal (this only affects fields that are string)
-//		6) `skipzero:"false"`		// if true, then any fields that are 0, 0.00, time.Zero(), false, nil will be excluded from marshal (this only affects fields that are number, bool, time, pointer)
-//		7) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
-//											2006, 06 = year,
-//											01, 1, Jan, January = month,
-//											02, 2, _2 = day (_2 = width two, right justified)
-//											03, 3, 15 = hour (15 = 24 hour format)
-//											04, 4 = minute
-//											05, 5 = second
-//											PM pm = AM PM
-//		8) `outprefix:""`			// for marshal method, if field value is to precede with an output prefix, such as XYZ= (affects marshal queryParams / csv methods only)
-// 		9) `zeroblank:"false"`		// set true to set blank to data when value is 0, 0.00, or time.IsZero
-func MarshalStructToQueryParams(inputStructPtr interface{}, tagName string, excludeTagName string) (string, error) {
-	if inputStructPtr == nil {
-		return "", fmt.Errorf("MarshalStructToQueryParams Requires Input Struct Variable Pointer")
+// src may be struct or pointer to struct, dst must be pointer to struct
+func FillByTag(src interface{}, dst interface{}, tagName string) error {
+	if src == nil {
+		return errors.New("FillByTag Requires Src Struct")
+	}
+
+	if dst == nil {
+		return errors.New("FillByTag Requires Dst Struct Pointer")
 	}
 
 	if LenTrim(tagName) == 0 {
-		return "", fmt.Errorf("MarshalStructToQueryParams Requires TagName (Tag Name defines query parameter name)")
+		return errors.New("FillByTag Requires TagName")
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	srcValue := reflect.ValueOf(src)
 
-	if s.Kind() != reflect.Ptr {
-		return "", fmt.Errorf("MarshalStructToQueryParams Expects inputStructPtr To Be a Pointer")
-	} else {
-		s = s.Elem()
+	if srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			return errors.New("FillByTag Requires Src Struct")
+		}
+
+		srcValue = srcValue.Elem()
 	}
 
-	if s.Kind() != reflect.Struct {
-		return "", fmt.Errorf("MarshalStructToQueryParams Requires Struct Object")
+	if srcValue.Kind() != reflect.Struct {
+		return errors.New("FillByTag Expects Src To Be Struct")
 	}
 
-	output := ""
-	uniqueMap := make(map[string]string)
+	dstValue := reflect.ValueOf(dst)
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Struct {
+		return errors.New("FillByTag Expects Dst To Be Struct Pointer")
+	}
 
-		if o := s.FieldByName(field.Name); o.IsValid() {
-			tag := field.Tag.Get(tagName)
+	dstValue = dstValue.Elem()
+	tagName = Trim(tagName)
 
-			if LenTrim(tag) == 0 {
-				tag = field.Name
+	dstFields := make(map[string]reflect.Value)
+
+	for i := 0; i < dstValue.NumField(); i++ {
+		if key := getFillFieldKey(dstValue.Type().Field(i), tagName); key != "-" {
+			if _, ok := dstFields[key]; !ok {
+				dstFields[key] = dstValue.Field(i)
 			}
+		}
+	}
 
-			if tag != "-" {
-				if LenTrim(excludeTagName) > 0 {
-					if Trim(field.Tag.Get(excludeTagName)) == "-" {
-						continue
-					}
-				}
+	var errs FillErrors
 
-				if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-					if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-						continue
-					} else {
-						uniqueMap[strings.ToLower(tagUniqueId)] = field.Name
-					}
-				}
+	for i := 0; i < srcValue.NumField(); i++ {
+		field := srcValue.Type().Field(i)
 
-				var boolTrue, boolFalse, timeFormat, outPrefix string
-				var skipBlank, skipZero, zeroblank bool
+		if len(field.PkgPath) > 0 {
+			// unexported field
+			continue
+		}
 
-				if vs := GetStructTagsValueSlice(field, "booltrue", "boolfalse", "skipblank", "skipzero", "timeformat", "outprefix", "zeroblank"); len(vs) == 7 {
-					boolTrue = vs[0]
-					boolFalse = vs[1]
-					skipBlank, _ = ParseBool(vs[2])
-					skipZero, _ = ParseBool(vs[3])
-					timeFormat = vs[4]
-					outPrefix = vs[5]
-					zeroblank, _ = ParseBool(vs[6])
-				}
+		key := getFillFieldKey(field, tagName)
 
-				oldVal := o
+		if key == "-" {
+			continue
+		}
 
-				if tagGetter := Trim(field.Tag.Get("getter")); len(tagGetter) > 0 {
-					isBase := false
-					useParam := false
-					paramVal := ""
-					var paramSlice interface{}
+		if df, ok := dstFields[key]; ok && df.CanSet() {
+			sv := srcValue.Field(i)
+			path := srcValue.Type().Name() + "." + field.Name
 
-					if strings.ToLower(Left(tagGetter, 5)) == "base." {
-						isBase = true
-						tagGetter = Right(tagGetter, len(tagGetter)-5)
-					}
+			if df.Kind() == reflect.String && !sv.Type().AssignableTo(df.Type()) {
+				if sv.Kind() == reflect.Ptr && sv.IsNil() {
+					df.SetString("")
+					continue
+				}
 
-					if strings.ToLower(Right(tagGetter, 3)) == "(x)" {
-						useParam = true
+				if stringer, ok := sv.Interface().(fmt.Stringer); ok {
+					df.SetString(stringer.String())
+					continue
+				}
+			}
 
-						if o.Kind() != reflect.Slice {
-							paramVal, _, _ = ReflectValueToString(o, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroblank)
-						} else {
-							if o.Len() > 0 {
-								paramSlice = o.Slice(0, o.Len()).Interface()
-							}
-						}
+			if err := setConvertedValue(sv, df, path); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
 
-						tagGetter = Left(tagGetter, len(tagGetter)-3)
-					}
+	if len(errs) > 0 {
+		return errs
+	}
 
-					var ov []reflect.Value
-					var notFound bool
+	return nil
+}
 
-					if isBase {
-						if useParam {
-							if paramSlice == nil {
-								ov, notFound = ReflectCall(s.Addr(), tagGetter, paramVal)
-							} else {
-								ov, notFound = ReflectCall(s.Addr(), tagGetter, paramSlice)
-							}
-						} else {
-							ov, notFound = ReflectCall(s.Addr(), tagGetter)
-						}
-					} else {
-						if useParam {
-							if paramSlice == nil {
-								ov, notFound = ReflectCall(o, tagGetter, paramVal)
-							} else {
-								ov, notFound = ReflectCall(o, tagGetter, paramSlice)
-							}
-						} else {
-							ov, notFound = ReflectCall(o, tagGetter)
-						}
-					}
+// DeepFill copies src struct field values into dst struct pointer fields,
+// src and dst fields are matched by the value of tagName struct tag, if tagName is blank or the field has no such tag, the field name is used,
+// any field with tagName value of - is not copied,
+// nested structs are recursively filled by the same tagName matching,
+// slices, maps and pointers are deep copied, so that mutating dst does not affect src,
+// when src and dst field types differ but are convertible (such as int to int64), the value is converted,
+// otherwise an error identifying the field is returned
+//
+// src may be struct or pointer to struct, dst must be pointer to struct
+func DeepFill(src interface{}, dst interface{}, tagName string) error {
+	return deepFill("DeepFill", src, dst, Trim(tagName))
+}
 
-					if !notFound {
-						if len(ov) > 0 {
-							o = ov[0]
-						}
-					}
-				}
+// FillDeep copies src struct field values into dst struct pointer fields having the same field name,
+// unlike Fill, slices, maps, pointers and nested structs are recursively cloned, so that dst owns memory independent of src,
+// time.Time and sql.Null* values are copied by value,
+// when src and dst field types differ but are convertible (such as int to int64), the value is converted,
+// otherwise an error identifying the field is returned,
+// pointers, maps and slices already copied are tracked by address, so that a src value referenced more than once (including cyclic reference,
+// such as a pointer referring back to its parent) is copied once and the copy is reused, preserving shared structure in dst,
+// if a cyclic reference cannot be reused (such as a pointer cycle dereferenced into a non-pointer dst field), error is returned rather than recursing infinitely
+//
+// src may be struct or pointer to struct, dst must be pointer to struct
+func FillDeep(src interface{}, dst interface{}) error {
+	return deepFill("FillDeep", src, dst, "")
+}
 
-				if buf, skip, err := ReflectValueToString(o, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroblank); err != nil || skip {
-					if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-						if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-							delete(uniqueMap, strings.ToLower(tagUniqueId))
-						}
-					}
+// deepFill validates src and dst, then deep fills dst from src, funcName is the public method name used in error info
+func deepFill(funcName string, src interface{}, dst interface{}, tagName string) error {
+	if src == nil {
+		return fmt.Errorf("%s Requires Src Struct", funcName)
+	}
 
-					continue
-				} else {
-					defVal := field.Tag.Get("def")
+	if dst == nil {
+		return fmt.Errorf("%s Requires Dst Struct Pointer", funcName)
+	}
 
-					if oldVal.Kind() == reflect.Int && oldVal.Int() == 0 && strings.ToLower(buf) == "unknown" {
-						// unknown enum value will be serialized as blank
-						buf = ""
+	srcValue := reflect.ValueOf(src)
+	visited := make(map[deepCopyVisit]reflect.Value)
 
-						if len(defVal) > 0 {
-							buf = defVal
-						} else {
-							if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-								if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-									// remove uniqueid if skip
-									delete(uniqueMap, strings.ToLower(tagUniqueId))
-									continue
-								}
-							}
-						}
-					}
+	if srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			return fmt.Errorf("%s Requires Src Struct", funcName)
+		}
 
-					if boolFalse == " " && len(outPrefix) > 0 && buf == "false" {
-						buf = ""
-					} else {
-						if len(buf) == 0 && len(defVal) > 0  {
-							buf = defVal
-						}
+		// src root pointer maps to dst root pointer, so that reference back to src root is filled as reference to dst root
+		visited[deepCopyVisit{ptr: srcValue.Pointer(), typ: srcValue.Type(), dstTyp: reflect.TypeOf(dst)}] = reflect.ValueOf(dst)
+		srcValue = srcValue.Elem()
+	}
 
-						if skipBlank && LenTrim(buf) == 0 {
-							buf = ""
-						} else if skipZero && buf == "0" {
-							buf = ""
-						} else {
-							buf = outPrefix + buf
-						}
-					}
+	if srcValue.Kind() != reflect.Struct {
+		return fmt.Errorf("%s Expects Src To Be Struct", funcName)
+	}
 
-					if LenTrim(output) > 0 {
-						output += "&"
-					}
+	dstValue := reflect.ValueOf(dst)
 
-					output += fmt.Sprintf("%s=%s", tag, url.PathEscape(buf))
-				}
-			}
-		}
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("%s Expects Dst To Be Struct Pointer", funcName)
 	}
 
-	if LenTrim(output) == 0 {
-		return "", fmt.Errorf("MarshalStructToQueryParams Yielded Blank Output")
-	} else {
-		return output, nil
+	dstValue = dstValue.Elem()
+
+	if dstValue.Kind() != reflect.Struct {
+		return fmt.Errorf("%s Expects Dst To Be Struct Pointer", funcName)
 	}
-}
 
-// MarshalStructToJson marshals a struct pointer's fields to json string,
-// output json names are based on values given in tagName,
-// to exclude certain struct fields from being marshaled, include - as value in struct tag defined by tagName,
-// if there is a need to name the value of tagName, but still need to exclude from output, use the excludeTagName with -, such as `x:"-"`
-//
-// special struct tags:
-//		1) `getter:"Key"`			// if field type is custom struct or enum,
-//									   specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
-//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
-//									   NOTE: if the method is to receive a parameter value, always in string data type, add '(x)' after the method name, such as 'XYZ(x)' or 'base.XYZ(x)'
-//		2) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-//		3) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-// 		4) `uniqueid:"xyz"`			// if two or more struct field is set with the same uniqueid, then only the first encountered field with the same uniqueid will be used in marshal
-//		5) `skipblank:"false"`		// if true, then any fields that is blank string will be excluded from marshal (this only affects fields that are string)
-//		6) `skipzero:"false"`		// if true, then any fields that are 0, 0.00, time.Zero(), false, nil will be excluded from marshal (this only affects fields that are number, bool, time, pointer)
-//		7) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
-//											2006, 06 = year,
-//											01, 1, Jan, January = month,
-//											02, 2, _2 = day (_2 = width two, right justified)
-//											03, 3, 15 = hour (15 = 24 hour format)
-//											04, 4 = minute
-//											05, 5 = second
-//											PM pm = AM PM
-// 		8) `zeroblank:"false"`		// set true to set blank to data when value is 0, 0.00, or time.IsZero
-func MarshalStructToJson(inputStructPtr interface{}, tagName string, excludeTagName string) (string, error) {
-	if inputStructPtr == nil {
-		return "", fmt.Errorf("MarshalStructToJson Requires Input Struct Variable Pointer")
+	if err := deepFillStruct(srcValue, dstValue, tagName, srcValue.Type().Name(), visited); err != nil {
+		return fmt.Errorf("%s Failed: %s", funcName, err)
 	}
 
-	if LenTrim(tagName) == 0 {
-		return "", fmt.Errorf("MarshalStructToJson Requires TagName (Tag Name defines Json name)")
+	return nil
+}
+
+// deepCopyVisit identifies a pointer, map or slice being deep copied into dst type, used to reuse copies and detect cyclic reference,
+// type is included since a struct and its first field share the same address, len is included since slices may share the same address
+type deepCopyVisit struct {
+	ptr    uintptr
+	typ    reflect.Type
+	dstTyp reflect.Type
+	len    int
+}
+
+// getStructFieldNameTag returns the name segment of field's tagName value, parsed in encoding/json style such as `json:"name,omitempty"`,
+// where the first comma delimited segment is the name (trimmed, blank if not defined), and omitEmpty is true if omitempty option is declared,
+// other options such as string are accepted and ignored, since values are already marshaled as json strings
+func getStructFieldNameTag(field reflect.StructField, tagName string) (name string, omitEmpty bool) {
+	if len(tagName) == 0 {
+		return "", false
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	parts := strings.Split(field.Tag.Get(tagName), ",")
 
-	if s.Kind() != reflect.Ptr {
-		return "", fmt.Errorf("MarshalStructToJson Expects inputStructPtr To Be a Pointer")
-	} else {
-		s = s.Elem()
+	for _, v := range parts[1:] {
+		if Trim(v) == "omitempty" {
+			omitEmpty = true
+		}
 	}
 
-	if s.Kind() != reflect.Struct {
-		return "", fmt.Errorf("MarshalStructToJson Requires Struct Object")
+	return Trim(parts[0]), omitEmpty
+}
+
+// getFillFieldKey returns the key used to match src and dst struct fields during fill,
+// the key is the tagName value (name portion before comma) if defined, otherwise the field name
+func getFillFieldKey(field reflect.StructField, tagName string) string {
+	if len(tagName) > 0 {
+		if tag := Trim(strings.Split(field.Tag.Get(tagName), ",")[0]); len(tag) > 0 {
+			return tag
+		}
 	}
 
-	output := ""
-	uniqueMap := make(map[string]string)
+	return field.Name
+}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+// deepFillStruct fills dst struct fields from src struct fields matched by tagName, path is the field path used in error info
+func deepFillStruct(src reflect.Value, dst reflect.Value, tagName string, path string, visited map[deepCopyVisit]reflect.Value) error {
+	dstFields := make(map[string]reflect.Value)
 
-		if o := s.FieldByName(field.Name); o.IsValid() {
-			tag := field.Tag.Get(tagName)
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
 
-			if LenTrim(tag) == 0 {
-				tag = field.Name
+		if key := getFillFieldKey(field, tagName); key != "-" {
+			if _, ok := dstFields[key]; !ok {
+				dstFields[key] = dst.Field(i)
 			}
+		}
+	}
 
-			if tag != "-" {
-				if LenTrim(excludeTagName) > 0 {
-					if Trim(field.Tag.Get(excludeTagName)) == "-" {
-						continue
-					}
-				}
-
-				if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-					if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-						continue
-					} else {
-						uniqueMap[strings.ToLower(tagUniqueId)] = field.Name
-					}
-				}
+	for i := 0; i < src.NumField(); i++ {
+		field := src.Type().Field(i)
 
-				var boolTrue, boolFalse, timeFormat string
-				var skipBlank, skipZero, zeroBlank bool
+		if len(field.PkgPath) > 0 {
+			// unexported field
+			continue
+		}
 
-				if vs := GetStructTagsValueSlice(field, "booltrue", "boolfalse", "skipblank", "skipzero", "timeformat", "zeroblank"); len(vs) == 6 {
-					boolTrue = vs[0]
-					boolFalse = vs[1]
-					skipBlank, _ = ParseBool(vs[2])
-					skipZero, _ = ParseBool(vs[3])
-					timeFormat = vs[4]
-					zeroBlank, _ = ParseBool(vs[5])
-				}
+		key := getFillFieldKey(field, tagName)
 
-				oldVal := o
+		if key == "-" {
+			continue
+		}
 
-				if tagGetter := Trim(field.Tag.Get("getter")); len(tagGetter) > 0 {
-					isBase := false
-					useParam := false
-					paramVal := ""
-					var paramSlice interface{}
+		if df, ok := dstFields[key]; ok && df.CanSet() {
+			if err := deepCopyValue(src.Field(i), df, tagName, path+"."+field.Name, visited); err != nil {
+				return err
+			}
+		}
+	}
 
-					if strings.ToLower(Left(tagGetter, 5)) == "base." {
-						isBase = true
-						tagGetter = Right(tagGetter, len(tagGetter)-5)
-					}
+	return nil
+}
 
-					if strings.ToLower(Right(tagGetter, 3)) == "(x)" {
-						useParam = true
+// deepCopyValue copies src value into dst value, where slices, maps, pointers, interfaces and structs are recursively copied,
+// if src and dst types differ, src is converted to dst type when convertible, otherwise error is returned,
+// visited maps pointers, maps and slices already copied to their dst copy, so that shared or cyclic references reuse the same copy
+func deepCopyValue(src reflect.Value, dst reflect.Value, tagName string, path string, visited map[deepCopyVisit]reflect.Value) error {
+	if !src.IsValid() {
+		return nil
+	}
 
-						if o.Kind() != reflect.Slice {
-							paramVal, _, _ = ReflectValueToString(o, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank)
-						} else {
-							if o.Len() > 0 {
-								paramSlice = o.Slice(0, o.Len()).Interface()
-							}
-						}
+	srcType := src.Type()
+	dstType := dst.Type()
 
-						tagGetter = Left(tagGetter, len(tagGetter)-3)
-					}
+	var visit *deepCopyVisit
 
-					var ov []reflect.Value
-					var notFound bool
+	switch src.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !src.IsNil() && (src.Kind() != reflect.Slice || src.Len() > 0) {
+			v := deepCopyVisit{ptr: src.Pointer(), typ: srcType, dstTyp: dstType}
 
-					if isBase {
-						if useParam {
-							if paramSlice == nil {
-								ov, notFound = ReflectCall(s.Addr(), tagGetter, paramVal)
-							} else {
-								ov, notFound = ReflectCall(s.Addr(), tagGetter, paramSlice)
-							}
-						} else {
-							ov, notFound = ReflectCall(s.Addr(), tagGetter)
-						}
-					} else {
-						if useParam {
-							if paramSlice == nil {
-								ov, notFound = ReflectCall(o, tagGetter, paramVal)
-							} else {
-								ov, notFound = ReflectCall(o, tagGetter, paramSlice)
-							}
-						} else {
-							ov, notFound = ReflectCall(o, tagGetter)
-						}
-					}
+			if src.Kind() == reflect.Slice {
+				v.len = src.Len()
+			}
 
-					if !notFound {
-						if len(ov) > 0 {
-							o = ov[0]
-						}
-					}
+			if c, ok := visited[v]; ok {
+				if !c.IsValid() {
+					// copy in progress without reusable dst copy
+					return fmt.Errorf("Field %s Contains Cyclic Reference", path)
 				}
 
-				buf, skip, err := ReflectValueToString(o, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank)
+				dst.Set(c)
+				return nil
+			}
 
-				if err != nil || skip {
-					if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-						if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-							delete(uniqueMap, strings.ToLower(tagUniqueId))
-						}
-					}
+			// marked in progress until dst copy is allocated, see recordVisit
+			visited[v] = reflect.Value{}
+			visit = &v
 
-					continue
+			defer func() {
+				if c, ok := visited[v]; ok && !c.IsValid() {
+					delete(visited, v)
 				}
+			}()
+		}
+	}
 
-				defVal := field.Tag.Get("def")
+	// recordVisit records the allocated dst copy of src, prior to recursing into its elements
+	recordVisit := func(c reflect.Value) {
+		if visit != nil && c.Type() == dstType {
+			visited[*visit] = c
+		}
+	}
 
-				if oldVal.Kind() == reflect.Int && oldVal.Int() == 0 && strings.ToLower(buf) == "unknown" {
-					// unknown enum value will be serialized as blank
-					buf = ""
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dstType))
+			return nil
+		}
 
-					if len(defVal) > 0 {
-						buf = defVal
-					} else {
-						if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-							if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-								// remove uniqueid if skip
-								delete(uniqueMap, strings.ToLower(tagUniqueId))
-								continue
-							}
-						}
-					}
+		if dst.Kind() != reflect.Ptr {
+			// dereference src pointer into dst value
+			return deepCopyValue(src.Elem(), dst, tagName, path, visited)
+		}
+
+		n := reflect.New(dstType.Elem())
+		recordVisit(n)
+
+		if err := deepCopyValue(src.Elem(), n.Elem(), tagName, path, visited); err != nil {
+			return err
+		}
+
+		dst.Set(n)
+		return nil
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dstType))
+			return nil
+		}
+
+		if dst.Kind() == reflect.Interface {
+			n := reflect.New(src.Elem().Type()).Elem()
+
+			if err := deepCopyValue(src.Elem(), n, tagName, path, visited); err != nil {
+				return err
+			}
+
+			if n.Type().AssignableTo(dstType) {
+				dst.Set(n)
+				return nil
+			}
+		} else {
+			return deepCopyValue(src.Elem(), dst, tagName, path, visited)
+		}
+	case reflect.Slice:
+		if dst.Kind() == reflect.Slice {
+			if src.IsNil() {
+				dst.Set(reflect.Zero(dstType))
+				return nil
+			}
+
+			n := reflect.MakeSlice(dstType, src.Len(), src.Len())
+			recordVisit(n)
+
+			for i := 0; i < src.Len(); i++ {
+				if err := deepCopyValue(src.Index(i), n.Index(i), tagName, fmt.Sprintf("%s[%d]", path, i), visited); err != nil {
+					return err
 				}
+			}
 
-				outPrefix := field.Tag.Get("outprefix")
+			dst.Set(n)
+			return nil
+		}
+	case reflect.Array:
+		if dst.Kind() == reflect.Array && dst.Len() == src.Len() {
+			for i := 0; i < src.Len(); i++ {
+				if err := deepCopyValue(src.Index(i), dst.Index(i), tagName, fmt.Sprintf("%s[%d]", path, i), visited); err != nil {
+					return err
+				}
+			}
 
-				if boolTrue == " " && len(buf) == 0 && len(outPrefix) > 0 {
-					buf = outPrefix + defVal
-				} else if boolFalse == " " && buf == "false" && len(outPrefix) > 0 {
-					buf = ""
-				} else if len(defVal) > 0 && len(buf) == 0 {
-					buf = outPrefix + defVal
+			return nil
+		}
+	case reflect.Map:
+		if dst.Kind() == reflect.Map {
+			if src.IsNil() {
+				dst.Set(reflect.Zero(dstType))
+				return nil
+			}
+
+			n := reflect.MakeMapWithSize(dstType, src.Len())
+			recordVisit(n)
+
+			for _, k := range src.MapKeys() {
+				nk := reflect.New(dstType.Key()).Elem()
+
+				if err := deepCopyValue(k, nk, tagName, fmt.Sprintf("%s[%v]", path, k), visited); err != nil {
+					return err
 				}
 
-				buf = strings.Replace(buf, `"`, `\"`, -1)
-				buf = strings.Replace(buf, `'`, `\'`, -1)
+				nv := reflect.New(dstType.Elem()).Elem()
 
-				if LenTrim(output) > 0 {
-					output += ", "
+				if err := deepCopyValue(src.MapIndex(k), nv, tagName, fmt.Sprintf("%s[%v]", path, k), visited); err != nil {
+					return err
 				}
 
-				output += fmt.Sprintf(`"%s":"%s"`, tag, JsonToEscaped(buf))
+				n.SetMapIndex(nk, nv)
+			}
+
+			dst.Set(n)
+			return nil
+		}
+	case reflect.Struct:
+		if dst.Kind() == reflect.Struct {
+			if srcType == dstType {
+				// same type, copy by value first so unexported fields are retained, then deep copy exported fields
+				dst.Set(src)
 			}
+
+			return deepFillStruct(src, dst, tagName, path, visited)
 		}
 	}
 
-	if LenTrim(output) == 0 {
-		return "", fmt.Errorf("MarshalStructToJson Yielded Blank Output")
-	} else {
-		return fmt.Sprintf("{%s}", output), nil
+	return setConvertedValue(src, dst, path)
+}
+
+// setConvertedValue sets src value into dst, if not assignable, src is converted into dst type when convertible,
+// integer to string conversion is not allowed since reflect converts integer to rune rather than digits
+func setConvertedValue(src reflect.Value, dst reflect.Value, path string) error {
+	srcType := src.Type()
+	dstType := dst.Type()
+
+	if srcType.AssignableTo(dstType) {
+		dst.Set(src)
+		return nil
+	}
+
+	if srcType.ConvertibleTo(dstType) {
+		switch src.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if dst.Kind() == reflect.String {
+				return fmt.Errorf("Field %s Type %s Not Convertible To %s", path, srcType, dstType)
+			}
+		case reflect.Slice:
+			if dst.Kind() == reflect.Array || (dst.Kind() == reflect.Ptr && dstType.Elem().Kind() == reflect.Array) {
+				return fmt.Errorf("Field %s Type %s Not Convertible To %s", path, srcType, dstType)
+			}
+		}
+
+		dst.Set(src.Convert(dstType))
+		return nil
 	}
+
+	return fmt.Errorf("Field %s Type %s Not Convertible To %s", path, srcType, dstType)
 }
 
-// UnmarshalJsonToStruct will parse jsonPayload string,
-// and set parsed json element value into struct fields based on struct tag named by tagName,
-// any tagName value with - will be ignored, any excludeTagName defined with value of - will also cause parser to ignore the field
-//
-// note: this method expects simple json in key value pairs only, not json containing slices or more complex json structs within existing json field
-//
-// Predefined Struct Tags Usable:
-// 		1) `setter:"ParseByKey`		// if field type is custom struct or enum,
-//									   specify the custom method (only 1 lookup parameter value allowed) setter that sets value(s) into the field
-//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
-//									   NOTE: setter method always intake a string parameter
-//		2) `def:""`					// default value to set into struct field in case unmarshal doesn't set the struct field value
-//		3) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
-//											2006, 06 = year,
-//											01, 1, Jan, January = month,
-//											02, 2, _2 = day (_2 = width two, right justified)
-//											03, 3, 15 = hour (15 = 24 hour format)
-//											04, 4 = minute
-//											05, 5 = second
-//											PM pm = AM PM
-//		4) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-//		5) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-func UnmarshalJsonToStruct(inputStructPtr interface{}, jsonPayload string, tagName string, excludeTagName string) error {
-	if inputStructPtr == nil {
-		return fmt.Errorf("InputStructPtr is Required")
+// uniqueFieldClaims tracks the uniqueid tag values claimed by struct fields during marshal, keyed by lower case uniqueid,
+// among fields sharing the same uniqueid, only the first field claiming it is marshaled,
+// a field claiming uniqueid but later skipped (such as via skipblank or skipzero) releases its claim, so that the next field may claim it
+type uniqueFieldClaims map[string]string
+
+// claim returns true if field has no uniqueid tag, or its uniqueid is not yet claimed (and is now claimed by field),
+// false is returned if uniqueid is already claimed by another field
+func (u uniqueFieldClaims) claim(field reflect.StructField) bool {
+	id := strings.ToLower(Trim(field.Tag.Get("uniqueid")))
+
+	if len(id) == 0 {
+		return true
 	}
 
-	if LenTrim(jsonPayload) == 0 {
-		return fmt.Errorf("JsonPayload is Required")
+	if _, ok := u[id]; ok {
+		return false
 	}
 
-	if LenTrim(tagName) == 0 {
-		return fmt.Errorf("TagName is Required")
+	u[id] = field.Name
+	return true
+}
+
+// release releases the uniqueid claimed by field, returns true if a claim was released
+func (u uniqueFieldClaims) release(field reflect.StructField) bool {
+	id := strings.ToLower(Trim(field.Tag.Get("uniqueid")))
+
+	if name, ok := u[id]; len(id) > 0 && ok && name == field.Name {
+		delete(u, id)
+		return true
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	return false
+}
 
-	if s.Kind() != reflect.Ptr {
-		return fmt.Errorf("InputStructPtr Must Be Pointer")
-	} else {
-		s = s.Elem()
+// getStructFieldTimeFormat returns the trimmed timeFormat (timeformat tag value) to use for field,
+// except for time.Duration field (or pointer to), where durationformat tag value (ns, us, ms, s, m, h) is returned instead
+func getStructFieldTimeFormat(field reflect.StructField, timeFormat string) string {
+	t := field.Type
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
 
-	if s.Kind() != reflect.Struct {
-		return fmt.Errorf("InputStructPtr Must Be Struct")
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return Trim(field.Tag.Get("durationformat"))
 	}
 
-	// unmarshal json to map
-	jsonMap := make(map[string]json.RawMessage)
+	return Trim(timeFormat)
+}
 
-	if err := json.Unmarshal([]byte(jsonPayload), &jsonMap); err != nil {
-		return fmt.Errorf("Unmarshal Json Failed: %s", err)
+// getStructFieldBoolTag returns the value of tag (booltrue or boolfalse) for field scoped to format (json or csv),
+// such as booltrue.csv, falling back to the generic tag value if the format scoped tag is not defined
+func getStructFieldBoolTag(field reflect.StructField, tag string, format string) string {
+	if v, ok := field.Tag.Lookup(tag + "." + format); ok {
+		return v
 	}
 
-	if jsonMap == nil {
-		return fmt.Errorf("Unmarshaled Json Map is Nil")
+	return field.Tag.Get(tag)
+}
+
+// getStructFieldBoolTagValue parses the bool literal value of tag for field via ParseBoolExtended, blank or undefined tag is false,
+// unrecognized bool literal (such as typo 'ture') returns error naming the field and tag
+func getStructFieldBoolTagValue(field reflect.StructField, tag string) (bool, error) {
+	v := Trim(field.Tag.Get(tag))
+
+	if len(v) == 0 {
+		return false, nil
 	}
 
-	if len(jsonMap) == 0 {
-		return fmt.Errorf("Unmarshaled Json Map Has No Elements")
+	b, err := ParseBoolExtended(v)
+
+	if err != nil {
+		return false, fmt.Errorf("Field %s Declares %s '%s', Expects Bool Literal", field.Name, tag, v)
 	}
 
-	StructClearFields(inputStructPtr)
-	SetStructFieldDefaultValues(inputStructPtr)
+	return b, nil
+}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+// structFieldTags contains the parsed marshal and unmarshal related struct tags of a struct field,
+// parsed once per struct type as part of its cached field layout, see getCachedStructFields
+type structFieldTags struct {
+	BoolTrue      string // booltrue tag as is
+	BoolFalse     string // boolfalse tag as is
+	BoolTrueJson  string // booltrue.json tag, or booltrue tag if not defined
+	BoolFalseJson string // boolfalse.json tag, or boolfalse tag if not defined
+	BoolTrueCSV   string // booltrue.csv tag, or booltrue tag if not defined
+	BoolFalseCSV  string // boolfalse.csv tag, or boolfalse tag if not defined
+	TimeFormat    string // timeformat tag, or durationformat tag for time.Duration field
+	OutPrefix     string // outprefix tag as is
+	Getter        string // getter tag, trimmed
+	Setter        string // setter tag, trimmed
+	SkipBlank     bool
+	SkipZero      bool
+	ZeroBlank     bool
+	UseStringer   bool
+	JsonNull      bool
+	JsonRaw       bool
+	Encrypt       bool
+	NoHash        bool
+	Trim          bool
+	B64Encode     bool
+	B64Decode     bool
+	Err           error // first bool tag declaring unrecognized bool literal, such as skipblank:"ture"
+}
 
-		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
-			// get json field name if defined
-			jName := Trim(field.Tag.Get(tagName))
+// parseStructFieldTags parses the marshal and unmarshal related struct tags of field, see structFieldTags
+func parseStructFieldTags(field reflect.StructField) *structFieldTags {
+	tags := &structFieldTags{
+		BoolTrue:      field.Tag.Get("booltrue"),
+		BoolFalse:     field.Tag.Get("boolfalse"),
+		BoolTrueJson:  getStructFieldBoolTag(field, "booltrue", "json"),
+		BoolFalseJson: getStructFieldBoolTag(field, "boolfalse", "json"),
+		BoolTrueCSV:   getStructFieldBoolTag(field, "booltrue", "csv"),
+		BoolFalseCSV:  getStructFieldBoolTag(field, "boolfalse", "csv"),
+		TimeFormat:    getStructFieldTimeFormat(field, field.Tag.Get("timeformat")),
+		OutPrefix:     field.Tag.Get("outprefix"),
+		Getter:        Trim(field.Tag.Get("getter")),
+		Setter:        Trim(field.Tag.Get("setter")),
+	}
 
-			if jName == "-" {
-				continue
-			}
+	boolTags := []struct {
+		tag string
+		v   *bool
+	}{
+		{"skipblank", &tags.SkipBlank},
+		{"skipzero", &tags.SkipZero},
+		{"zeroblank", &tags.ZeroBlank},
+		{"usestringer", &tags.UseStringer},
+		{"jsonnull", &tags.JsonNull},
+		{"jsonraw", &tags.JsonRaw},
+		{"encrypt", &tags.Encrypt},
+		{"nohash", &tags.NoHash},
+		{"trim", &tags.Trim},
+		{"b64encode", &tags.B64Encode},
+		{"b64decode", &tags.B64Decode},
+	}
 
-			if LenTrim(excludeTagName) > 0 {
-				if Trim(field.Tag.Get(excludeTagName)) == "-" {
-					continue
-				}
-			}
+	for _, b := range boolTags {
+		var err error
 
-			if LenTrim(jName) == 0 {
-				jName = field.Name
-			}
+		if *b.v, err = getStructFieldBoolTagValue(field, b.tag); err != nil && tags.Err == nil {
+			tags.Err = err
+		}
+	}
 
-			// get json field value based on jName from jsonMap
-			jValue := ""
-			timeFormat := Trim(field.Tag.Get("timeformat"))
+	return tags
+}
 
-			if jRaw, ok := jsonMap[jName]; !ok {
-				continue
-			} else {
-				jValue = JsonFromEscaped(string(jRaw))
+// isStructFieldTimeType returns true if field is time.Time, *time.Time or sql.NullTime
+func isStructFieldTimeType(field reflect.StructField) bool {
+	t := field.Type
 
-				if len(jValue) > 0 {
-					if tagSetter := Trim(field.Tag.Get("setter")); len(tagSetter) > 0 {
-						isBase := false
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
 
-						if strings.ToLower(Left(tagSetter, 5)) == "base." {
-							isBase = true
-							tagSetter = Right(tagSetter, len(tagSetter)-5)
-						}
+	return t == reflect.TypeOf(time.Time{}) || t == reflect.TypeOf(sql.NullTime{})
+}
 
-						if o.Kind() != reflect.Ptr && o.Kind() != reflect.Interface && o.Kind() != reflect.Struct && o.Kind() != reflect.Slice {
-							// o is not ptr, interface, struct
-							var results []reflect.Value
-							var notFound bool
+// Format defines the serialization format used by MarshalStruct and UnmarshalStruct
+type Format int
 
-							if isBase {
-								results, notFound = ReflectCall(s.Addr(), tagSetter, jValue)
-							} else {
-								results, notFound = ReflectCall(o, tagSetter, jValue)
-							}
+const (
+	FormatJson Format = iota
+	FormatCSV
+	FormatQueryParams
+)
 
-							if !notFound && len(results) > 0 {
-								if len(results) == 1 {
-									if jv, _, err := ReflectValueToString(results[0], "", "", false, false, timeFormat, false); err == nil {
-										jValue = jv
-									}
-								} else if len(results) > 1 {
-									getFirstVar := true
+// MarshalOptions defines options for MarshalStruct, struct tags defined on a field always take precedence over option defaults
+type MarshalOptions struct {
+	// TagName is the struct tag holding the json or query param name, such as json, used by FormatJson and FormatQueryParams
+	TagName string
 
-									if e, ok := results[len(results)-1].Interface().(error); ok {
-										// last var is error, check if error exists
-										if e != nil {
-											getFirstVar = false
-										}
-									}
+	// ExcludeTagName is the struct tag that if set to "-" excludes the field, used by FormatJson and FormatQueryParams
+	ExcludeTagName string
 
-									if getFirstVar {
-										if jv, _, err := ReflectValueToString(results[0], "", "", false, false, timeFormat, false); err == nil {
-											jValue = jv
-										}
-									}
-								}
-							}
-						} else {
-							// o is ptr, interface, struct
-							// get base type
-							if o.Kind() != reflect.Slice {
-								if baseType, _, isNilPtr := DerefPointersZero(o); isNilPtr {
-									// create new struct pointer
-									o.Set(reflect.New(baseType.Type()))
-								} else {
-									if o.Kind() == reflect.Interface && o.Interface() == nil {
-										customType := ReflectTypeRegistryGet(o.Type().String())
+	// Delimiter is the csv delimiter used by FormatCSV, defaults to comma if blank
+	Delimiter string
 
-										if customType == nil {
-											return fmt.Errorf("%s Struct Field %s is Interface Without Actual Object Assignment", s.Type(), o.Type())
-										} else {
-											o.Set(reflect.New(customType))
-										}
-									}
-								}
-							}
+	// SkipBlankAll if true, treats every field as if skipblank:"true" is defined
+	SkipBlankAll bool
 
-							var ov []reflect.Value
-							var notFound bool
+	// SkipZeroAll if true, treats every field as if skipzero:"true" is defined
+	SkipZeroAll bool
 
-							if isBase {
-								ov, notFound = ReflectCall(s.Addr(), tagSetter, jValue)
-							} else {
-								ov, notFound = ReflectCall(o, tagSetter, jValue)
-							}
+	// TimeFormatDefault is the time format used by time.Time (or pointer to), and sql.NullTime fields not defining timeformat tag
+	TimeFormatDefault string
 
-							if !notFound {
-								if len(ov) == 1 {
-									if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
-										o.Set(ov[0])
-									}
-								} else if len(ov) > 1 {
-									getFirstVar := true
+	// BoolTrue and BoolFalse are the bool literals used by fields not defining booltrue and boolfalse tags
+	BoolTrue  string
+	BoolFalse string
 
-									if e := DerefError(ov[len(ov)-1]); e != nil {
-										getFirstVar = false
-									}
+	// Transform if not nil is invoked for each field's stringified value, see MarshalStructToJsonFunc, used by FormatJson only
+	Transform func(fieldName string, tagName string, value string) string
 
-									if getFirstVar {
-										if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
-											o.Set(ov[0])
-										}
-									}
-								}
-							}
+	// Indent if not blank pretty prints json output with the given indent per nesting level, see MarshalStructToJsonIndent, used by FormatJson only
+	Indent string
 
-							// for o as ptr
-							// once complete, continue
-							continue
-						}
-					}
-				}
-			}
+	// QueryEscape if true escapes values via url.QueryEscape instead of url.PathEscape, see MarshalStructToQueryString, used by FormatQueryParams only
+	QueryEscape bool
 
-			// set validated csv value into corresponding struct field
-			outPrefix := field.Tag.Get("outprefix")
-			boolTrue := field.Tag.Get("booltrue")
-			boolFalse := field.Tag.Get("boolfalse")
+	// BlankOutputReturnsError if not nil overrides the package default set by SetBlankOutputReturnsError for this call
+	BlankOutputReturnsError *bool
 
-			if boolTrue == " " && len(outPrefix) > 0 && jValue == outPrefix {
-				jValue = "true"
-			} else {
-				evalOk := false
-				if LenTrim(boolTrue) > 0 && len(jValue) > 0 && boolTrue == jValue {
-					jValue = "true"
-					evalOk = true
-				}
+	// Redact if true redacts field values per `mask:""` struct tag via RedactString, for logging purpose, see MarshalStructToJsonRedacted
+	Redact bool
 
-				if !evalOk {
-					if LenTrim(boolFalse) > 0 && len(jValue) > 0 && boolFalse == jValue {
-						jValue = "false"
-					}
-				}
-			}
+	// skipNoHash if true excludes fields defining `nohash:"true"` struct tag, see StructFingerprint
+	skipNoHash bool
 
-			if err := ReflectStringToField(o, jValue, timeFormat); err != nil {
-				return err
-			}
+	// encryptGCM if not nil encrypts values of fields defining `encrypt:"true"` struct tag, see MarshalStructToJsonEncrypted
+	encryptGCM cipher.AEAD
+}
+
+// redact returns value redacted per struct tag `mask:""` of field, if opts enables Redact, otherwise value is returned as is, opts may be nil
+func (opts *MarshalOptions) redact(field reflect.StructField, value string) string {
+	if opts == nil || !opts.Redact {
+		return value
+	}
+
+	if mode := Trim(field.Tag.Get("mask")); len(mode) > 0 {
+		return RedactString(value, mode)
+	}
+
+	return value
+}
+
+// getMarshalOptions returns the first non-nil marshal options given as optional variadic parameter, or nil if none given
+func getMarshalOptions(opts []*MarshalOptions) *MarshalOptions {
+	for _, o := range opts {
+		if o != nil {
+			return o
 		}
 	}
 
 	return nil
 }
 
-// MarshalSliceStructToJson accepts a slice of struct pointer, then using tagName and excludeTagName to marshal to json array
-// To pass in inputSliceStructPtr, convert slice of actual objects at the calling code, using SliceObjectsToSliceInterface(),
-// if there is a need to name the value of tagName, but still need to exclude from output, use the excludeTagName with -, such as `x:"-"`
-func MarshalSliceStructToJson(inputSliceStructPtr []interface{}, tagName string, excludeTagName string) (jsonArrayOutput string, err error) {
-	if len(inputSliceStructPtr) == 0 {
-		return "", fmt.Errorf("Input Slice Struct Pointer Nil")
+// blankOutputReturnsError returns whether marshal yielding blank output returns error, per opts override or package default, opts may be nil
+func (opts *MarshalOptions) blankOutputReturnsError() bool {
+	if opts != nil && opts.BlankOutputReturnsError != nil {
+		return *opts.BlankOutputReturnsError
 	}
 
-	for _, v := range inputSliceStructPtr {
-		if s, e := MarshalStructToJson(v, tagName, excludeTagName); e != nil {
-			return "", fmt.Errorf("MarshalSliceStructToJson Failed: %s", e)
-		} else {
-			if LenTrim(jsonArrayOutput) > 0 {
-				jsonArrayOutput += ", "
-			}
+	return isBlankOutputReturnsError()
+}
 
-			jsonArrayOutput += s
-		}
+// apply merges marshal option defaults into the tag values already read from field, opts may be nil
+func (opts *MarshalOptions) apply(field reflect.StructField, boolTrue *string, boolFalse *string, timeFormat *string, skipBlank *bool, skipZero *bool) {
+	if opts == nil {
+		return
 	}
 
-	if LenTrim(jsonArrayOutput) > 0 {
-		return fmt.Sprintf("[%s]", jsonArrayOutput), nil
-	} else {
-		return "", fmt.Errorf("MarshalSliceStructToJson Yielded Blank String")
+	if opts.SkipBlankAll {
+		*skipBlank = true
+	}
+
+	if opts.SkipZeroAll {
+		*skipZero = true
+	}
+
+	if len(*timeFormat) == 0 && isStructFieldTimeType(field) {
+		*timeFormat = Trim(opts.TimeFormatDefault)
+	}
+
+	if len(*boolTrue) == 0 && len(*boolFalse) == 0 {
+		*boolTrue = opts.BoolTrue
+		*boolFalse = opts.BoolFalse
 	}
 }
 
-// StructClearFields will clear all fields within struct with default value
-func StructClearFields(inputStructPtr interface{}) {
-	if inputStructPtr == nil {
-		return
+// UnmarshalOptions defines options for UnmarshalStruct, struct tags defined on a field always take precedence over option defaults
+type UnmarshalOptions struct {
+	// TagName is the struct tag holding the json name, such as json, used by FormatJson
+	TagName string
+
+	// ExcludeTagName is the struct tag that if set to "-" excludes the field, used by FormatJson
+	ExcludeTagName string
+
+	// Delimiter is the csv delimiter used by FormatCSV, defaults to comma if blank
+	Delimiter string
+
+	// CustomDelimiterParser if not nil is used by FormatCSV to split the csv payload, see UnmarshalCSVToStruct
+	CustomDelimiterParser func(string) []string
+
+	// TimeFormatDefault is the time format used by time.Time (or pointer to), and sql.NullTime fields not defining timeformat tag
+	TimeFormatDefault string
+
+	// BoolTrue and BoolFalse are the bool literals used by fields not defining booltrue and boolfalse tags
+	BoolTrue  string
+	BoolFalse string
+
+	// StrictUnknownFields if true, FormatJson returns error listing json keys not matching any struct field, see UnmarshalJsonToStructStrict
+	StrictUnknownFields bool
+
+	// CaseInsensitive if true, FormatJson matches json keys to struct fields case insensitively, see UnmarshalJsonToStructCI
+	CaseInsensitive bool
+
+	// decryptGCM if not nil decrypts values of fields tagged encrypt, see UnmarshalJsonToStructEncrypted
+	decryptGCM cipher.AEAD
+}
+
+// boolTag returns the value of tag (booltrue or boolfalse) for field scoped to format (json or csv),
+// falling back to the option default if neither booltrue nor boolfalse is defined on field, opts may be nil
+func (opts *UnmarshalOptions) boolTag(field reflect.StructField, tag string, format string) string {
+	v := getStructFieldBoolTag(field, tag, format)
+
+	if opts == nil || len(v) > 0 {
+		return v
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	if len(getStructFieldBoolTag(field, "booltrue", format)) > 0 || len(getStructFieldBoolTag(field, "boolfalse", format)) > 0 {
+		return v
+	}
 
-	if s.Kind() != reflect.Ptr {
-		return
-	} else {
-		s = s.Elem()
+	if tag == "booltrue" {
+		return opts.BoolTrue
 	}
 
-	if s.Kind() != reflect.Struct {
-		return
+	return opts.BoolFalse
+}
+
+// timeFormat returns the time format for field, falling back to the option default for time fields not defining timeformat tag, opts may be nil
+func (opts *UnmarshalOptions) timeFormat(field reflect.StructField) string {
+	v := getStructFieldTimeFormat(field, field.Tag.Get("timeformat"))
+
+	if opts != nil && len(v) == 0 && isStructFieldTimeType(field) {
+		v = Trim(opts.TimeFormatDefault)
 	}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+	return v
+}
 
-		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
-			switch o.Kind() {
-			case reflect.String:
-				o.SetString("")
-			case reflect.Bool:
-				o.SetBool(false)
-			case reflect.Int8:
-				fallthrough
-			case reflect.Int16:
-				fallthrough
-			case reflect.Int:
-				fallthrough
-			case reflect.Int32:
-				fallthrough
-			case reflect.Int64:
-				o.SetInt(0)
-			case reflect.Float32:
-				fallthrough
-			case reflect.Float64:
-				o.SetFloat(0)
-			case reflect.Uint8:
-				fallthrough
-			case reflect.Uint16:
-				fallthrough
-			case reflect.Uint:
-				fallthrough
-			case reflect.Uint32:
-				fallthrough
-			case reflect.Uint64:
-				o.SetUint(0)
-			case reflect.Ptr:
-				o.Set(reflect.Zero(o.Type()))
-			case reflect.Slice:
-				o.Set(reflect.Zero(o.Type()))
-			default:
-				switch o.Interface().(type) {
-				case sql.NullString:
-					o.Set(reflect.ValueOf(sql.NullString{}))
-				case sql.NullBool:
-					o.Set(reflect.ValueOf(sql.NullBool{}))
-				case sql.NullFloat64:
-					o.Set(reflect.ValueOf(sql.NullFloat64{}))
-				case sql.NullInt32:
-					o.Set(reflect.ValueOf(sql.NullInt32{}))
-				case sql.NullInt64:
-					o.Set(reflect.ValueOf(sql.NullInt64{}))
-				case sql.NullTime:
-					o.Set(reflect.ValueOf(sql.NullTime{}))
-				case time.Time:
-					o.Set(reflect.ValueOf(time.Time{}))
-				default:
-					o.Set(reflect.Zero(o.Type()))
-				}
-			}
-		}
-	}
-}
+// getStructFieldTimeZone returns the time.Location named by field's timezone tag (or its tz alias), such as UTC or America/Chicago,
+// if neither tag is defined, nil location is returned, if the zone name is not valid, error is returned
+func getStructFieldTimeZone(field reflect.StructField) (*time.Location, error) {
+	tz := Trim(field.Tag.Get("timezone"))
 
-// StructNonDefaultRequiredFieldsCount returns count of struct fields that are tagged as required but not having any default values pre-set
-func StructNonDefaultRequiredFieldsCount(inputStructPtr interface{}) int {
-	if inputStructPtr == nil {
-		return 0
+	if len(tz) == 0 {
+		tz = Trim(field.Tag.Get("tz"))
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	if len(tz) == 0 {
+		return nil, nil
+	}
 
-	if s.Kind() != reflect.Ptr {
-		return 0
+	if loc, err := time.LoadLocation(tz); err != nil {
+		return nil, fmt.Errorf("Field %s Time Zone '%s' Not Valid: %s", field.Name, tz, err)
 	} else {
-		s = s.Elem()
+		return loc, nil
 	}
+}
 
-	if s.Kind() != reflect.Struct {
-		return 0
+// validateStructTimeZones verifies that timezone (or tz) struct tag of each field names a valid time zone,
+// otherwise error of the first invalid time zone encountered is returned
+func validateStructTimeZones(fields []structFieldValue) error {
+	for _, sf := range fields {
+		if _, err := getStructFieldTimeZone(sf.Field); err != nil {
+			return err
+		}
 	}
 
-	count := 0
+	return nil
+}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+// structFieldValue contains the struct field definition and its reflected value,
+// the field may be promoted from an embedded (anonymous) struct
+type structFieldValue struct {
+	Field reflect.StructField
+	Value reflect.Value
+	Tags  *structFieldTags // parsed struct tags of field, cached per struct type
+}
 
-		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
-			tagDef := field.Tag.Get("def")
-			tagReq := field.Tag.Get("req")
+// getStructFieldValues returns fields of struct value s in declaration order,
+// fields of embedded (anonymous) struct are promoted as if they belong to s (same as encoding/json),
+// unless the embedded struct field itself is named via tagName, in which case it is returned as a regular field,
+// embedded struct field with tagName value of - is excluded,
+// on field name collision, the outer (shallower) field wins, comparing both the tagName value (if defined) and the field name,
+// if allocNilEmbedded is true, nil embedded struct pointers are allocated so that promoted fields can be set (for unmarshal),
+// otherwise fields of nil embedded struct pointers are excluded
+func getStructFieldValues(s reflect.Value, tagName string, allocNilEmbedded bool) []structFieldValue {
+	type depthField struct {
+		sf    structFieldValue
+		depth int
+	}
 
-			if len(tagDef) == 0 && strings.ToLower(tagReq) == "true" {
-				// required and no default value
-				count++
+	cached := getCachedStructFields(s.Type(), tagName)
+	list := make([]depthField, 0, len(cached))
+
+	for _, cf := range cached {
+		v := s
+		reachable := true
+
+		// navigate through embedded struct fields to the field's parent struct
+		for _, i := range cf.Index[:len(cf.Index)-1] {
+			v = v.Field(i)
+
+			if v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					if !allocNilEmbedded || !v.CanSet() {
+						reachable = false
+						break
+					}
+
+					v.Set(reflect.New(v.Type().Elem()))
+				}
+
+				v = v.Elem()
 			}
 		}
+
+		if reachable {
+			list = append(list, depthField{sf: structFieldValue{Field: cf.Field, Value: v.Field(cf.Index[len(cf.Index)-1]), Tags: cf.Tags}, depth: cf.Depth})
+		}
 	}
 
-	return count
-}
+	// resolve name collisions, shallower depth wins by tagName value (or field name), then first declared wins by field name,
+	// fields of the same depth sharing tagName value are all kept (such as csv fields sharing pos via uniqueid)
+	names := make([]string, len(list))
+	minDepth := make(map[string]int, len(list))
+	minDepthByName := make(map[string]int, len(list))
 
-// IsStructFieldSet checks if any field value is not default blank or zero
-func IsStructFieldSet(inputStructPtr interface{}) bool {
-	if inputStructPtr == nil {
-		return false
+	for i, v := range list {
+		names[i] = getStructFieldCollisionName(v.sf.Field, tagName)
+
+		if d, ok := minDepth[names[i]]; !ok || v.depth < d {
+			minDepth[names[i]] = v.depth
+		}
+
+		if d, ok := minDepthByName[v.sf.Field.Name]; !ok || v.depth < d {
+			minDepthByName[v.sf.Field.Name] = v.depth
+		}
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	result := make([]structFieldValue, 0, len(list))
+	added := make(map[string]bool, len(list))
 
-	if s.Kind() != reflect.Ptr {
-		return false
-	} else {
-		s = s.Elem()
+	for i, v := range list {
+		if v.depth == minDepth[names[i]] && v.depth == minDepthByName[v.sf.Field.Name] && !added[v.sf.Field.Name] {
+			added[v.sf.Field.Name] = true
+			result = append(result, v.sf)
+		}
 	}
 
-	if s.Kind() != reflect.Struct {
-		return false
+	return result
+}
+
+// getStructFieldCollisionName returns the name used to detect promoted field collision,
+// which is the tagName value if defined (and not -), otherwise the field name
+func getStructFieldCollisionName(field reflect.StructField, tagName string) string {
+	if name, _ := getStructFieldNameTag(field, tagName); len(name) > 0 && name != "-" {
+		return name
 	}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+	return field.Name
+}
 
-		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
-			tagDef := field.Tag.Get("def")
+// structFieldCacheKey identifies the cached field layout of struct type t, as promoted for tagName
+type structFieldCacheKey struct {
+	Type    reflect.Type
+	TagName string
+}
 
-			switch o.Kind() {
-			case reflect.String:
-				if LenTrim(o.String()) > 0 {
-					if o.String() != tagDef	{
-						return true
-					}
-				}
-			case reflect.Bool:
-				if o.Bool() {
-					return true
-				}
-			case reflect.Int8:
-				fallthrough
-			case reflect.Int16:
-				fallthrough
-			case reflect.Int:
-				fallthrough
-			case reflect.Int32:
-				fallthrough
-			case reflect.Int64:
-				if o.Int() != 0 {
-					if Int64ToString(o.Int()) != tagDef	{
-						return true
-					}
+// cachedStructField is the reflection metadata of a struct field, as promoted into its top level struct
+type cachedStructField struct {
+	Field reflect.StructField
+	Index []int // field index path from the top level struct, through embedded struct fields
+	Depth int
+	Tags  *structFieldTags
+}
+
+// struct tag cache, so that repeated marshal and unmarshal of the same struct type does not re-read and re-parse struct tags via reflection
+var structFieldCache = make(map[structFieldCacheKey][]cachedStructField)
+var structValidateTagsCache = make(map[reflect.StructTag]structFieldValidateTags)
+var structTagCacheMu sync.RWMutex
+
+// ClearStructTagCache clears the cached struct field layouts and parsed struct tags,
+// the cache is rebuilt on demand by subsequent marshal and unmarshal calls
+func ClearStructTagCache() {
+	structTagCacheMu.Lock()
+	defer structTagCacheMu.Unlock()
+
+	structFieldCache = make(map[structFieldCacheKey][]cachedStructField)
+	structValidateTagsCache = make(map[reflect.StructTag]structFieldValidateTags)
+}
+
+// getCachedStructFields returns the field layout of struct type t (pre name collision resolution) from cache,
+// the layout is built and cached upon first request, see getStructFieldValues for the embedded struct promotion rules
+func getCachedStructFields(t reflect.Type, tagName string) []cachedStructField {
+	key := structFieldCacheKey{Type: t, TagName: tagName}
+
+	structTagCacheMu.RLock()
+	cached, ok := structFieldCache[key]
+	structTagCacheMu.RUnlock()
+
+	if ok {
+		return cached
+	}
+
+	cached = []cachedStructField{}
+
+	var walk func(st reflect.Type, index []int, depth int)
+	onPath := make(map[reflect.Type]bool)
+
+	walk = func(st reflect.Type, index []int, depth int) {
+		// recursive embedding of struct type already being walked is not promoted
+		onPath[st] = true
+		defer delete(onPath, st)
+
+		for i := 0; i < st.NumField(); i++ {
+			field := st.Field(i)
+			fieldIndex := append(append([]int{}, index...), i)
+
+			if field.Anonymous && depth < 32 {
+				ft := field.Type
+
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
 				}
-			case reflect.Float32:
-				fallthrough
-			case reflect.Float64:
-				if o.Float() != 0 {
-					if Float64ToString(o.Float()) != tagDef	{
-						return true
-					}
+
+				tag := ""
+
+				if len(tagName) > 0 {
+					tag, _ = getStructFieldNameTag(field, tagName)
 				}
-			case reflect.Uint8:
-				fallthrough
-			case reflect.Uint16:
-				fallthrough
-			case reflect.Uint:
-				fallthrough
-			case reflect.Uint32:
-				fallthrough
-			case reflect.Uint64:
-				if o.Uint() > 0 {
-					if UInt64ToString(o.Uint()) != tagDef {
-						return true
+
+				if ft.Kind() == reflect.Struct && ft.PkgPath() != "time" && ft.PkgPath() != "database/sql" && len(tag) == 0 {
+					if !onPath[ft] {
+						walk(ft, fieldIndex, depth+1)
 					}
+
+					continue
+				} else if tag == "-" {
+					continue
 				}
-			case reflect.Ptr:
-				if !o.IsNil() {
-					return true
-				}
-			case reflect.Slice:
-				if o.Len() > 0 {
-					return true
-				}
-			default:
-				switch f := o.Interface().(type) {
-				case sql.NullString:
-					if f.Valid {
-						if len(tagDef) == 0 {
-							return true
-						} else {
-							if f.String != tagDef {
-								return true
-							}
-						}
-					}
-				case sql.NullBool:
-					if f.Valid {
-						if len(tagDef) == 0 {
-							return true
-						} else {
-							if f.Bool, _ = ParseBool(tagDef); f.Bool {
-								return true
-							}
-						}
-					}
-				case sql.NullFloat64:
-					if f.Valid {
-						if len(tagDef) == 0 {
-							return true
-						} else {
-							if Float64ToString(f.Float64) != tagDef {
-								return true
-							}
-						}
-					}
-				case sql.NullInt32:
-					if f.Valid {
-						if len(tagDef) == 0 {
-							return true
-						} else {
-							if Itoa(int(f.Int32)) != tagDef {
-								return true
-							}
-						}
-					}
-				case sql.NullInt64:
-					if f.Valid {
-						if len(tagDef) == 0 {
-							return true
-						} else {
-							if Int64ToString(f.Int64) != tagDef {
-								return true
-							}
-						}
-					}
-				case sql.NullTime:
-					if f.Valid {
-						if len(tagDef) == 0 {
-							return true
-						} else {
-							tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+			}
 
-							if LenTrim(tagTimeFormat) == 0 {
-								tagTimeFormat = DateTimeFormatString()
-							}
+			cached = append(cached, cachedStructField{Field: field, Index: fieldIndex, Depth: depth, Tags: parseStructFieldTags(field)})
+		}
+	}
 
-							if f.Time != ParseDateTimeCustom(tagDef, tagTimeFormat) {
-								return true
-							}
-						}
-					}
-				case time.Time:
-					if !f.IsZero() {
-						if len(tagDef) == 0 {
-							return true
-						} else {
-							tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+	walk(t, []int{}, 0)
 
-							if LenTrim(tagTimeFormat) == 0 {
-								tagTimeFormat = DateTimeFormatString()
-							}
+	structTagCacheMu.Lock()
+	structFieldCache[key] = cached
+	structTagCacheMu.Unlock()
 
-							if f != ParseDateTimeCustom(tagDef, tagTimeFormat) {
-								return true
-							}
-						}
-					}
-				default:
-					if o.Kind() == reflect.Interface && o.Interface() != nil {
-						return true
-					}
-				}
+	return cached
+}
+
+// invokeStructFieldGetter invokes the custom method defined in struct tag `getter:""` for field value o, and returns the first result value,
+// if getter is prefixed with 'base.', the method is invoked on s (the parent struct), otherwise on o,
+// if getter is suffixed with argument list such as '(x)' or '(field:OtherField, lit:'USD', self)', each argument is resolved as:
+//		1) x or self = field value o
+//		2) field:Name = value of sibling field Name in s
+//		3) lit:'value' = literal value (quotes optional, literal may not contain comma)
+// argument whose value is assignable to the method's declared parameter type is passed as is (such as slice or map passed to '(x)'),
+// otherwise it is stringified and converted to the declared parameter type, error names the argument failing conversion,
+// if the getter method is not found or returns no result, o is returned as is
+func invokeStructFieldGetter(s reflect.Value, o reflect.Value, tagGetter string, boolTrue string, boolFalse string, skipBlank bool, skipZero bool, timeFormat string, zeroBlank bool) (reflect.Value, error) {
+	isBase, methodName, args, hasArgs := parseStructFieldGetterTag(tagGetter)
+	target := o
+
+	if isBase {
+		target = s.Addr()
+	}
+
+	if !target.IsValid() {
+		return o, nil
+	}
+
+	method := target.MethodByName(methodName)
+
+	if !method.IsValid() {
+		return o, nil
+	}
+
+	var params []reflect.Value
+
+	if hasArgs {
+		if method.Type().NumIn() != len(args) {
+			return o, fmt.Errorf("Getter %s Expects %d Arguments, %d Given", methodName, method.Type().NumIn(), len(args))
+		}
+
+		for i, arg := range args {
+			p, err := resolveStructFieldGetterArg(s, o, arg, method.Type().In(i), boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank)
+
+			if err != nil {
+				return o, fmt.Errorf("Getter %s Argument %d '%s' Not Valid: %s", methodName, i+1, arg, err)
 			}
+
+			params = append(params, p)
 		}
 	}
 
-	return false
+	if ov := method.Call(params); len(ov) > 0 {
+		return ov[0], nil
+	}
+
+	return o, nil
 }
 
-// SetStructFieldDefaultValues sets default value defined in struct tag `def:""` into given field,
-// this method is used during unmarshal action only,
-// default value setting is for value types and fields with `setter:""` defined only,
-// timeformat is used if field is datetime, for overriding default format of ISO style
-func SetStructFieldDefaultValues(inputStructPtr interface{}) bool {
-	if inputStructPtr == nil {
-		return false
+// parseStructFieldGetterTag splits tagGetter into its base. prefix indicator, method name, and argument tokens if argument list is defined
+func parseStructFieldGetterTag(tagGetter string) (isBase bool, method string, args []string, hasArgs bool) {
+	if strings.ToLower(Left(tagGetter, 5)) == "base." {
+		isBase = true
+		tagGetter = Right(tagGetter, len(tagGetter)-5)
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	i := strings.Index(tagGetter, "(")
 
-	if s.Kind() != reflect.Ptr {
-		return false
-	} else {
-		s = s.Elem()
+	if i < 0 || !strings.HasSuffix(tagGetter, ")") {
+		return isBase, Trim(tagGetter), nil, false
 	}
 
-	if s.Kind() != reflect.Struct {
-		return false
+	for _, token := range strings.Split(tagGetter[i+1:len(tagGetter)-1], ",") {
+		if token = Trim(token); len(token) > 0 {
+			args = append(args, token)
+		}
 	}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+	return isBase, Trim(tagGetter[:i]), args, true
+}
 
-		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
-			tagDef := field.Tag.Get("def")
+// resolveStructFieldGetterArg resolves getter argument token (see invokeStructFieldGetter) into value of parameter type t
+func resolveStructFieldGetterArg(s reflect.Value, o reflect.Value, token string, t reflect.Type, boolTrue string, boolFalse string, skipBlank bool, skipZero bool, timeFormat string, zeroBlank bool) (reflect.Value, error) {
+	var src reflect.Value
+	lower := strings.ToLower(token)
 
-			if len(tagDef) == 0 {
-				continue
-			}
+	switch {
+	case lower == "x" || lower == "self":
+		src = o
+	case strings.HasPrefix(lower, "field:"):
+		name := Trim(token[6:])
+		sf, ok := s.Type().FieldByName(name)
 
-			switch o.Kind() {
-			case reflect.String:
-				if LenTrim(o.String()) == 0 {
-					o.SetString(tagDef)
-				}
-			case reflect.Int8:
-				fallthrough
-			case reflect.Int16:
-				fallthrough
-			case reflect.Int:
-				fallthrough
-			case reflect.Int32:
-				fallthrough
-			case reflect.Int64:
-				if o.Int() == 0 {
-					tagSetter := Trim(field.Tag.Get("setter"))
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("Sibling Field %s Not Found", name)
+		}
 
-					if LenTrim(tagSetter) == 0 {
-						if i64, ok := ParseInt64(tagDef); ok && i64 != 0 {
-							if !o.OverflowInt(i64) {
-								o.SetInt(i64)
-							}
-						}
-					} else {
-						if res, notFound := ReflectCall(o, tagSetter, tagDef); !notFound {
-							if len(res) == 1 {
-								if val, skip, err := ReflectValueToString(res[0], "", "", false, false, "", false); err == nil && !skip {
-									tagDef = val
-								} else {
-									continue
-								}
-							} else if len(res) > 1 {
-								if err := DerefError(res[len(res)-1:][0]); err == nil {
-									if val, skip, err := ReflectValueToString(res[0], "", "", false, false, "", false); err == nil && !skip {
-										tagDef = val
-									} else {
-										continue
-									}
-								}
-							}
+		src = s.FieldByIndex(sf.Index)
+		boolTrue, boolFalse = sf.Tag.Get("booltrue"), sf.Tag.Get("boolfalse")
+		skipBlank, skipZero, zeroBlank = false, false, false
+		timeFormat = getStructFieldTimeFormat(sf, sf.Tag.Get("timeformat"))
+	case strings.HasPrefix(lower, "lit:"):
+		return convertStructFieldGetterArg(strings.Trim(Trim(token[4:]), "'"), t)
+	default:
+		return reflect.Value{}, fmt.Errorf("Expects x, self, field:Name or lit:'value'")
+	}
 
-							if i64, ok := ParseInt64(tagDef); ok && i64 != 0 {
-								if !o.OverflowInt(i64) {
-									o.SetInt(i64)
-								}
-							}
-						}
-					}
-				}
-			case reflect.Float32:
-				fallthrough
-			case reflect.Float64:
-				if o.Float() == 0 {
-					if f64, ok := ParseFloat64(tagDef); ok && f64 != 0 {
-						if !o.OverflowFloat(f64) {
-							o.SetFloat(f64)
-						}
-					}
-				}
-			case reflect.Uint8:
-				fallthrough
-			case reflect.Uint16:
-				fallthrough
-			case reflect.Uint:
-				fallthrough
-			case reflect.Uint32:
-				fallthrough
-			case reflect.Uint64:
-				if o.Uint() == 0 {
-					if u64 := StrToUint64(tagDef); u64 != 0 {
-						if !o.OverflowUint(u64) {
-							o.SetUint(u64)
-						}
-					}
-				}
-			default:
-				switch f := o.Interface().(type) {
-				case sql.NullString:
-					if !f.Valid {
-						o.Set(reflect.ValueOf(sql.NullString{String: tagDef, Valid: true}))
-					}
-				case sql.NullBool:
-					if !f.Valid {
-						b, _ := ParseBool(tagDef)
-						o.Set(reflect.ValueOf(sql.NullBool{Bool: b, Valid: true}))
-					}
-				case sql.NullFloat64:
-					if !f.Valid {
-						if f64, ok := ParseFloat64(tagDef); ok && f64 != 0 {
-							o.Set(reflect.ValueOf(sql.NullFloat64{Float64: f64, Valid: true}))
-						}
-					}
-				case sql.NullInt32:
-					if !f.Valid {
-						if i32, ok := ParseInt32(tagDef); ok && i32 != 0 {
-							o.Set(reflect.ValueOf(sql.NullInt32{Int32: int32(i32), Valid: true}))
-						}
-					}
-				case sql.NullInt64:
-					if !f.Valid {
-						if i64, ok := ParseInt64(tagDef); ok && i64 != 0 {
-							o.Set(reflect.ValueOf(sql.NullInt64{Int64: i64, Valid: true}))
-						}
-					}
-				case sql.NullTime:
-					if !f.Valid {
-						tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+	if src.IsValid() && src.CanInterface() && src.Type().AssignableTo(t) {
+		return src, nil
+	}
 
-						if LenTrim(tagTimeFormat) == 0 {
-							tagTimeFormat = DateTimeFormatString()
-						}
+	buf, _, err := ReflectValueToString(src, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank)
 
-						if t := ParseDateTimeCustom(tagDef, tagTimeFormat); !t.IsZero() {
-							o.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
-						}
-					}
-				case time.Time:
-					if f.IsZero() {
-						tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+	if err != nil {
+		return reflect.Value{}, err
+	}
 
-						if LenTrim(tagTimeFormat) == 0 {
-							tagTimeFormat = DateTimeFormatString()
-						}
+	return convertStructFieldGetterArg(buf, t)
+}
 
-						if t := ParseDateTimeCustom(tagDef, tagTimeFormat); !t.IsZero() {
-							o.Set(reflect.ValueOf(t))
-						}
-					}
-				}
+// convertStructFieldGetterArg converts string v into value of parameter type t, numeric and bool conversions are strict
+func convertStructFieldGetterArg(v string, t reflect.Type) (reflect.Value, error) {
+	p := reflect.New(t).Elem()
+
+	switch t.Kind() {
+	case reflect.String:
+		p.SetString(v)
+	case reflect.Bool:
+		b, err := ParseBoolExtended(v)
+
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		p.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == reflect.TypeOf(time.Duration(0)) {
+			if err := ReflectStringToField(p, v, ""); err != nil {
+				return reflect.Value{}, err
 			}
+
+			break
+		}
+
+		i, err := strconv.ParseInt(Trim(v), 10, t.Bits())
+
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("Conversion To %s Failed: %s", t, err)
+		}
+
+		p.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(Trim(v), 10, t.Bits())
+
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("Conversion To %s Failed: %s", t, err)
+		}
+
+		p.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(Trim(v), t.Bits())
+
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("Conversion To %s Failed: %s", t, err)
+		}
+
+		p.SetFloat(f)
+	case reflect.Interface:
+		if !reflect.TypeOf(v).Implements(t) {
+			return reflect.Value{}, fmt.Errorf("Conversion To %s Not Supported", t)
+		}
+
+		p.Set(reflect.ValueOf(v))
+	default:
+		if err := ReflectStringToField(p, v, ""); err != nil {
+			return reflect.Value{}, fmt.Errorf("Conversion To %s Failed: %s", t, err)
 		}
 	}
 
-	return true
+	return p, nil
 }
 
-// UnmarshalCSVToStruct will parse csvPayload string (one line of csv data) using csvDelimiter, (if csvDelimiter = "", then customDelimiterParserFunc is required)
-// and set parsed csv element value into struct fields based on Ordinal Position defined via struct tag,
-// additionally processes struct tag data validation and length / range (if not valid, will set to data type default)
+// parseStructFieldSetterArgs splits tagSetter such as Compute(#FieldA,#FieldB) into the method name and its additional arguments,
+// each #FieldName token resolves to the current value of sibling field FieldName in s (stringified honoring its timeformat tag),
+// token without # prefix is passed as literal string, tagSetter without parenthesis is returned as is without additional arguments,
+// since struct fields are unmarshaled in declaration order, sibling fields referenced should be declared before the setter field
+func parseStructFieldSetterArgs(s reflect.Value, tagSetter string) (method string, args []interface{}, err error) {
+	i := strings.Index(tagSetter, "(")
+
+	if i < 0 || !strings.HasSuffix(tagSetter, ")") {
+		return tagSetter, nil, nil
+	}
+
+	method = Trim(tagSetter[:i])
+
+	for _, token := range strings.Split(tagSetter[i+1:len(tagSetter)-1], ",") {
+		if token = Trim(token); len(token) == 0 {
+			continue
+		}
+
+		if !strings.HasPrefix(token, "#") {
+			args = append(args, token)
+			continue
+		}
+
+		name := token[1:]
+		sf, ok := s.Type().FieldByName(name)
+
+		if !ok {
+			return "", nil, fmt.Errorf("Sibling Field %s Not Found", name)
+		}
+
+		v := s.FieldByIndex(sf.Index)
+		buf, _, e := ReflectValueToString(v, "", "", false, false, getStructFieldTimeFormat(sf, sf.Tag.Get("timeformat")), false)
+
+		if e != nil {
+			return "", nil, fmt.Errorf("Sibling Field %s Value Not Valid: %s", name, e)
+		}
+
+		args = append(args, buf)
+	}
+
+	return method, args, nil
+}
+
+// isStructFieldGetterFound returns true if the getter method named by tagGetter (see invokeStructFieldGetter) exists,
+// on s (the parent struct) if getter is prefixed with 'base.', otherwise on field value o
+func isStructFieldGetterFound(s reflect.Value, o reflect.Value, tagGetter string) bool {
+	isBase, method, _, _ := parseStructFieldGetterTag(tagGetter)
+
+	if isBase {
+		return s.Addr().MethodByName(method).IsValid()
+	}
+
+	return o.IsValid() && o.MethodByName(method).IsValid()
+}
+
+// reflectMapToStringMap returns map value o as string keyed and string valued map, along with its keys in sorted order,
+// map keys and values are stringified via ReflectValueToString
+func reflectMapToStringMap(o reflect.Value, timeFormat string) (keys []string, values map[string]string, err error) {
+	values = make(map[string]string)
+
+	if o.Kind() != reflect.Map || o.IsNil() {
+		return nil, values, nil
+	}
+
+	iter := o.MapRange()
+
+	for iter.Next() {
+		k, _, e := ReflectValueToString(iter.Key(), "", "", false, false, timeFormat, false)
+
+		if e != nil {
+			return nil, nil, e
+		}
+
+		v, _, e := ReflectValueToString(iter.Value(), "", "", false, false, timeFormat, false)
+
+		if e != nil {
+			return nil, nil, e
+		}
+
+		keys = append(keys, k)
+		values[k] = v
+	}
+
+	sort.Strings(keys)
+	return keys, values, nil
+}
+
+// validateStructExclusiveGroups verifies that within each `exclusive:"group"` struct tag group, at most one field holds a non-zero value,
+// otherwise error naming the group and its conflicting fields is returned
+func validateStructExclusiveGroups(fields []structFieldValue) error {
+	var groups []string
+	groupFields := make(map[string][]string)
+
+	for _, sf := range fields {
+		group := Trim(sf.Field.Tag.Get("exclusive"))
+
+		if len(group) == 0 || !sf.Value.IsValid() || sf.Value.IsZero() {
+			continue
+		}
+
+		key := strings.ToLower(group)
+
+		if _, ok := groupFields[key]; !ok {
+			groups = append(groups, group)
+		}
+
+		groupFields[key] = append(groupFields[key], sf.Field.Name)
+	}
+
+	for _, group := range groups {
+		if names := groupFields[strings.ToLower(group)]; len(names) > 1 {
+			return fmt.Errorf("Exclusive Group '%s' Has Conflicting Fields Set: %s", group, strings.Join(names, ", "))
+		}
+	}
+
+	return nil
+}
+
+// MarshalStructToQueryParams marshals a struct pointer's fields to query params string,
+// output query param names are based on values given in tagName,
+// tagName value is parsed in encoding/json style, such as `json:"name,omitempty"`, where omitempty is same as skipblank and skipzero both true,
+// to exclude certain struct fields from being marshaled, use - as value in struct tag defined by tagName,
+// if there is a need to name the value of tagName, but still need to exclude from output, use the excludeTagName with -, such as `x:"-"`,
+// fields of embedded (anonymous) struct are promoted and marshaled as if they belong to the outer struct, outer fields win on name collision
 //
-// Predefined Struct Tags Usable:
-//		1) `pos:"1"`				// ordinal position of the field in relation to the csv parsed output expected (Zero-Based Index)
-//									   NOTE: if field is mutually exclusive with one or more uniqueId, then pos # should be named the same for all uniqueIds,
-//											 if multiple fields are in exclusive condition, and skipBlank or skipZero, always include a blank default field as the last of unique field list
-//										     if value is '-', this means position value is calculated from other fields and set via `setter:"base.Xyz"` during unmarshal csv, there is no marshal to csv for this field
-//		2) `type:"xyz"`				// data type expected:
-//											A = AlphabeticOnly, N = NumericOnly 0-9, AN = AlphaNumeric, ANS = AN + PrintableSymbols,
-//											H = Hex, B64 = Base64, B = true/false, REGEX = Regular Expression, Blank = Any,
-//		3) `regex:"xyz"`			// if Type = REGEX, this struct tag contains the regular expression string,
-//										 	regex express such as [^A-Za-z0-9_-]+
-//										 	method will replace any regex matched string to blank
-//		4) `size:"x..y"`			// data type size rule:
-//											x = Exact size match
-//											x.. = From x and up
-//											..y = From 0 up to y
-//											x..y = From x to y
-//											+%z = Append to x, x.., ..y, x..y; adds additional constraint that the result size must equate to 0 from modulo of z
-//		5) `range:"x..y"`			// data type range value when Type is N, if underlying data type is string, method will convert first before testing
-//		6) `req:"true"`				// indicates data value is required or not, true or false
-//		7) `getter:"Key"`			// if field type is custom struct or enum, specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
+// special struct tags:
+//		1) `getter:"Key"`			// if field type is custom struct or enum,
+//									   specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
 //									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
 //									   NOTE: if the method is to receive a parameter value, always in string data type, add '(x)' after the method name, such as 'XYZ(x)' or 'base.XYZ(x)'
-// 		8) `setter:"ParseByKey`		// if field type is custom struct or enum, specify the custom method (only 1 lookup parameter value allowed) setter that sets value(s) into the field
-//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
-//									   NOTE: setter method always intake a string parameter value
-//		9) `outprefix:""`			// for marshal method, if field value is to precede with an output prefix, such as XYZ= (affects marshal queryParams / csv methods only)
-//									   WARNING: if csv is variable elements count, rather than fixed count ordinal, then csv MUST include outprefix for all fields in order to properly identify target struct field
-//		10) `def:""`				// default value to set into struct field in case unmarshal doesn't set the struct field value
-//		11) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
+//									   NOTE: for multiple or non-string parameters, list arguments as x or self (field value), field:Name (sibling field value), or lit:'value',
+//									         such as 'base.Format(self, lit:'USD', field:Precision)', each argument is converted to the method's declared parameter type
+//		2) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//		3) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+// 		4) `uniqueid:"xyz"`			// if two or more struct field is set with the same uniqueid, then only the first encountered field with the same uniqueid will be used in marshal
+//		5) `skipblank:"false"`		// if true, then any fields that is blank string will be excluded from marshal (this only affects fields that are string)
+//		6) `skipzero:"false"`		// if true, then any fields that are 0, 0.00, time.Zero(), false, nil will be excluded from marshal (this only affects fields that are number, bool, time, pointer)
+//									   nil pointer field is fully excluded, while non-nil pointer field always marshals its underlying value, even if zero (same as json omitempty)
+//		7) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
 //											2006, 06 = year,
 //											01, 1, Jan, January = month,
 //											02, 2, _2 = day (_2 = width two, right justified)
@@ -1217,1021 +1421,6896 @@ func SetStructFieldDefaultValues(inputStructPtr interface{}) bool {
 //											04, 4 = minute
 //											05, 5 = second
 //											PM pm = AM PM
-//		12) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-//		13) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-//		14) `validate:"==x"`		// if field has to match a specific value or the entire method call will fail, match data format as:
-//									   		==xyz (== refers to equal, for numbers and string match, xyz is data to match, case insensitive)
-//												[if == validate against one or more values, use ||]
-//									   		!=xyz (!= refers to not equal)
-//												[if != validate against one or more values, use &&]
-//											>=xyz >>xyz <<xyz <=xyz (greater equal, greater, less than, less equal; xyz must be int or float)
-//											:=Xyz where Xyz is a parameterless function defined at struct level, that performs validation, returns bool or error where true or nil indicates validation success
-//									   note: expected source data type for validate to be effective is string, int, float64; if field is blank and req = false, then validate will be skipped
-func UnmarshalCSVToStruct(inputStructPtr interface{}, csvPayload string, csvDelimiter string, customDelimiterParserFunc func(string) []string) error {
-	if inputStructPtr == nil {
-		return fmt.Errorf("InputStructPtr is Required")
+//		8) `outprefix:""`			// for marshal method, if field value is to precede with an output prefix, such as XYZ= (affects marshal queryParams / csv methods only)
+// 		9) `zeroblank:"false"`		// set true to set blank to data when value is 0, 0.00, or time.IsZero
+//		10) `usestringer:"false"`	// if true and no getter is defined, field value implementing fmt.Stringer is marshaled via its String() method
+//		11) `mapstyle:"bracket"`		// for map field, each map element is rendered as key[subkey]=value (bracket, default) or key.subkey=value (dot), in sorted subkey order
+//		12) `durationformat:""`		// for time.Duration field, blank renders go duration string such as 1h30m0s, or set ns, us, ms, s, m, h to render numeric value in the given unit
+//		13) `timezone:"UTC"`		// for time.Time field, optional time zone name (or use tz tag alias), such as UTC or America/Chicago, time value is converted to this zone before formatting, invalid zone name fails marshal
+//		14) `enum:"StatusEnum"`		// if no getter is defined, integer field value is marshaled as its enum name registered via EnumRegistryRegister
+//		15) `onlyif:"Type==card"`	// field is marshaled only if the condition on sibling field(s) is met, == or != clauses joined by &&, compared on their marshaled value
+//		16) `mask:"last4"`			// redaction mode (last4, first6last4, full, email, hash) applied only by MarshalStructToQueryParamsRedacted, see RedactString
+//
+// Deprecated: values are escaped via url.PathEscape, which leaves &, = and + unescaped, so such values are split or altered when parsed as query string,
+// use MarshalStructToQueryString instead
+func MarshalStructToQueryParams(inputStructPtr interface{}, tagName string, excludeTagName string) (string, error) {
+	return marshalStructToQueryParams(inputStructPtr, tagName, excludeTagName, nil)
+}
+
+// MarshalStructToQueryString marshals a struct pointer's fields to query params string, using the same struct tags as MarshalStructToQueryParams,
+// unlike MarshalStructToQueryParams, values are escaped via url.QueryEscape, so the output round trips through url.ParseQuery
+func MarshalStructToQueryString(inputStructPtr interface{}, tagName string, excludeTagName string) (string, error) {
+	return marshalStructToQueryParams(inputStructPtr, tagName, excludeTagName, &MarshalOptions{QueryEscape: true})
+}
+
+// MarshalStructToQueryParamsRedacted marshals a struct pointer's fields to query params string, same as MarshalStructToQueryString,
+// except values of fields defining `mask:"last4"` struct tag (including each map or slice element) are redacted via RedactString,
+// intended for logging, use MarshalStructToQueryString for wire payloads
+func MarshalStructToQueryParamsRedacted(inputStructPtr interface{}, tagName string, excludeTagName string) (string, error) {
+	return marshalStructToQueryParams(inputStructPtr, tagName, excludeTagName, &MarshalOptions{QueryEscape: true, Redact: true})
+}
+
+// MarshalStructToUrlValues marshals a struct pointer's fields to url.Values, using the same struct tags as MarshalStructToQueryParams,
+// use Values.Encode() for application/x-www-form-urlencoded body (query escaped, in sorted key order),
+// unlike MarshalStructToQueryParams, each element of slice field (other than byte slice) is marshaled as repeated value of the same key
+func MarshalStructToUrlValues(inputStructPtr interface{}, tagName string, excludeTagName string) (url.Values, error) {
+	params, err := marshalStructToQueryParamList(inputStructPtr, tagName, excludeTagName, true, nil, "MarshalStructToUrlValues")
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+
+	for _, p := range params {
+		values.Add(p.key(func(k string) string { return k }), p.Value)
+	}
+
+	return values, nil
+}
+
+// UnmarshalUrlValuesToStruct sets url.Values (such as parsed form body or query string) into struct pointer fields,
+// the key for each field is based on values given in tagName, any tagName value with - will be ignored,
+// any excludeTagName defined with value of - will also cause the field to be ignored,
+// struct fields are cleared and then set with def default values before values are applied (same as UnmarshalJsonToStruct),
+// repeated keys are set into slice field (other than byte slice), otherwise the first value of the key is used,
+// map field (string keyed) is set from keys in the form of name[subkey], or name.subkey if mapstyle is dot
+//
+// Predefined Struct Tags Usable:
+//		1) `setter:"ParseByKey`		// same as UnmarshalJsonToStruct, the value is passed to setter method
+//		2) `def:""`					// default value to set into struct field in case values doesn't set the struct field value
+//		3) `timeformat:"20060102"`	// for time.Time field, optional date time format used to parse value
+//		4) `timezone:"UTC"`			// for time.Time field, optional time zone name (or use tz tag alias), time value without zone info is parsed as time in this zone
+//		5) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition
+//		6) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition
+//		7) `mapstyle:"bracket"`		// for map field, bracket (default) matches name[subkey] keys, dot matches name.subkey keys
+//		8) `enum:"StatusEnum"`		// if no setter is defined, value is converted from enum name registered via EnumRegistryRegister into integer field
+func UnmarshalUrlValuesToStruct(inputStructPtr interface{}, v url.Values, tagName string, excludeTagName string) error {
+	return unmarshalUrlValuesToStruct(inputStructPtr, v, tagName, excludeTagName, "Url Value", "UnmarshalUrlValuesToStruct")
+}
+
+// unmarshalUrlValuesToStruct sets v into struct pointer fields, see UnmarshalUrlValuesToStruct,
+// shared by UnmarshalUrlValuesToStruct and UnmarshalXmlToStruct, valueName and funcName are used in error messages
+func unmarshalUrlValuesToStruct(inputStructPtr interface{}, v url.Values, tagName string, excludeTagName string, valueName string, funcName string) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("%s Requires Input Struct Variable Pointer", funcName)
+	}
+
+	if LenTrim(tagName) == 0 {
+		return fmt.Errorf("%s Requires TagName (Tag Name defines values key name)", funcName)
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("%s Expects inputStructPtr To Be a Pointer", funcName)
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("%s Requires Struct Object", funcName)
+	}
+
+	StructClearFields(inputStructPtr)
+	fields := getStructFieldValues(s, tagName, true)
+	SetStructFieldDefaultValues(inputStructPtr)
+
+	for _, sf := range fields {
+		field := sf.Field
+		o := sf.Value
+
+		if !o.IsValid() || !o.CanSet() {
+			continue
+		}
+
+		name, _ := getStructFieldNameTag(field, tagName)
+
+		if name == "-" {
+			continue
+		}
+
+		if LenTrim(excludeTagName) > 0 {
+			if Trim(field.Tag.Get(excludeTagName)) == "-" {
+				continue
+			}
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		timeFormat := getStructFieldTimeFormat(field, field.Tag.Get("timeformat"))
+		timeZone, err := getStructFieldTimeZone(field)
+
+		if err != nil {
+			return fmt.Errorf("%s Failed: %s", funcName, err)
+		}
+
+		if o.Kind() == reflect.Map {
+			if err := setUrlValuesToMapField(o, v, name, strings.ToLower(Trim(field.Tag.Get("mapstyle"))) == "dot", timeFormat, timeZone); err != nil {
+				return fmt.Errorf("Unmarshal %s %s Failed: %s", valueName, name, err)
+			}
+
+			continue
+		}
+
+		vals := v[name]
+
+		if len(vals) == 0 {
+			continue
+		}
+
+		tagSetter := Trim(field.Tag.Get("setter"))
+
+		if o.Kind() == reflect.Slice && o.Type().Elem().Kind() != reflect.Uint8 && len(tagSetter) == 0 {
+			// repeated keys are set into slice field
+			sv := reflect.MakeSlice(o.Type(), len(vals), len(vals))
+
+			for i, p := range vals {
+				if err := ReflectStringToField(sv.Index(i), p, timeFormat, timeZone); err != nil {
+					return fmt.Errorf("Unmarshal %s %s Failed: %s", valueName, name, err)
+				}
+			}
+
+			o.Set(sv)
+			continue
+		}
+
+		value := vals[0]
+
+		if len(tagSetter) > 0 {
+			if sv, handled, err := invokeStructFieldSetter(s, o, tagSetter, value, timeFormat); err != nil {
+				return fmt.Errorf("Unmarshal %s %s Failed: %s", valueName, name, err)
+			} else if handled {
+				continue
+			} else {
+				value = sv
+			}
+		} else if ev, err := unmarshalStructFieldEnum(field, value); err != nil {
+			return fmt.Errorf("Unmarshal %s %s Failed: %s", valueName, name, err)
+		} else {
+			value = ev
+		}
+
+		if boolTrue := Trim(field.Tag.Get("booltrue")); len(boolTrue) > 0 && value == boolTrue {
+			value = "true"
+		} else if boolFalse := Trim(field.Tag.Get("boolfalse")); len(boolFalse) > 0 && value == boolFalse {
+			value = "false"
+		}
+
+		if err := ReflectStringToField(o, value, timeFormat, timeZone); err != nil {
+			return fmt.Errorf("Unmarshal %s %s Failed: %s", valueName, name, err)
+		}
+	}
+
+	if isValidateRequiredGroupsOnUnmarshal() {
+		return ValidateStructRequiredGroups(inputStructPtr)
+	}
+
+	return nil
+}
+
+// MarshalStructToXml marshals a struct pointer's fields to xml string, as flat child elements of rootElement in struct declaration order,
+// using the same struct tags and value evaluation as MarshalStructToQueryParams (such as getter, def, timeformat, skipblank, skipzero),
+// output element names are based on values given in tagName, if rootElement is blank, the struct type name is used as root element,
+// each element of slice field (other than byte slice) is marshaled as repeated element of the same name, map fields are omitted,
+// element text and attribute values are escaped via xml.EscapeText
+//
+// Additional Struct Tags Usable:
+//		1) `attr:"true"`			// if true, field is marshaled as attribute of root element rather than child element,
+//									   for slice field, only its first element is marshaled as attribute
+func MarshalStructToXml(inputStructPtr interface{}, tagName string, excludeTagName string, rootElement string) (string, error) {
+	params, err := marshalStructToQueryParamList(inputStructPtr, tagName, excludeTagName, true, nil, "MarshalStructToXml")
+
+	if err != nil {
+		return "", err
+	}
+
+	s := reflect.ValueOf(inputStructPtr).Elem()
+
+	if rootElement = Trim(rootElement); len(rootElement) == 0 {
+		rootElement = s.Type().Name()
+	}
+
+	var attrs, elems bytes.Buffer
+	attrDone := make(map[string]bool)
+
+	for _, p := range params {
+		if len(p.SubKey) > 0 {
+			continue
+		}
+
+		isAttr := false
+
+		if sf, ok := findStructFieldByKey(s, p.Name, tagName, false); ok {
+			var err error
+
+			if isAttr, err = getStructFieldBoolTagValue(sf.Field, "attr"); err != nil {
+				return "", fmt.Errorf("MarshalStructToXml Failed: %s", err)
+			}
+		}
+
+		if isAttr {
+			if attrDone[p.Name] {
+				continue
+			}
+
+			attrDone[p.Name] = true
+			attrs.WriteString(" " + p.Name + `="`)
+
+			if err := xml.EscapeText(&attrs, []byte(p.Value)); err != nil {
+				return "", fmt.Errorf("MarshalStructToXml Failed: %s", err)
+			}
+
+			attrs.WriteString(`"`)
+		} else {
+			elems.WriteString("<" + p.Name + ">")
+
+			if err := xml.EscapeText(&elems, []byte(p.Value)); err != nil {
+				return "", fmt.Errorf("MarshalStructToXml Failed: %s", err)
+			}
+
+			elems.WriteString("</" + p.Name + ">")
+		}
+	}
+
+	return "<" + rootElement + attrs.String() + ">" + elems.String() + "</" + rootElement + ">", nil
+}
+
+// UnmarshalXmlToStruct parses xmlPayload into struct pointer fields, where root element attributes and its flat child elements are matched by name to fields,
+// the name for each field is based on values given in tagName, using the same struct tags and value handling as UnmarshalUrlValuesToStruct,
+// repeated child elements are set into slice field (other than byte slice), otherwise the first element of the name is used,
+// root element name is not checked, child elements nested below the first level are ignored
+func UnmarshalXmlToStruct(inputStructPtr interface{}, xmlPayload string, tagName string, excludeTagName string) error {
+	if LenTrim(xmlPayload) == 0 {
+		return fmt.Errorf("UnmarshalXmlToStruct Requires Xml Payload")
+	}
+
+	v, err := parseFlatXmlToUrlValues(xmlPayload)
+
+	if err != nil {
+		return fmt.Errorf("UnmarshalXmlToStruct Failed: %s", err)
+	}
+
+	return unmarshalUrlValuesToStruct(inputStructPtr, v, tagName, excludeTagName, "Xml Element", "UnmarshalXmlToStruct")
+}
+
+// parseFlatXmlToUrlValues parses xmlPayload into url.Values, keyed by root element attribute and first level child element names,
+// child element value is its text content, with elements nested below the first level skipped
+func parseFlatXmlToUrlValues(xmlPayload string) (url.Values, error) {
+	d := xml.NewDecoder(strings.NewReader(xmlPayload))
+	v := url.Values{}
+	rootFound := false
+
+	for {
+		tok, err := d.Token()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		start, ok := tok.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		if !rootFound {
+			rootFound = true
+
+			for _, a := range start.Attr {
+				v.Add(a.Name.Local, a.Value)
+			}
+
+			continue
+		}
+
+		// first level child element, text content is collected until its end element
+		var text strings.Builder
+
+		for done := false; !done; {
+			if tok, err = d.Token(); err != nil {
+				return nil, err
+			}
+
+			switch t := tok.(type) {
+			case xml.CharData:
+				text.Write(t)
+			case xml.StartElement:
+				if err = d.Skip(); err != nil {
+					return nil, err
+				}
+			case xml.EndElement:
+				done = true
+			}
+		}
+
+		v.Add(start.Name.Local, text.String())
+	}
+
+	if !rootFound {
+		return nil, fmt.Errorf("Root Element Not Found")
+	}
+
+	return v, nil
+}
+
+// setUrlValuesToMapField sets string keyed map field o from keys of v in the form of name[subkey] (or name.subkey if dotStyle),
+// each element value is converted via ReflectStringToField, map is left as is if no matching key is found
+func setUrlValuesToMapField(o reflect.Value, v url.Values, name string, dotStyle bool, timeFormat string, timeZone *time.Location) error {
+	if o.Type().Key().Kind() != reflect.String {
+		return nil
+	}
+
+	var m reflect.Value
+
+	for k, vals := range v {
+		if len(vals) == 0 {
+			continue
+		}
+
+		subKey := ""
+
+		if dotStyle {
+			if !strings.HasPrefix(k, name+".") {
+				continue
+			}
+
+			subKey = k[len(name)+1:]
+		} else {
+			if !strings.HasPrefix(k, name+"[") || !strings.HasSuffix(k, "]") {
+				continue
+			}
+
+			subKey = k[len(name)+1 : len(k)-1]
+		}
+
+		if !m.IsValid() {
+			m = reflect.MakeMap(o.Type())
+		}
+
+		ev := reflect.New(o.Type().Elem()).Elem()
+
+		if err := ReflectStringToField(ev, vals[0], timeFormat, timeZone); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(reflect.ValueOf(subKey).Convert(o.Type().Key()), ev)
+	}
+
+	if m.IsValid() {
+		o.Set(m)
+	}
+
+	return nil
+}
+
+// marshalStructToQueryParams marshals struct pointer's fields to query params string, see MarshalStructToQueryParams,
+// opts if not nil applies marshal defaults, values are escaped via url.QueryEscape if opts.QueryEscape is true, otherwise via url.PathEscape
+func marshalStructToQueryParams(inputStructPtr interface{}, tagName string, excludeTagName string, opts *MarshalOptions) (string, error) {
+	params, err := marshalStructToQueryParamList(inputStructPtr, tagName, excludeTagName, false, opts, "MarshalStructToQueryParams")
+
+	if err != nil {
+		return "", err
+	}
+
+	escape := url.PathEscape
+
+	if opts != nil && opts.QueryEscape {
+		escape = url.QueryEscape
+	}
+
+	output := ""
+
+	for _, p := range params {
+		if len(output) > 0 {
+			output += "&"
+		}
+
+		output += p.key(escape) + "=" + escape(p.Value)
+	}
+
+	return output, nil
+}
+
+// queryParam is a single query param (or form value) marshaled from struct field, see marshalStructToQueryParamList,
+// for map field element, Name is the field's query param name, and SubKey is the map element key
+type queryParam struct {
+	Name     string
+	SubKey   string
+	DotStyle bool
+	Value    string
+}
+
+// key returns the query param key, being Name, or Name[SubKey] (Name.SubKey if DotStyle) for map field element, SubKey is escaped via escape
+func (p queryParam) key(escape func(string) string) string {
+	if len(p.SubKey) == 0 {
+		return p.Name
+	}
+
+	if p.DotStyle {
+		return p.Name + "." + escape(p.SubKey)
+	}
+
+	return p.Name + "[" + escape(p.SubKey) + "]"
+}
+
+// marshalStructToQueryParamList marshals struct pointer's fields to query params in field order, shared by MarshalStructToQueryParams and MarshalStructToUrlValues,
+// if expandSlices is true, each element of slice field (other than byte slice) is marshaled as repeated param of the same name,
+// opts if not nil applies marshal defaults, funcName is the public function name used in error messages,
+// error is returned if no query param is marshaled, unless blank output error is turned off (see SetBlankOutputReturnsError)
+func marshalStructToQueryParamList(inputStructPtr interface{}, tagName string, excludeTagName string, expandSlices bool, opts *MarshalOptions, funcName string) ([]queryParam, error) {
+	if inputStructPtr == nil {
+		return nil, fmt.Errorf("%s Requires Input Struct Variable Pointer", funcName)
+	}
+
+	if LenTrim(tagName) == 0 {
+		return nil, fmt.Errorf("%s Requires TagName (Tag Name defines query parameter name)", funcName)
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("%s Expects inputStructPtr To Be a Pointer", funcName)
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s Requires Struct Object", funcName)
+	}
+
+	fields := getStructFieldValues(s, tagName, false)
+
+	if err := validateStructTimeZones(fields); err != nil {
+		return nil, fmt.Errorf("%s Failed: %s", funcName, err)
+	}
+
+	var params []queryParam
+	unique := make(uniqueFieldClaims)
+	maskedFields := make(map[string]reflect.StructField)
+
+	for _, sf := range fields {
+		field := sf.Field
+
+		if o := sf.Value; o.IsValid() {
+			tag, omitEmpty := getStructFieldNameTag(field, tagName)
+
+			if LenTrim(tag) == 0 {
+				tag = field.Name
+			}
+
+			if tag != "-" {
+				if LenTrim(excludeTagName) > 0 {
+					if Trim(field.Tag.Get(excludeTagName)) == "-" {
+						continue
+					}
+				}
+
+				if ok, err := isStructFieldOnlyIfMet(s, field); err != nil {
+					return nil, fmt.Errorf("%s Struct Field %s Failed: %s", funcName, field.Name, err)
+				} else if !ok {
+					continue
+				}
+
+				if !unique.claim(field) {
+					continue
+				}
+
+				if LenTrim(field.Tag.Get("mask")) > 0 {
+					maskedFields[tag] = field
+				}
+
+				tags := sf.Tags
+
+				if tags.Err != nil {
+					return nil, fmt.Errorf("%s Struct Field %s Failed: %s", funcName, field.Name, tags.Err)
+				}
+
+				boolTrue, boolFalse, timeFormat, outPrefix := tags.BoolTrue, tags.BoolFalse, tags.TimeFormat, tags.OutPrefix
+				skipBlank, skipZero, zeroblank := tags.SkipBlank, tags.SkipZero, tags.ZeroBlank
+
+				opts.apply(field, &boolTrue, &boolFalse, &timeFormat, &skipBlank, &skipZero)
+
+				if omitEmpty {
+					// encoding/json omitempty option omits blank and zero value
+					skipBlank = true
+					skipZero = true
+				}
+
+				oldVal := o
+				useStringer := tags.UseStringer
+
+				if tagGetter := tags.Getter; len(tagGetter) > 0 {
+					useStringer = false
+
+					var err error
+
+					if o, err = invokeStructFieldGetter(s, o, tagGetter, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroblank); err != nil {
+						return nil, fmt.Errorf("%s Struct Field %s Failed: %s", funcName, field.Name, err)
+					}
+				} else if ev, err := marshalStructFieldEnum(field, o, skipZero); err != nil {
+					return nil, fmt.Errorf("%s Struct Field %s Failed: %s", funcName, field.Name, err)
+				} else {
+					o = ev
+				}
+
+				if o.Kind() == reflect.Map {
+					mapKeys, mapValues, err := reflectMapToStringMap(o, timeFormat)
+
+					if err != nil || len(mapKeys) == 0 || (skipZero && o.IsNil()) {
+						unique.release(field)
+						continue
+					}
+
+					isDotStyle := strings.ToLower(Trim(field.Tag.Get("mapstyle"))) == "dot"
+
+					for _, k := range mapKeys {
+						params = append(params, queryParam{Name: tag, SubKey: k, DotStyle: isDotStyle, Value: mapValues[k]})
+					}
+
+					continue
+				}
+
+				if expandSlices && o.Kind() == reflect.Slice && o.Type().Elem().Kind() != reflect.Uint8 {
+					// each slice element is marshaled as repeated param
+					loc, _ := getStructFieldTimeZone(field)
+					count := len(params)
+
+					for i := 0; i < o.Len(); i++ {
+						if buf, _, err := ReflectValueToString(reflectTimeInLocation(o.Index(i), loc), boolTrue, boolFalse, false, false, timeFormat, zeroblank); err == nil {
+							params = append(params, queryParam{Name: tag, Value: buf})
+						}
+					}
+
+					if len(params) == count {
+						unique.release(field)
+					}
+
+					continue
+				}
+
+				if o.Kind() == reflect.Ptr {
+					if o.IsNil() {
+						if skipZero {
+							// nil pointer is fully omitted when skipzero
+							unique.release(field)
+							continue
+						}
+					} else if isQueryParamsPointerDeref(o) {
+						// non-nil pointer is set explicitly, its underlying value is marshaled even if zero (same as json omitempty)
+						o = o.Elem()
+						skipZero = false
+					}
+				}
+
+				loc, _ := getStructFieldTimeZone(field)
+
+				if buf, skip, err := ReflectValueToString(reflectTimeInLocation(o, loc), boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroblank, useStringer); err != nil || skip {
+					unique.release(field)
+					continue
+				} else {
+					defVal := getStructFieldDefaultValue(field)
+
+					if oldVal.Kind() == reflect.Int && oldVal.Int() == 0 && strings.ToLower(buf) == "unknown" {
+						// unknown enum value will be serialized as blank
+						buf = ""
+
+						if len(defVal) > 0 {
+							buf = defVal
+						} else {
+							if unique.release(field) {
+								continue
+							}
+						}
+					}
+
+					if boolFalse == " " && len(outPrefix) > 0 && buf == "false" {
+						buf = ""
+					} else {
+						if len(buf) == 0 && len(defVal) > 0  {
+							buf = defVal
+						}
+
+						if skipBlank && LenTrim(buf) == 0 {
+							buf = ""
+						} else if skipZero && buf == "0" {
+							buf = ""
+						} else {
+							buf = outPrefix + buf
+						}
+					}
+
+					params = append(params, queryParam{Name: tag, Value: buf})
+				}
+			}
+		}
+	}
+
+	if len(params) == 0 && opts.blankOutputReturnsError() {
+		return nil, fmt.Errorf("%s Yielded Blank Output", funcName)
+	}
+
+	if opts != nil && opts.Redact {
+		// masked field values (including each map or slice element) are redacted
+		for i := range params {
+			if field, ok := maskedFields[params[i].Name]; ok {
+				params[i].Value = opts.redact(field, params[i].Value)
+			}
+		}
+	}
+
+	return params, nil
+}
+
+// isQueryParamsPointerDeref returns true if non-nil pointer o is to be dereferenced for query params marshal,
+// being pointer to primitive value or time.Time, pointer to other struct or pointer is marshaled as is
+func isQueryParamsPointerDeref(o reflect.Value) bool {
+	e := o.Elem()
+
+	switch e.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Array, reflect.Chan, reflect.Func:
+		return false
+	case reflect.Struct:
+		return e.Type() == reflect.TypeOf(time.Time{})
+	default:
+		return true
+	}
+}
+
+// MarshalStruct marshals a struct pointer's fields to the given format (FormatJson, FormatCSV, FormatQueryParams),
+// using the same struct tags as MarshalStructToJson, MarshalStructToCSV and MarshalStructToQueryParams respectively,
+// opts if nil uses default options, where TagName and ExcludeTagName must be set for FormatJson and FormatQueryParams as applicable
+func MarshalStruct(inputStructPtr interface{}, format Format, opts *MarshalOptions) (string, error) {
+	if opts == nil {
+		opts = &MarshalOptions{}
+	}
+
+	switch format {
+	case FormatJson:
+		var sb strings.Builder
+
+		if err := marshalStructToJsonTo(&sb, inputStructPtr, opts.TagName, opts.ExcludeTagName, "", opts, "MarshalStruct"); err != nil {
+			return "", err
+		}
+
+		return sb.String(), nil
+
+	case FormatCSV:
+		delimiter := opts.Delimiter
+
+		if len(delimiter) == 0 {
+			delimiter = ","
+		}
+
+		return marshalStructToCSV(inputStructPtr, delimiter, false, nil, opts)
+
+	case FormatQueryParams:
+		return marshalStructToQueryParams(inputStructPtr, opts.TagName, opts.ExcludeTagName, opts)
+
+	default:
+		return "", fmt.Errorf("MarshalStruct Format %d Not Supported", format)
+	}
+}
+
+// UnmarshalStruct parses payload of the given format (FormatJson, FormatCSV) into struct pointer's fields,
+// using the same struct tags as UnmarshalJsonToStruct and UnmarshalCSVToStruct respectively, FormatQueryParams is not supported,
+// opts if nil uses default options, where TagName and ExcludeTagName must be set for FormatJson as applicable
+func UnmarshalStruct(inputStructPtr interface{}, payload string, format Format, opts *UnmarshalOptions) error {
+	if opts == nil {
+		opts = &UnmarshalOptions{}
+	}
+
+	switch format {
+	case FormatJson:
+		return unmarshalJsonToStruct(inputStructPtr, payload, opts.TagName, opts.ExcludeTagName, opts)
+
+	case FormatCSV:
+		delimiter := opts.Delimiter
+
+		if len(delimiter) == 0 {
+			delimiter = ","
+		}
+
+		return unmarshalCSVToStruct(inputStructPtr, payload, delimiter, opts.CustomDelimiterParser, false, opts)
+
+	default:
+		return fmt.Errorf("UnmarshalStruct Format %d Not Supported", format)
+	}
+}
+
+// MarshalStructToJson marshals a struct pointer's fields to json string,
+// output json names are based on values given in tagName,
+// tagName value is parsed in encoding/json style, such as `json:"name,omitempty"`, where omitempty is same as skipblank and skipzero both true,
+// (string option is accepted and ignored, as values are already marshaled as json strings)
+// to exclude certain struct fields from being marshaled, include - as value in struct tag defined by tagName,
+// if there is a need to name the value of tagName, but still need to exclude from output, use the excludeTagName with -, such as `x:"-"`,
+// fields of embedded (anonymous) struct are promoted and marshaled as if they belong to the outer struct, outer fields win on name collision,
+// map fields are rendered as nested json objects in sorted key order, string valued map elements are stringified via ReflectValueToString,
+// (nil map is rendered as null, and under skipzero, nil or empty map is omitted),
+// slice fields (other than byte slice) are rendered as json arrays, each element stringified via ReflectValueToString same as scalar field values,
+// (nil or empty slice is rendered as [], and under skipzero, omitted),
+// nested struct fields (other than time.Time and sql.Null*) are rendered recursively as nested json objects, readable by UnmarshalJsonToStruct,
+// (nil nested struct pointer is rendered as null, and under skipzero, zero value or nil nested struct is omitted)
+//
+// special struct tags:
+//		1) `getter:"Key"`			// if field type is custom struct or enum,
+//									   specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
+//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
+//									   NOTE: if the method is to receive a parameter value, always in string data type, add '(x)' after the method name, such as 'XYZ(x)' or 'base.XYZ(x)'
+//									   NOTE: for multiple or non-string parameters, list arguments as x or self (field value), field:Name (sibling field value), or lit:'value',
+//									         such as 'base.Format(self, lit:'USD', field:Precision)', each argument is converted to the method's declared parameter type
+//		2) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//		3) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//									   booltrue.json and boolfalse.json tags, if defined, are preferred over booltrue and boolfalse for json, allowing different literals per output format
+// 		4) `uniqueid:"xyz"`			// if two or more struct field is set with the same uniqueid, then only the first encountered field with the same uniqueid will be used in marshal
+//		5) `skipblank:"false"`		// if true, then any fields that is blank string will be excluded from marshal (this only affects fields that are string)
+//		6) `skipzero:"false"`		// if true, then any fields that are 0, 0.00, time.Zero(), false, nil will be excluded from marshal (this only affects fields that are number, bool, time, pointer)
+//		7) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
+//											2006, 06 = year,
+//											01, 1, Jan, January = month,
+//											02, 2, _2 = day (_2 = width two, right justified)
+//											03, 3, 15 = hour (15 = 24 hour format)
+//											04, 4 = minute
+//											05, 5 = second
+//											PM pm = AM PM
+// 		8) `zeroblank:"false"`		// set true to set blank to data when value is 0, 0.00, or time.IsZero
+//		9) `exclusive:"xyz"`		// fields sharing the same exclusive group are mutually exclusive, if more than one field in the group holds non-zero value,
+//									   marshal fails with error naming the group and its conflicting fields (unlike uniqueid, which silently uses the first field)
+//		10) `usestringer:"false"`	// if true and no getter is defined, field value implementing fmt.Stringer is marshaled via its String() method
+//		11) `durationformat:""`		// for time.Duration field, blank renders go duration string such as 1h30m0s, or set ns, us, ms, s, m, h to render numeric value in the given unit
+//		12) `timezone:"UTC"`		// for time.Time field, optional time zone name (or use tz tag alias), such as UTC or America/Chicago, time value is converted to this zone before formatting, invalid zone name fails marshal
+//		13) `jsonnull:"false"`		// if true, nil pointer (or interface) field, and invalid sql.Null* field (Valid = false), is marshaled as unquoted json null,
+//									   rather than being omitted or blank, precedence: if skipzero is also true, skipzero wins and the field is omitted
+//		14) `enum:"StatusEnum"`		// if no getter is defined, integer field value is marshaled as its enum name registered via EnumRegistryRegister, unregistered value fails marshal
+//		15) `onlyif:"Type==card"`	// field is marshaled only if the condition on sibling field(s) is met, such as 'PaymentType==card && Status!=void',
+//									   each clause compares sibling struct field (by field name) via == or !=, on its marshaled value (after its getter or enum tag)
+//		16) `jsonraw:"false"`		// if true, field value holding pre-serialized json (such as json blob column) is embedded verbatim rather than quoted and escaped,
+//									   value failing json.Valid is marshaled as quoted string instead, to avoid producing broken output
+//		17) `mask:"last4"`			// redaction mode (last4, first6last4, full, email, hash) applied only by MarshalStructToJsonRedacted (or MarshalOptions.Redact), see RedactString
+func MarshalStructToJson(inputStructPtr interface{}, tagName string, excludeTagName string) (string, error) {
+	var sb strings.Builder
+
+	if err := marshalStructToJsonTo(&sb, inputStructPtr, tagName, excludeTagName, "", nil, "MarshalStructToJson"); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// MarshalStructToJsonWriter marshals a struct pointer's fields to json, same as MarshalStructToJson,
+// except the json output is written to w via buffered writer (flushed upon completion) rather than returned as string,
+// such as writing directly to http response, nothing is written to w if marshal fails prior to output
+func MarshalStructToJsonWriter(w io.Writer, inputStructPtr interface{}, tagName string, excludeTagName string) error {
+	if w == nil {
+		return fmt.Errorf("MarshalStructToJsonWriter Requires Writer")
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if err := marshalStructToJsonTo(bw, inputStructPtr, tagName, excludeTagName, "", nil, "MarshalStructToJsonWriter"); err != nil {
+		return err
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("MarshalStructToJsonWriter Write Failed: %s", err)
+	}
+
+	return nil
+}
+
+// MarshalStructToJsonIndent marshals a struct pointer's fields to json string, same as MarshalStructToJson,
+// except the json output is pretty printed, with each element on its own line indented by indent per nesting level (such as two spaces or tab),
+// field ordering is the same as MarshalStructToJson (struct declaration order), if indent is blank, output is same as MarshalStructToJson
+func MarshalStructToJsonIndent(inputStructPtr interface{}, tagName string, excludeTagName string, indent string) (string, error) {
+	var sb strings.Builder
+
+	if err := marshalStructToJsonTo(&sb, inputStructPtr, tagName, excludeTagName, "", &MarshalOptions{Indent: indent}, "MarshalStructToJsonIndent"); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// MarshalStructToJsonFunc marshals a struct pointer's fields to json string, same as MarshalStructToJson,
+// except transform is invoked for each field with its struct field name, json name (per tagName), and stringified value,
+// the value returned by transform is emitted instead, allowing caller specific masking or formatting,
+// transform is not invoked for values emitted as raw json (map, json null), if transform is nil, same as MarshalStructToJson
+func MarshalStructToJsonFunc(inputStructPtr interface{}, tagName string, excludeTagName string, transform func(fieldName string, tagName string, value string) string) (string, error) {
+	var sb strings.Builder
+
+	if err := marshalStructToJsonTo(&sb, inputStructPtr, tagName, excludeTagName, "", &MarshalOptions{Transform: transform}, "MarshalStructToJsonFunc"); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// MarshalStructToJsonRedacted marshals a struct pointer's fields to json string, same as MarshalStructToJson,
+// except values of fields defining `mask:"last4"` struct tag are redacted via RedactString (last4, first6last4, full, email, hash),
+// map, slice and raw json values of masked fields are fully masked, intended for logging, use MarshalStructToJson for wire payloads
+func MarshalStructToJsonRedacted(inputStructPtr interface{}, tagName string, excludeTagName string) (string, error) {
+	var sb strings.Builder
+
+	if err := marshalStructToJsonTo(&sb, inputStructPtr, tagName, excludeTagName, "", &MarshalOptions{Redact: true}, "MarshalStructToJsonRedacted"); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// MarshalStructToTOML marshals a struct pointer's fields to toml string, as flat top-level key = value lines in struct declaration order,
+// using the same struct tags and value evaluation as MarshalStructToJson (such as getter, skipblank, skipzero, uniqueid),
+// string values are written as toml basic (double quoted) strings, while number and bool field values are written bare,
+// time.Time field without timeformat tag is written as bare RFC3339 date time, time value not in RFC3339 format is written quoted,
+// key not consisting of A-Za-z0-9_- characters only is quoted, map, slice and json null values are omitted as toml output is kept flat,
+// opts (optional) overrides marshal defaults for this call, such as BlankOutputReturnsError, where TagName and ExcludeTagName are not used
+func MarshalStructToTOML(inputStructPtr interface{}, tagName string, excludeTagName string, opts ...*MarshalOptions) (string, error) {
+	if inputStructPtr == nil {
+		return "", fmt.Errorf("MarshalStructToTOML Requires Input Struct Variable Pointer")
+	}
+
+	if LenTrim(tagName) == 0 {
+		return "", fmt.Errorf("MarshalStructToTOML Requires TagName (Tag Name defines TOML key)")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return "", fmt.Errorf("MarshalStructToTOML Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return "", fmt.Errorf("MarshalStructToTOML Requires Struct Object")
+	}
+
+	fields := getStructFieldValues(s, tagName, false)
+
+	if err := validateStructExclusiveGroups(fields); err != nil {
+		return "", fmt.Errorf("MarshalStructToTOML Failed: %s", err)
+	}
+
+	if err := validateStructTimeZones(fields); err != nil {
+		return "", fmt.Errorf("MarshalStructToTOML Failed: %s", err)
+	}
+
+	tomlOpts := MarshalOptions{}
+
+	if o := getMarshalOptions(opts); o != nil {
+		tomlOpts = *o
+	}
+
+	if len(tomlOpts.TimeFormatDefault) == 0 {
+		tomlOpts.TimeFormatDefault = time.RFC3339
+	}
+
+	keys, values, raw, err := marshalStructToJsonElements(s, tagName, excludeTagName, "", &tomlOpts)
+
+	if err != nil {
+		return "", fmt.Errorf("MarshalStructToTOML Failed: %s", err)
+	}
+
+	var sb strings.Builder
+
+	for _, k := range keys {
+		if raw[k] {
+			continue
+		}
+
+		key := k
+
+		if !tomlBareKeyRegex.MatchString(k) {
+			key = tomlQuoteString(k)
+		}
+
+		v := values[k]
+
+		if sf, ok := findStructFieldByKey(s, k, tagName, false); ok && isTOMLBareValue(sf.Value, v) {
+			sb.WriteString(key + " = " + v + "\n")
+		} else {
+			sb.WriteString(key + " = " + tomlQuoteString(v) + "\n")
+		}
+	}
+
+	if sb.Len() == 0 && tomlOpts.blankOutputReturnsError() {
+		return "", fmt.Errorf("MarshalStructToTOML Yielded Blank Output")
+	}
+
+	return sb.String(), nil
+}
+
+// toml bare key, integer and float literal patterns
+var tomlBareKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+var tomlIntRegex = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)$`)
+var tomlFloatRegex = regexp.MustCompile(`^[+-]?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// isTOMLBareValue returns true if marshaled value v of field o is written bare (unquoted) in toml,
+// being number, bool or RFC3339 time field whose value v is a valid toml literal of the same type
+func isTOMLBareValue(o reflect.Value, v string) bool {
+	for o.Kind() == reflect.Ptr && !o.IsNil() {
+		o = o.Elem()
+	}
+
+	switch o.Kind() {
+	case reflect.Bool:
+		return v == "true" || v == "false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return o.Type() != reflect.TypeOf(time.Duration(0)) && tomlIntRegex.MatchString(v)
+	case reflect.Float32, reflect.Float64:
+		return tomlFloatRegex.MatchString(v)
+	case reflect.Struct:
+		if o.Type() == reflect.TypeOf(time.Time{}) || o.Type() == reflect.TypeOf(sql.NullTime{}) {
+			_, err := time.Parse(time.RFC3339, v)
+			return err == nil
+		}
+	}
+
+	return false
+}
+
+// tomlQuoteString returns v as toml basic string, double quoted with escape sequences
+func tomlQuoteString(v string) string {
+	return `"` + strings.Replace(jsonEscapeString(v), "\x7f", `\u007f`, -1) + `"`
+}
+
+// MarshalStructToJsonEncrypted marshals a struct pointer's fields to json string, same as MarshalStructToJson,
+// except values of fields tagged `encrypt:"true"` are encrypted via AES-GCM using key (16, 24 or 32 bytes for AES-128, AES-192 or AES-256),
+// each encrypted value is random nonce prepended to cipher text, encoded as base64 string, while other fields are emitted in clear,
+// blank and json null values are not encrypted, encrypt tag on field rendered as json object or array (such as map or slice) fails marshal,
+// use UnmarshalJsonToStructEncrypted to decrypt
+func MarshalStructToJsonEncrypted(inputStructPtr interface{}, tagName string, excludeTagName string, key []byte) (string, error) {
+	gcm, err := newStructFieldGCM(key)
+
+	if err != nil {
+		return "", fmt.Errorf("MarshalStructToJsonEncrypted Failed: %s", err)
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if inputStructPtr == nil || s.Kind() != reflect.Ptr || s.Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("MarshalStructToJsonEncrypted Requires Input Struct Variable Pointer")
+	}
+
+	var sb strings.Builder
+
+	if err := marshalStructToJsonTo(&sb, inputStructPtr, tagName, excludeTagName, "", &MarshalOptions{encryptGCM: gcm}, "MarshalStructToJsonEncrypted"); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// newStructFieldGCM returns AES-GCM cipher for key, used by encrypt tag
+func newStructFieldGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptStructFieldValue encrypts v via gcm with random nonce, returning base64 of nonce prepended to cipher text
+func encryptStructFieldValue(gcm cipher.AEAD, v string) (string, error) {
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(v), nil)), nil
+}
+
+// decryptStructFieldValue decrypts base64 value v produced by encryptStructFieldValue via gcm
+func decryptStructFieldValue(gcm cipher.AEAD, v string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(v)
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("Cipher Text Smaller Than Nonce Size")
+	}
+
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// marshalStructToJsonTo marshals a struct pointer's fields (belonging to group if not blank) as json object written to w,
+// opts if not nil applies marshal defaults and value transform, see MarshalOptions,
+// funcName is the public function name used in error messages, nothing is written to w if marshal fails prior to output,
+// NOTE: all fields are evaluated first (so that uniqueid, redaction and encryption apply across fields, and a failing field writes nothing),
+//       then the evaluated elements are written to w piece by piece, without assembling the json object as an intermediate string
+func marshalStructToJsonTo(w io.StringWriter, inputStructPtr interface{}, tagName string, excludeTagName string, group string, opts *MarshalOptions, funcName string) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("%s Requires Input Struct Variable Pointer", funcName)
+	}
+
+	if LenTrim(tagName) == 0 {
+		return fmt.Errorf("%s Requires TagName (Tag Name defines Json name)", funcName)
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("%s Expects inputStructPtr To Be a Pointer", funcName)
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("%s Requires Struct Object", funcName)
+	}
+
+	fields := getStructFieldValues(s, tagName, false)
+
+	if err := validateStructExclusiveGroups(fields); err != nil {
+		return fmt.Errorf("%s Failed: %s", funcName, err)
+	}
+
+	if err := validateStructTimeZones(fields); err != nil {
+		return fmt.Errorf("%s Failed: %s", funcName, err)
+	}
+
+	keys, values, raw, err := marshalStructToJsonElements(s, tagName, excludeTagName, group, opts)
+
+	if err != nil {
+		return fmt.Errorf("%s Failed: %s", funcName, err)
+	}
+
+	if len(keys) == 0 {
+		if opts.blankOutputReturnsError() {
+			return fmt.Errorf("%s Yielded Blank Output", funcName)
+		}
+
+		if _, err := w.WriteString("{}"); err != nil {
+			return fmt.Errorf("%s Write Failed: %s", funcName, err)
+		}
+
+		return nil
+	}
+
+	if opts != nil && len(opts.Indent) > 0 {
+		if err := writeJsonElementsIndent(w, keys, values, raw, opts.Indent); err != nil {
+			return fmt.Errorf("%s Write Failed: %s", funcName, err)
+		}
+
+		return nil
+	}
+
+	if _, err := w.WriteString("{"); err != nil {
+		return fmt.Errorf("%s Write Failed: %s", funcName, err)
+	}
+
+	if err := writeJsonElements(w, keys, values, raw); err != nil {
+		return fmt.Errorf("%s Write Failed: %s", funcName, err)
+	}
+
+	if _, err := w.WriteString("}"); err != nil {
+		return fmt.Errorf("%s Write Failed: %s", funcName, err)
+	}
+
+	return nil
+}
+
+// MarshalStructToJsonGroup marshals a struct pointer's fields to json string, same as MarshalStructToJson,
+// except only fields belonging to the given group are included in output, allowing reusable views (such as summary vs full) of the same struct,
+// field belongs to group if its `group:"summary,full"` struct tag contains the group name (case insensitive),
+// fields without group tag belong to all groups, if group is blank, all fields are included
+func MarshalStructToJsonGroup(inputStructPtr interface{}, tagName string, excludeTagName string, group string) (string, error) {
+	var sb strings.Builder
+
+	if err := marshalStructToJsonTo(&sb, inputStructPtr, tagName, excludeTagName, Trim(group), nil, "MarshalStructToJsonGroup"); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// isStructFieldInGroup returns true if field's group struct tag contains group, or if field has no group tag, or group is blank
+func isStructFieldInGroup(field reflect.StructField, group string) bool {
+	tagGroup := Trim(field.Tag.Get("group"))
+
+	if len(group) == 0 || len(tagGroup) == 0 {
+		return true
+	}
+
+	for _, g := range strings.Split(tagGroup, ",") {
+		if strings.ToLower(Trim(g)) == strings.ToLower(group) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isStructFieldOnlyIfMet evaluates struct tag `onlyif:"PaymentType==card && Status!=void"` of field against sibling field values in s,
+// each clause compares a field (by struct field name) with == or != against a literal value (quotes optional), clauses are joined by &&,
+// the sibling field value is stringified via ReflectValueToString after its getter (or enum tag) is applied, so comparison is on its serialized form,
+// true is returned if onlyif tag is not defined, or all clauses are met, error is returned if the tag is malformed or names unknown field
+func isStructFieldOnlyIfMet(s reflect.Value, field reflect.StructField) (bool, error) {
+	tagOnlyIf := Trim(field.Tag.Get("onlyif"))
+
+	if len(tagOnlyIf) == 0 {
+		return true, nil
+	}
+
+	for _, clause := range strings.Split(tagOnlyIf, "&&") {
+		clause = Trim(clause)
+		op := "!="
+		idx := strings.Index(clause, op)
+
+		if idx < 0 {
+			op = "=="
+			idx = strings.Index(clause, op)
+		}
+
+		if idx <= 0 {
+			return false, fmt.Errorf("OnlyIf Clause '%s' Not Valid, Expects Field==Value or Field!=Value", clause)
+		}
+
+		name := Trim(clause[:idx])
+		expected := Trim(clause[idx+len(op):])
+
+		if len(expected) >= 2 && (expected[0] == '\'' || expected[0] == '"') && expected[len(expected)-1] == expected[0] {
+			expected = expected[1 : len(expected)-1]
+		}
+
+		sf, ok := s.Type().FieldByName(name)
+
+		if !ok {
+			return false, fmt.Errorf("OnlyIf Clause '%s' Field %s Not Found", clause, name)
+		}
+
+		o := s.FieldByIndex(sf.Index)
+		boolTrue := sf.Tag.Get("booltrue")
+		boolFalse := sf.Tag.Get("boolfalse")
+		timeFormat := getStructFieldTimeFormat(sf, sf.Tag.Get("timeformat"))
+		useStringer := false
+		var err error
+
+		if tagGetter := Trim(sf.Tag.Get("getter")); len(tagGetter) > 0 {
+			o, err = invokeStructFieldGetter(s, o, tagGetter, boolTrue, boolFalse, false, false, timeFormat, false)
+		} else {
+			if useStringer, err = getStructFieldBoolTagValue(sf, "usestringer"); err == nil {
+				o, err = marshalStructFieldEnum(sf, o, false)
+			}
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("OnlyIf Clause '%s' Failed: %s", clause, err)
+		}
+
+		actual, _, err := ReflectValueToString(o, boolTrue, boolFalse, false, false, timeFormat, false, useStringer)
+
+		if err != nil {
+			return false, fmt.Errorf("OnlyIf Clause '%s' Failed: %s", clause, err)
+		}
+
+		if (op == "==") != (actual == expected) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// marshalStructToJsonElements returns the json element names in field order, along with each element's unescaped string value,
+// as evaluated by MarshalStructToJson, raw indicates elements whose value is already json (such as map rendered as json object),
+// if group is not blank, only fields belonging to the group are included, opts if not nil applies marshal defaults and value transform to non-raw values
+func marshalStructToJsonElements(s reflect.Value, tagName string, excludeTagName string, group string, opts *MarshalOptions) (keys []string, values map[string]string, raw map[string]bool, err error) {
+	return marshalStructToJsonElementsDepth(s, tagName, excludeTagName, group, opts, 0)
+}
+
+// marshalStructToJsonElementsDepth is marshalStructToJsonElements at the given nesting depth,
+// nested struct fields are rendered recursively as json objects, up to maxJsonUnmarshalDepth levels
+func marshalStructToJsonElementsDepth(s reflect.Value, tagName string, excludeTagName string, group string, opts *MarshalOptions, depth int) (keys []string, values map[string]string, raw map[string]bool, err error) {
+	values = make(map[string]string)
+	raw = make(map[string]bool)
+	unique := make(uniqueFieldClaims)
+	maskedFields := make(map[string]reflect.StructField)
+	encryptedFields := make(map[string]string)
+
+	for _, sf := range getStructFieldValues(s, tagName, false) {
+		field := sf.Field
+
+		if o := sf.Value; o.IsValid() {
+			tag, omitEmpty := getStructFieldNameTag(field, tagName)
+
+			if LenTrim(tag) == 0 {
+				tag = field.Name
+			}
+
+			if tag != "-" {
+				if LenTrim(excludeTagName) > 0 {
+					if Trim(field.Tag.Get(excludeTagName)) == "-" {
+						continue
+					}
+				}
+
+				if !isStructFieldInGroup(field, group) {
+					continue
+				}
+
+				if ok, err := isStructFieldOnlyIfMet(s, field); err != nil {
+					return nil, nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+				} else if !ok {
+					continue
+				}
+
+				tags := sf.Tags
+
+				if tags.Err != nil {
+					return nil, nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, tags.Err)
+				}
+
+				if opts != nil && opts.skipNoHash && tags.NoHash {
+					continue
+				}
+
+				if !unique.claim(field) {
+					continue
+				}
+
+				if LenTrim(field.Tag.Get("mask")) > 0 {
+					maskedFields[tag] = field
+				}
+
+				if tags.Encrypt {
+					encryptedFields[tag] = field.Name
+				}
+
+				boolTrue, boolFalse, timeFormat := tags.BoolTrueJson, tags.BoolFalseJson, tags.TimeFormat
+				skipBlank, skipZero, zeroBlank := tags.SkipBlank, tags.SkipZero, tags.ZeroBlank
+
+				opts.apply(field, &boolTrue, &boolFalse, &timeFormat, &skipBlank, &skipZero)
+
+				if omitEmpty {
+					// encoding/json omitempty option omits blank and zero value
+					skipBlank = true
+					skipZero = true
+				}
+
+				oldVal := o
+				useStringer := tags.UseStringer
+
+				if tagGetter := tags.Getter; len(tagGetter) > 0 {
+					useStringer = false
+
+					var err error
+
+					if o, err = invokeStructFieldGetter(s, o, tagGetter, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank); err != nil {
+						return nil, nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+					}
+				} else if ev, err := marshalStructFieldEnum(field, o, skipZero); err != nil {
+					return nil, nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+				} else {
+					o = ev
+				}
+
+				if o.Kind() == reflect.Ptr && o.IsNil() && isJsonNestedStructType(o.Type()) {
+					// nil nested struct pointer is rendered as json null, unless skipzero
+					if skipZero {
+						unique.release(field)
+						continue
+					}
+
+					if _, ok := values[tag]; !ok {
+						keys = append(keys, tag)
+					}
+
+					values[tag] = "null"
+					raw[tag] = true
+					continue
+				}
+
+				if isJsonNestedStruct(o) && !(useStringer && isStructFieldStringer(o)) {
+					// nested struct (or non-nil pointer to struct) is rendered recursively as json object
+					if skipZero && reflect.Indirect(o).IsZero() {
+						unique.release(field)
+						continue
+					}
+
+					objJson, e := marshalJsonNestedStruct(o, tagName, excludeTagName, opts, depth+1)
+
+					if e != nil {
+						return nil, nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, e)
+					}
+
+					if _, ok := values[tag]; !ok {
+						keys = append(keys, tag)
+					}
+
+					values[tag] = objJson
+					raw[tag] = true
+					continue
+				}
+
+				if o.Kind() == reflect.Map {
+					// map is rendered as nested json object, in sorted key order
+					if skipZero && o.Len() == 0 {
+						unique.release(field)
+						continue
+					}
+
+					mapJson := ""
+
+					if !o.IsNil() && o.Type().Elem().Kind() == reflect.String {
+						// string valued map elements are stringified via ReflectValueToString
+						if mapKeys, mapValues, e := reflectMapToStringMap(o, timeFormat); e == nil {
+							mapJson = "{" + formatJsonElements(mapKeys, mapValues, nil) + "}"
+						}
+					}
+
+					if len(mapJson) == 0 {
+						if b, e := json.Marshal(o.Interface()); e == nil {
+							mapJson = string(b)
+						}
+					}
+
+					if len(mapJson) > 0 {
+						if _, ok := values[tag]; !ok {
+							keys = append(keys, tag)
+						}
+
+						values[tag] = mapJson
+						raw[tag] = true
+					} else {
+						unique.release(field)
+					}
+
+					continue
+				}
+
+				if o.Kind() == reflect.Slice && o.Type().Elem().Kind() != reflect.Uint8 {
+					// slice of scalars is rendered as json array, empty (or nil) slice is rendered as [] unless skipzero
+					if skipZero && o.Len() == 0 {
+						unique.release(field)
+						continue
+					}
+
+					loc, _ := getStructFieldTimeZone(field)
+
+					if arrJson, ok := reflectSliceToJsonArray(o, boolTrue, boolFalse, timeFormat, zeroBlank, loc); ok {
+						if _, ok := values[tag]; !ok {
+							keys = append(keys, tag)
+						}
+
+						values[tag] = arrJson
+						raw[tag] = true
+					} else {
+						unique.release(field)
+					}
+
+					continue
+				}
+
+				if tags.JsonNull && !skipZero && isJsonNullValue(o) {
+					// nil pointer or invalid sql null value is rendered as json null, skipzero takes precedence
+					if _, ok := values[tag]; !ok {
+						keys = append(keys, tag)
+					}
+
+					values[tag] = "null"
+					raw[tag] = true
+					continue
+				}
+
+				loc, _ := getStructFieldTimeZone(field)
+				buf, skip, err := ReflectValueToString(reflectTimeInLocation(o, loc), boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank, useStringer)
+
+				if err != nil || skip {
+					unique.release(field)
+					continue
+				}
+
+				defVal := getStructFieldDefaultValue(field)
+
+				if oldVal.Kind() == reflect.Int && oldVal.Int() == 0 && strings.ToLower(buf) == "unknown" {
+					// unknown enum value will be serialized as blank
+					buf = ""
+
+					if len(defVal) > 0 {
+						buf = defVal
+					} else {
+						if unique.release(field) {
+							continue
+						}
+					}
+				}
+
+				outPrefix := tags.OutPrefix
+
+				if boolTrue == " " && len(buf) == 0 && len(outPrefix) > 0 {
+					buf = outPrefix + defVal
+				} else if boolFalse == " " && buf == "false" && len(outPrefix) > 0 {
+					buf = ""
+				} else if len(defVal) > 0 && len(buf) == 0 {
+					buf = outPrefix + defVal
+				}
+
+				if _, ok := values[tag]; !ok {
+					keys = append(keys, tag)
+				}
+
+				if opts != nil && opts.Transform != nil {
+					buf = opts.Transform(field.Name, tag, buf)
+				}
+
+				values[tag] = buf
+
+				if tags.JsonRaw && len(buf) > 0 && json.Valid([]byte(buf)) {
+					// pre-serialized json value is embedded verbatim, invalid json falls back to quoted string
+					raw[tag] = true
+				} else {
+					delete(raw, tag)
+				}
+			}
+		}
+	}
+
+	if opts != nil && opts.Redact {
+		// masked field values are redacted, map, slice and raw json values are fully masked, json null is kept
+		for tag, field := range maskedFields {
+			if v, ok := values[tag]; ok && len(v) > 0 {
+				if raw[tag] {
+					if v == "null" {
+						continue
+					}
+
+					values[tag] = RedactString(v, "full")
+					delete(raw, tag)
+				} else {
+					values[tag] = opts.redact(field, v)
+				}
+			}
+		}
+	}
+
+	if opts != nil && opts.encryptGCM != nil {
+		// encrypted field rendered as json object or array is rejected rather than emitted in clear, json null is kept
+		for _, tag := range keys {
+			fieldName, ok := encryptedFields[tag]
+
+			if !ok || len(values[tag]) == 0 {
+				continue
+			}
+
+			if raw[tag] {
+				if values[tag] == "null" {
+					continue
+				}
+
+				return nil, nil, nil, fmt.Errorf("Struct Field %s Failed: Encrypt Not Supported For Value Rendered As Json Object or Array", fieldName)
+			}
+
+			v, e := encryptStructFieldValue(opts.encryptGCM, values[tag])
+
+			if e != nil {
+				return nil, nil, nil, fmt.Errorf("Struct Field %s Failed: Encrypt Failed: %s", fieldName, e)
+			}
+
+			values[tag] = v
+		}
+	}
+
+	return keys, values, raw, nil
+}
+
+// reflectSliceToJsonArray renders slice o as json array literal, where each element is stringified via ReflectValueToString,
+// and written as json string (same as scalar field values), ok is false if any element is not convertible (such as struct element)
+func reflectSliceToJsonArray(o reflect.Value, boolTrue string, boolFalse string, timeFormat string, zeroBlank bool, loc *time.Location) (arrJson string, ok bool) {
+	var sb strings.Builder
+	sb.WriteString("[")
+
+	for i := 0; i < o.Len(); i++ {
+		buf, _, err := ReflectValueToString(reflectTimeInLocation(o.Index(i), loc), boolTrue, boolFalse, false, false, timeFormat, zeroBlank)
+
+		if err != nil {
+			return "", false
+		}
+
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(`"` + jsonEscapeString(buf) + `"`)
+	}
+
+	sb.WriteString("]")
+	return sb.String(), true
+}
+
+// isJsonNullValue returns true if o is nil pointer or interface, or database/sql null type (such as sql.NullString) whose Valid field is false
+func isJsonNullValue(o reflect.Value) bool {
+	switch o.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return o.IsNil()
+	case reflect.Struct:
+		if o.Type().PkgPath() == "database/sql" {
+			if valid := o.FieldByName("Valid"); valid.IsValid() && valid.Kind() == reflect.Bool {
+				return !valid.Bool()
+			}
+		}
+	}
+
+	return false
+}
+
+// formatJsonElements formats keys and values into comma delimited json elements, without the enclosing braces,
+// values marked in raw are written as is without quoting
+func formatJsonElements(keys []string, values map[string]string, raw map[string]bool) string {
+	var sb strings.Builder
+	_ = writeJsonElements(&sb, keys, values, raw)
+	return sb.String()
+}
+
+// writeJsonElements writes keys and values to w as comma delimited json elements, without the enclosing braces,
+// values marked in raw are written as is without quoting
+func writeJsonElements(w io.StringWriter, keys []string, values map[string]string, raw map[string]bool) error {
+	for i, k := range keys {
+		if i > 0 {
+			if _, err := w.WriteString(", "); err != nil {
+				return err
+			}
+		}
+
+		if err := writeJsonString(w, k); err != nil {
+			return err
+		}
+
+		if _, err := w.WriteString(":"); err != nil {
+			return err
+		}
+
+		if raw[k] {
+			if _, err := w.WriteString(values[k]); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := writeJsonString(w, values[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeJsonString writes v to w as quoted json string literal, without building an intermediate quoted string
+func writeJsonString(w io.StringWriter, v string) error {
+	if _, err := w.WriteString(`"`); err != nil {
+		return err
+	}
+
+	if _, err := w.WriteString(jsonEscapeString(v)); err != nil {
+		return err
+	}
+
+	_, err := w.WriteString(`"`)
+	return err
+}
+
+// writeJsonElementsIndent writes keys and values to w as json object, with each element on its own line indented by indent,
+// values marked in raw (nested json object or array) are re-indented so that their nesting continues from the element's indent level
+func writeJsonElementsIndent(w io.StringWriter, keys []string, values map[string]string, raw map[string]bool, indent string) error {
+	if _, err := w.WriteString("{\n"); err != nil {
+		return err
+	}
+
+	for i, k := range keys {
+		if _, err := w.WriteString(indent); err != nil {
+			return err
+		}
+
+		if err := writeJsonString(w, k); err != nil {
+			return err
+		}
+
+		if _, err := w.WriteString(": "); err != nil {
+			return err
+		}
+
+		if raw[k] {
+			var buf bytes.Buffer
+			v := values[k]
+
+			if err := json.Indent(&buf, []byte(v), indent, indent); err == nil {
+				v = buf.String()
+			}
+
+			if _, err := w.WriteString(v); err != nil {
+				return err
+			}
+		} else if err := writeJsonString(w, values[k]); err != nil {
+			return err
+		}
+
+		sep := "\n"
+
+		if i < len(keys)-1 {
+			sep = ",\n"
+		}
+
+		if _, err := w.WriteString(sep); err != nil {
+			return err
+		}
+	}
+
+	_, err := w.WriteString("}")
+	return err
+}
+
+// jsonEscapeString escapes v for use within json string literal (without the enclosing quotes),
+// backslash, double quote, \n, \r, \t, \b, \f are escaped with backslash, other control characters below 0x20 are escaped as \u00XX,
+// all other characters (including non-ascii such as emoji) are written as is
+func jsonEscapeString(v string) string {
+	if !isJsonEscapeNeeded(v) {
+		return v
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(v) + 8)
+
+	for _, r := range v {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			if r < 0x20 {
+				sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// isJsonEscapeNeeded returns false if v is printable ascii without backslash or double quote, which is written in json string literal as is
+func isJsonEscapeNeeded(v string) bool {
+	for i := 0; i < len(v); i++ {
+		if c := v[i]; c < 0x20 || c >= 0x80 || c == '\\' || c == '"' {
+			return true
+		}
+	}
+
+	return false
+}
+
+// invokeStructFieldSetter invokes the setter method named by tagSetter with value as parameter, for struct field o within struct s,
+// if o is ptr, interface, struct or slice, the setter result is set into o directly and handled is returned as true,
+// otherwise the setter result is returned as string (or value as is if setter is not found) for the caller to set into o
+func invokeStructFieldSetter(s reflect.Value, o reflect.Value, tagSetter string, value string, timeFormat string) (result string, handled bool, err error) {
+	isBase := false
+
+	if strings.ToLower(Left(tagSetter, 5)) == "base." {
+		isBase = true
+		tagSetter = Right(tagSetter, len(tagSetter)-5)
+	}
+
+	if o.Kind() != reflect.Ptr && o.Kind() != reflect.Interface && o.Kind() != reflect.Struct && o.Kind() != reflect.Slice {
+		// o is not ptr, interface, struct
+		var results []reflect.Value
+		var notFound bool
+
+		if isBase {
+			results, notFound = ReflectCall(s.Addr(), tagSetter, value)
+		} else {
+			results, notFound = ReflectCall(o, tagSetter, value)
+		}
+
+		if !notFound && len(results) > 0 {
+			if len(results) == 1 {
+				if jv, _, err := ReflectValueToString(results[0], "", "", false, false, timeFormat, false); err == nil {
+					value = jv
+				}
+			} else if len(results) > 1 {
+				getFirstVar := true
+
+				if e, ok := results[len(results)-1].Interface().(error); ok {
+					// last var is error, check if error exists
+					if e != nil {
+						getFirstVar = false
+					}
+				}
+
+				if getFirstVar {
+					if jv, _, err := ReflectValueToString(results[0], "", "", false, false, timeFormat, false); err == nil {
+						value = jv
+					}
+				}
+			}
+		}
+	} else {
+		// o is ptr, interface, struct
+		// get base type
+		if o.Kind() != reflect.Slice {
+			if baseType, _, isNilPtr := DerefPointersZero(o); isNilPtr {
+				// create new struct pointer
+				o.Set(reflect.New(baseType.Type()))
+			} else {
+				if o.Kind() == reflect.Interface && o.Interface() == nil {
+					customType := ReflectTypeRegistryGet(o.Type().String())
+
+					if customType == nil {
+						return "", false, fmt.Errorf("%s Struct Field %s is Interface Without Actual Object Assignment", s.Type(), o.Type())
+					} else {
+						o.Set(reflect.New(customType))
+					}
+				}
+			}
+		}
+
+		var ov []reflect.Value
+		var notFound bool
+
+		if isBase {
+			ov, notFound = ReflectCall(s.Addr(), tagSetter, value)
+		} else {
+			ov, notFound = ReflectCall(o, tagSetter, value)
+		}
+
+		if !notFound {
+			if len(ov) == 1 {
+				if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
+					o.Set(ov[0])
+				}
+			} else if len(ov) > 1 {
+				getFirstVar := true
+
+				if e := DerefError(ov[len(ov)-1]); e != nil {
+					getFirstVar = false
+				}
+
+				if getFirstVar {
+					if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
+						o.Set(ov[0])
+					}
+				}
+			}
+		}
+
+		// for o as ptr
+		// once complete, field is handled
+		return "", true, nil
+	}
+
+	return value, false, nil
+}
+
+// UnmarshalJsonToStruct will parse jsonPayload string,
+// and set parsed json element value into struct fields based on struct tag named by tagName,
+// tagName value is parsed in encoding/json style, where the name segment before comma is used, such as `json:"name,omitempty"`,
+// any tagName value with - will be ignored, any excludeTagName defined with value of - will also cause parser to ignore the field,
+// fields of embedded (anonymous) struct are promoted and set as if they belong to the outer struct, nil embedded struct pointers are allocated
+//
+// nested json object is recursively unmarshaled into struct (or pointer to struct) field using the same tagName and excludeTagName,
+// nested json array of objects is unmarshaled into slice of struct (or slice of pointer to struct) field, element by element,
+// map fields (such as map[string]string) are populated from json object as is,
+// json array of scalars (strings, numbers, bools) is unmarshaled into slice field (other than byte slice), element by element,
+// nesting is limited to maxJsonUnmarshalDepth levels, and error of nested element names its path, such as order.items[2].sku
+//
+// Predefined Struct Tags Usable:
+// 		1) `setter:"ParseByKey`		// if field type is custom struct or enum,
+//									   specify the custom method (only 1 lookup parameter value allowed) setter that sets value(s) into the field
+//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
+//									   NOTE: setter method always intake a string parameter
+//		2) `def:""`					// default value to set into struct field in case unmarshal doesn't set the struct field value
+//		3) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
+//											2006, 06 = year,
+//											01, 1, Jan, January = month,
+//											02, 2, _2 = day (_2 = width two, right justified)
+//											03, 3, 15 = hour (15 = 24 hour format)
+//											04, 4 = minute
+//											05, 5 = second
+//											PM pm = AM PM
+//		4) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//		5) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//									   booltrue.json and boolfalse.json tags, if defined, are preferred over booltrue and boolfalse for json, allowing different literals per output format
+//		6) `timezone:"UTC"`		// for time.Time field, optional time zone name (or use tz tag alias), such as UTC or America/Chicago, time value without zone info is parsed as time in this zone, invalid zone name fails unmarshal
+//		7) `jsonnull:"false"`		// if true, json null value is treated as absent, leaving the field as nil pointer or invalid sql.Null* value (default value if def tag is defined)
+//		8) `trim:"false"`			// if true, leading and trailing whitespace of json value is trimmed before setter, bool literal and field conversion
+//		9) `enum:"StatusEnum"`		// if no setter is defined, json value is converted from enum name (or its numeric value) registered via EnumRegistryRegister into integer field
+func UnmarshalJsonToStruct(inputStructPtr interface{}, jsonPayload string, tagName string, excludeTagName string) error {
+	return unmarshalJsonToStruct(inputStructPtr, jsonPayload, tagName, excludeTagName, nil)
+}
+
+// UnmarshalJsonToStructStrict parses jsonPayload into struct pointer, same as UnmarshalJsonToStruct,
+// except after unmarshal, if jsonPayload contains top level keys not matching any struct field's tagName value (or field name if tagName value is blank),
+// error listing the unknown keys and the struct type is returned, keys of fields excluded via - or excludeTagName count as known
+func UnmarshalJsonToStructStrict(inputStructPtr interface{}, jsonPayload string, tagName string, excludeTagName string) error {
+	return unmarshalJsonToStruct(inputStructPtr, jsonPayload, tagName, excludeTagName, &UnmarshalOptions{StrictUnknownFields: true})
+}
+
+// UnmarshalJsonToStructCI parses jsonPayload into struct pointer, same as UnmarshalJsonToStruct,
+// except json keys are matched to struct fields case insensitively (such as Name or NAME matching name), including nested json objects,
+// exact key match is always preferred, if no exact match exists and two or more json keys differ from the field name only in case,
+// or two struct fields have names differing only in case, error is returned as the match is ambiguous
+func UnmarshalJsonToStructCI(inputStructPtr interface{}, jsonPayload string, tagName string, excludeTagName string) error {
+	return unmarshalJsonToStruct(inputStructPtr, jsonPayload, tagName, excludeTagName, &UnmarshalOptions{CaseInsensitive: true})
+}
+
+// UnmarshalJsonToStructEncrypted parses jsonPayload into struct pointer, same as UnmarshalJsonToStruct,
+// except values of fields tagged `encrypt:"true"` are decrypted via AES-GCM using key, prior to setter and field conversion,
+// see MarshalStructToJsonEncrypted, blank values are not decrypted, value failing decryption (such as wrong key) fails unmarshal
+func UnmarshalJsonToStructEncrypted(inputStructPtr interface{}, jsonPayload string, tagName string, excludeTagName string, key []byte) error {
+	gcm, err := newStructFieldGCM(key)
+
+	if err != nil {
+		return fmt.Errorf("UnmarshalJsonToStructEncrypted Failed: %s", err)
+	}
+
+	return unmarshalJsonToStruct(inputStructPtr, jsonPayload, tagName, excludeTagName, &UnmarshalOptions{decryptGCM: gcm})
+}
+
+// unmarshalJsonToStruct parses jsonPayload into struct pointer, see UnmarshalJsonToStruct, opts if not nil applies unmarshal defaults
+func unmarshalJsonToStruct(inputStructPtr interface{}, jsonPayload string, tagName string, excludeTagName string, opts *UnmarshalOptions) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("InputStructPtr is Required")
+	}
+
+	if LenTrim(jsonPayload) == 0 {
+		return fmt.Errorf("JsonPayload is Required")
+	}
+
+	if LenTrim(tagName) == 0 {
+		return fmt.Errorf("TagName is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	// unmarshal json to map
+	jsonMap := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal([]byte(jsonPayload), &jsonMap); err != nil {
+		return fmt.Errorf("Unmarshal Json Failed: %s", err)
+	}
+
+	if jsonMap == nil {
+		return fmt.Errorf("Unmarshaled Json Map is Nil")
+	}
+
+	if len(jsonMap) == 0 {
+		return fmt.Errorf("Unmarshaled Json Map Has No Elements")
+	}
+
+	if err := unmarshalJsonElementsToStruct(inputStructPtr, jsonMap, tagName, excludeTagName, "", 0, opts); err != nil {
+		return err
+	}
+
+	if opts != nil && opts.StrictUnknownFields {
+		if unknown := getJsonUnknownKeys(s, jsonMap, tagName, opts.CaseInsensitive); len(unknown) > 0 {
+			return fmt.Errorf("UnmarshalJsonToStruct Found Unknown Fields [%s] For Struct %s", strings.Join(unknown, ", "), s.Type())
+		}
+	}
+
+	if isValidateRequiredGroupsOnUnmarshal() {
+		return ValidateStructRequiredGroups(inputStructPtr)
+	}
+
+	return nil
+}
+
+// maxJsonUnmarshalDepth is the maximum nesting level of json objects and arrays handled by UnmarshalJsonToStruct
+const maxJsonUnmarshalDepth = 32
+
+// isJsonNestedStructType returns true if t (or its pointer element) is a struct type that is unmarshaled from nested json object,
+// time.Time and database/sql types (such as sql.NullString) are handled as scalar values instead
+func isJsonNestedStructType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) && t.PkgPath() != "database/sql"
+}
+
+// isJsonNestedStruct returns true if o is a nested struct value, or non-nil pointer to one, rendered as json object
+func isJsonNestedStruct(o reflect.Value) bool {
+	if !o.IsValid() || (o.Kind() == reflect.Ptr && o.IsNil()) {
+		return false
+	}
+
+	return isJsonNestedStructType(o.Type())
+}
+
+// isStructFieldStringer returns true if o (or its address) implements fmt.Stringer
+func isStructFieldStringer(o reflect.Value) bool {
+	_, ok := reflectStringer(o)
+	return ok
+}
+
+// marshalJsonNestedStruct renders nested struct o (or pointer to it) as json object literal, at the given nesting depth
+func marshalJsonNestedStruct(o reflect.Value, tagName string, excludeTagName string, opts *MarshalOptions, depth int) (string, error) {
+	if depth > maxJsonUnmarshalDepth {
+		return "", fmt.Errorf("Exceeds Max Depth %d", maxJsonUnmarshalDepth)
+	}
+
+	keys, values, raw, err := marshalStructToJsonElementsDepth(reflect.Indirect(o), tagName, excludeTagName, "", opts, depth)
+
+	if err != nil {
+		return "", err
+	}
+
+	return "{" + formatJsonElements(keys, values, raw) + "}", nil
+}
+
+// isJsonRawKind returns true if jRaw json value begins with the given delimiter, such as { for object, or [ for array
+func isJsonRawKind(jRaw json.RawMessage, delim byte) bool {
+	for _, b := range jRaw {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		default:
+			return b == delim
+		}
+	}
+
+	return false
+}
+
+// getJsonUnknownKeys returns sorted jsonMap keys not matching any field of struct s by tagName value or field name,
+// fields tagged - or excluded are still treated as known, if caseInsensitive is true, keys are matched case insensitively
+func getJsonUnknownKeys(s reflect.Value, jsonMap map[string]json.RawMessage, tagName string, caseInsensitive bool) (unknown []string) {
+	known := make(map[string]bool)
+	knownLower := make(map[string]bool)
+
+	for _, sf := range getStructFieldValues(s, tagName, true) {
+		// same lookup rule as unmarshal, field name is used only when tag name is blank (or - excluded field, whose key counts as known)
+		jName, _ := getStructFieldNameTag(sf.Field, tagName)
+
+		if LenTrim(jName) == 0 || jName == "-" {
+			jName = sf.Field.Name
+		}
+
+		known[jName] = true
+		knownLower[strings.ToLower(jName)] = true
+	}
+
+	for k := range jsonMap {
+		if !known[k] && !(caseInsensitive && knownLower[strings.ToLower(k)]) {
+			unknown = append(unknown, k)
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown
+}
+
+// getJsonMapValue returns the jsonMap value of key, if not found and lowerKeys is not nil (case insensitive matching),
+// the value of the json key differing from key only in case is returned, error is returned if more than one such json key exists
+func getJsonMapValue(jsonMap map[string]json.RawMessage, key string, lowerKeys map[string][]string) (json.RawMessage, bool, error) {
+	if jRaw, ok := jsonMap[key]; ok || lowerKeys == nil {
+		return jRaw, ok, nil
+	}
+
+	switch keys := lowerKeys[strings.ToLower(key)]; len(keys) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return jsonMap[keys[0]], true, nil
+	default:
+		sort.Strings(keys)
+		return nil, false, fmt.Errorf("Json Keys [%s] Ambiguously Match %s Case Insensitively", strings.Join(keys, ", "), key)
+	}
+}
+
+// validateJsonFieldNamesCaseInsensitive returns error if two fields of struct s have json names (tagName value or field name) differing only in case,
+// such fields can not be matched case insensitively, fields tagged - or excluded are ignored
+func validateJsonFieldNamesCaseInsensitive(s reflect.Value, fields []structFieldValue, tagName string, excludeTagName string) error {
+	names := make(map[string]string)
+
+	for _, sf := range fields {
+		jName, _ := getStructFieldNameTag(sf.Field, tagName)
+
+		if jName == "-" || (LenTrim(excludeTagName) > 0 && Trim(sf.Field.Tag.Get(excludeTagName)) == "-") {
+			continue
+		}
+
+		if LenTrim(jName) == 0 {
+			jName = sf.Field.Name
+		}
+
+		if other, ok := names[strings.ToLower(jName)]; ok && other != jName {
+			return fmt.Errorf("Struct %s Json Names %s and %s Differ Only In Case", s.Type(), other, jName)
+		}
+
+		names[strings.ToLower(jName)] = jName
+	}
+
+	return nil
+}
+
+// unmarshalJsonElementsToStruct sets jsonMap elements into struct fields of inputStructPtr, see UnmarshalJsonToStruct,
+// path is the json path of inputStructPtr (blank for root), depth is its nesting level, opts if not nil applies unmarshal defaults
+func unmarshalJsonElementsToStruct(inputStructPtr interface{}, jsonMap map[string]json.RawMessage, tagName string, excludeTagName string, path string, depth int, opts *UnmarshalOptions) error {
+	if depth > maxJsonUnmarshalDepth {
+		return fmt.Errorf("Unmarshal Json Element %s Failed: Exceeds Max Depth %d", path, maxJsonUnmarshalDepth)
+	}
+
+	s := reflect.ValueOf(inputStructPtr).Elem()
+
+	StructClearFields(inputStructPtr)
+	fields := getStructFieldValues(s, tagName, true)
+	SetStructFieldDefaultValues(inputStructPtr)
+
+	// json keys by lower case key, for case insensitive matching
+	var lowerKeys map[string][]string
+
+	if opts != nil && opts.CaseInsensitive {
+		if err := validateJsonFieldNamesCaseInsensitive(s, fields, tagName, excludeTagName); err != nil {
+			return err
+		}
+
+		lowerKeys = make(map[string][]string)
+
+		for k := range jsonMap {
+			lk := strings.ToLower(k)
+			lowerKeys[lk] = append(lowerKeys[lk], k)
+		}
+	}
+
+	for _, sf := range fields {
+		field := sf.Field
+
+		if o := sf.Value; o.IsValid() && o.CanSet() {
+			// get json field name if defined
+			jName, _ := getStructFieldNameTag(field, tagName)
+
+			if jName == "-" {
+				continue
+			}
+
+			if LenTrim(excludeTagName) > 0 {
+				if Trim(field.Tag.Get(excludeTagName)) == "-" {
+					continue
+				}
+			}
+
+			if LenTrim(jName) == 0 {
+				jName = field.Name
+			}
+
+			jPath := jName
+
+			if len(path) > 0 {
+				jPath = path + "." + jName
+			}
+
+			// get json field value based on jName from jsonMap
+			jValue := ""
+			timeFormat := opts.timeFormat(field)
+			timeZone, err := getStructFieldTimeZone(field)
+
+			if err != nil {
+				return fmt.Errorf("UnmarshalJsonToStruct Failed: %s", err)
+			}
+
+			tags := sf.Tags
+
+			if tags.Err != nil {
+				return fmt.Errorf("UnmarshalJsonToStruct Failed: %s", tags.Err)
+			}
+
+			if jRaw, ok, e := getJsonMapValue(jsonMap, jName, lowerKeys); e != nil {
+				return fmt.Errorf("Unmarshal Json Element %s Failed: %s", jPath, e)
+			} else if !ok {
+				continue
+			} else if (tags.JsonNull || (o.Kind() == reflect.Ptr && isJsonNestedStructType(o.Type()))) && Trim(string(jRaw)) == "null" {
+				// json null is treated as absent value, leaving field as nil pointer or invalid sql null value,
+				// nested struct pointer always accepts json null
+				continue
+			} else if o.Kind() == reflect.Map {
+				// json object is unmarshaled into map field as is
+				m := reflect.New(o.Type())
+
+				if err := json.Unmarshal(jRaw, m.Interface()); err != nil {
+					return &FieldUnmarshalError{StructType: s.Type().String(), FieldName: field.Name, JsonKey: jPath, Pos: -1, RawValue: string(jRaw), Err: err}
+				}
+
+				o.Set(m.Elem())
+				continue
+			} else if len(tags.Setter) == 0 &&
+				((isJsonNestedStructType(o.Type()) && isJsonRawKind(jRaw, '{')) ||
+					(o.Kind() == reflect.Slice && isJsonNestedStructType(o.Type().Elem()) && isJsonRawKind(jRaw, '['))) {
+				// nested json object or array of objects is unmarshaled into struct or slice of struct field
+				if err := unmarshalJsonNestedField(o, jRaw, tagName, excludeTagName, jPath, depth, opts); err != nil {
+					return err
+				}
+
+				continue
+			} else if len(tags.Setter) == 0 && o.Kind() == reflect.Slice && o.Type().Elem().Kind() != reflect.Uint8 && isJsonRawKind(jRaw, '[') {
+				// json array of scalars is unmarshaled into slice field, element by element
+				if err := unmarshalJsonArrayToSlice(o, jRaw, timeFormat, timeZone); err != nil {
+					return &FieldUnmarshalError{StructType: s.Type().String(), FieldName: field.Name, JsonKey: jPath, Pos: -1, RawValue: string(jRaw), Err: err}
+				}
+
+				continue
+			} else {
+				if isJsonRawKind(jRaw, '"') {
+					// json string literal is decoded, reversing its escape sequences
+					if err := json.Unmarshal(jRaw, &jValue); err != nil {
+						jValue = JsonFromEscaped(string(jRaw))
+					}
+				} else {
+					jValue = JsonFromEscaped(string(jRaw))
+				}
+
+				if tags.Trim {
+					jValue = Trim(jValue)
+				}
+
+				if tags.Encrypt && opts != nil && opts.decryptGCM != nil && len(jValue) > 0 {
+					if v, err := decryptStructFieldValue(opts.decryptGCM, jValue); err != nil {
+						return &FieldUnmarshalError{StructType: s.Type().String(), FieldName: field.Name, JsonKey: jPath, Pos: -1, RawValue: jValue, Err: fmt.Errorf("Decrypt Failed: %s", err)}
+					} else {
+						jValue = v
+					}
+				}
+
+				if len(jValue) > 0 {
+					if tagSetter := tags.Setter; len(tagSetter) > 0 {
+						if v, handled, err := invokeStructFieldSetter(s, o, tagSetter, jValue, timeFormat); err != nil {
+							return &FieldUnmarshalError{StructType: s.Type().String(), FieldName: field.Name, JsonKey: jPath, Pos: -1, RawValue: jValue, Err: err}
+						} else if handled {
+							continue
+						} else {
+							jValue = v
+						}
+					} else if v, err := unmarshalStructFieldEnum(field, jValue); err != nil {
+						return &FieldUnmarshalError{StructType: s.Type().String(), FieldName: field.Name, JsonKey: jPath, Pos: -1, RawValue: jValue, Err: err}
+					} else {
+						jValue = v
+					}
+				}
+			}
+
+			// set validated csv value into corresponding struct field
+			outPrefix := tags.OutPrefix
+			boolTrue := opts.boolTag(field, "booltrue", "json")
+			boolFalse := opts.boolTag(field, "boolfalse", "json")
+
+			if boolTrue == " " && len(outPrefix) > 0 && jValue == outPrefix {
+				jValue = "true"
+			} else {
+				evalOk := false
+				if LenTrim(boolTrue) > 0 && len(jValue) > 0 && boolTrue == jValue {
+					jValue = "true"
+					evalOk = true
+				}
+
+				if !evalOk {
+					if LenTrim(boolFalse) > 0 && len(jValue) > 0 && boolFalse == jValue {
+						jValue = "false"
+					}
+				}
+			}
+
+			if err := ReflectStringToField(o, jValue, timeFormat, timeZone); err != nil {
+				return &FieldUnmarshalError{StructType: s.Type().String(), FieldName: field.Name, JsonKey: jPath, Pos: -1, RawValue: jValue, Err: err}
+			}
+		}
+	}
+
+	return nil
+}
+
+// unmarshalJsonArrayToSlice unmarshals json array of scalars jRaw into slice field o as new slice,
+// each element (json string, number or bool literal) is converted via ReflectStringToField, json null element is left as zero value
+func unmarshalJsonArrayToSlice(o reflect.Value, jRaw json.RawMessage, timeFormat string, timeZone *time.Location) error {
+	var elems []json.RawMessage
+
+	if err := json.Unmarshal(jRaw, &elems); err != nil {
+		return err
+	}
+
+	sv := reflect.MakeSlice(o.Type(), len(elems), len(elems))
+
+	for i, e := range elems {
+		v := Trim(string(e))
+
+		if v == "null" {
+			continue
+		}
+
+		if isJsonRawKind(e, '"') {
+			if err := json.Unmarshal(e, &v); err != nil {
+				return err
+			}
+		}
+
+		if err := ReflectStringToField(sv.Index(i), v, timeFormat, timeZone); err != nil {
+			return fmt.Errorf("Element %d: %s", i, err)
+		}
+	}
+
+	o.Set(sv)
+	return nil
+}
+
+// unmarshalJsonNestedField unmarshals json object jRaw into struct (or pointer to struct) field o,
+// or json array jRaw into slice of struct (or slice of pointer to struct) field o, path is the json path of o
+func unmarshalJsonNestedField(o reflect.Value, jRaw json.RawMessage, tagName string, excludeTagName string, path string, depth int, opts *UnmarshalOptions) error {
+	// newStruct unmarshals json object into newly allocated struct, and returns pointer to it
+	newStruct := func(t reflect.Type, raw json.RawMessage, p string) (reflect.Value, error) {
+		jsonMap := make(map[string]json.RawMessage)
+
+		if err := json.Unmarshal(raw, &jsonMap); err != nil {
+			return reflect.Value{}, fmt.Errorf("Unmarshal Json Element %s Failed: %s", p, err)
+		}
+
+		v := reflect.New(t)
+
+		if err := unmarshalJsonElementsToStruct(v.Interface(), jsonMap, tagName, excludeTagName, p, depth+1, opts); err != nil {
+			return reflect.Value{}, err
+		}
+
+		return v, nil
+	}
+
+	if o.Kind() == reflect.Slice {
+		var elems []json.RawMessage
+
+		if err := json.Unmarshal(jRaw, &elems); err != nil {
+			return fmt.Errorf("Unmarshal Json Element %s Failed: %s", path, err)
+		}
+
+		elemType := o.Type().Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+
+		if isPtr {
+			elemType = elemType.Elem()
+		}
+
+		sv := reflect.MakeSlice(o.Type(), 0, len(elems))
+
+		for i, e := range elems {
+			p := fmt.Sprintf("%s[%d]", path, i)
+
+			if !isJsonRawKind(e, '{') {
+				if isPtr && string(e) == "null" {
+					sv = reflect.Append(sv, reflect.Zero(o.Type().Elem()))
+					continue
+				}
+
+				return fmt.Errorf("Unmarshal Json Element %s Failed: Expects Json Object", p)
+			}
+
+			if v, err := newStruct(elemType, e, p); err != nil {
+				return err
+			} else if isPtr {
+				sv = reflect.Append(sv, v)
+			} else {
+				sv = reflect.Append(sv, v.Elem())
+			}
+		}
+
+		o.Set(sv)
+		return nil
+	}
+
+	if o.Kind() == reflect.Ptr {
+		if v, err := newStruct(o.Type().Elem(), jRaw, path); err != nil {
+			return err
+		} else {
+			o.Set(v)
+		}
+	} else {
+		if v, err := newStruct(o.Type(), jRaw, path); err != nil {
+			return err
+		} else {
+			o.Set(v.Elem())
+		}
+	}
+
+	return nil
+}
+
+// MarshalSliceStructToJson accepts a slice of struct pointer, then using tagName and excludeTagName to marshal to json array
+// To pass in inputSliceStructPtr, convert slice of actual objects at the calling code, using SliceObjectsToSliceInterface(),
+// if there is a need to name the value of tagName, but still need to exclude from output, use the excludeTagName with -, such as `x:"-"`,
+// opts (optional) overrides marshal defaults for this call and each element, such as BlankOutputReturnsError, where TagName and ExcludeTagName are not used
+func MarshalSliceStructToJson(inputSliceStructPtr []interface{}, tagName string, excludeTagName string, opts ...*MarshalOptions) (jsonArrayOutput string, err error) {
+	o := getMarshalOptions(opts)
+
+	if len(inputSliceStructPtr) == 0 {
+		if !o.blankOutputReturnsError() {
+			return "[]", nil
+		}
+
+		return "", fmt.Errorf("Input Slice Struct Pointer Nil")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[")
+
+	for i, v := range inputSliceStructPtr {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		if e := marshalStructToJsonTo(&sb, v, tagName, excludeTagName, "", o, "MarshalStructToJson"); e != nil {
+			return "", fmt.Errorf("MarshalSliceStructToJson Failed: %s", e)
+		}
+	}
+
+	sb.WriteString("]")
+
+	return sb.String(), nil
+}
+
+// MarshalSliceStructToJsonStream accepts a slice of struct pointer, then using tagName and excludeTagName to marshal to json array written to w,
+// each element is marshaled via MarshalStructToJson and written to w as it goes, rather than accumulating the entire json array in memory,
+// if w supports Flush (such as http.Flusher or bufio.Writer), w is flushed after each element,
+// if marshal or write fails mid-stream, writing stops and the error is returned (w will contain partial json array),
+// empty items slice writes an empty json array
+func MarshalSliceStructToJsonStream(w io.Writer, items []interface{}, tagName string, excludeTagName string) error {
+	if w == nil {
+		return fmt.Errorf("MarshalSliceStructToJsonStream Requires Writer")
+	}
+
+	flush := func() error {
+		switch f := w.(type) {
+		case interface{ Flush() error }:
+			return f.Flush()
+		case interface{ Flush() }:
+			f.Flush()
+		}
+
+		return nil
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return fmt.Errorf("MarshalSliceStructToJsonStream Write Failed: %s", err)
+	}
+
+	for i, v := range items {
+		buf, err := MarshalStructToJson(v, tagName, excludeTagName)
+
+		if err != nil {
+			return fmt.Errorf("MarshalSliceStructToJsonStream Failed at Index %d: %s", i, err)
+		}
+
+		if i > 0 {
+			buf = ", " + buf
+		}
+
+		if _, err = io.WriteString(w, buf); err != nil {
+			return fmt.Errorf("MarshalSliceStructToJsonStream Write Failed at Index %d: %s", i, err)
+		}
+
+		if err = flush(); err != nil {
+			return fmt.Errorf("MarshalSliceStructToJsonStream Flush Failed at Index %d: %s", i, err)
+		}
+	}
+
+	if _, err := io.WriteString(w, "]"); err != nil {
+		return fmt.Errorf("MarshalSliceStructToJsonStream Write Failed: %s", err)
+	}
+
+	return flush()
+}
+
+// UnmarshalNDJSONToSlice reads newline delimited json (one json object per line) from ndjsonReader,
+// and unmarshals each json line via UnmarshalJsonToStruct into new struct element appended to outputSlicePtr,
+// outputSlicePtr is pointer to slice of struct or slice of struct pointer, such as &[]*Order, blank lines are skipped
+func UnmarshalNDJSONToSlice(ndjsonReader io.Reader, outputSlicePtr interface{}, tagName string, excludeTagName string) error {
+	return UnmarshalNDJSONToSliceWithContext(context.Background(), ndjsonReader, outputSlicePtr, tagName, excludeTagName)
+}
+
+// UnmarshalNDJSONToSliceWithContext is same as UnmarshalNDJSONToSlice,
+// except ctx is checked between json lines, and ctx.Err() is returned once ctx is cancelled or timed out
+func UnmarshalNDJSONToSliceWithContext(ctx context.Context, ndjsonReader io.Reader, outputSlicePtr interface{}, tagName string, excludeTagName string) error {
+	return unmarshalLinesToSlice(ctx, ndjsonReader, outputSlicePtr, "NDJSON", func(itemPtr interface{}, line string) error {
+		return UnmarshalJsonToStruct(itemPtr, line, tagName, excludeTagName)
+	})
+}
+
+// unmarshalLinesToSlice reads r line by line, and for each non-blank line, allocates new struct element,
+// invokes unmarshal to set the line into the element, then appends the element to outputSlicePtr,
+// ctx is checked prior to each line, and ctx.Err() is returned once ctx is done, leaving outputSlicePtr with elements read so far
+func unmarshalLinesToSlice(ctx context.Context, r io.Reader, outputSlicePtr interface{}, format string, unmarshal func(itemPtr interface{}, line string) error) error {
+	if ctx == nil {
+		return fmt.Errorf("Unmarshal %s To Slice Requires Context", format)
+	}
+
+	if r == nil {
+		return fmt.Errorf("Unmarshal %s To Slice Requires Reader", format)
+	}
+
+	sp := reflect.ValueOf(outputSlicePtr)
+
+	if sp.Kind() != reflect.Ptr || sp.IsNil() || sp.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Unmarshal %s To Slice Expects OutputSlicePtr To Be Pointer To Slice", format)
+	}
+
+	sv := sp.Elem()
+	elemType := sv.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("Unmarshal %s To Slice Expects Slice Element To Be Struct or Struct Pointer", format)
+	}
+
+	br := bufio.NewReader(r)
+	lineNum := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line, readErr := br.ReadString('\n')
+
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("Unmarshal %s To Slice Read Failed at Line %d: %s", format, lineNum+1, readErr)
+		}
+
+		if len(line) > 0 {
+			lineNum++
+
+			if line = strings.TrimRight(line, "\r\n"); LenTrim(line) > 0 {
+				item := reflect.New(elemType)
+
+				if err := unmarshal(item.Interface(), line); err != nil {
+					return fmt.Errorf("Unmarshal %s To Slice Failed at Line %d: %w", format, lineNum, setFieldUnmarshalErrorLine(err, lineNum))
+				}
+
+				if isPtr {
+					sv.Set(reflect.Append(sv, item))
+				} else {
+					sv.Set(reflect.Append(sv, item.Elem()))
+				}
+			}
+		}
+
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// Snapshot captures the current stringified value of each json element of a struct pointer, keyed by values given in tagName,
+// the values are evaluated the same way as MarshalStructToJson, the returned snapshot is later passed into MarshalChangedSince
+func Snapshot(inputStructPtr interface{}, tagName string) (map[string]string, error) {
+	if inputStructPtr == nil {
+		return nil, fmt.Errorf("Snapshot Requires Input Struct Variable Pointer")
+	}
+
+	if LenTrim(tagName) == 0 {
+		return nil, fmt.Errorf("Snapshot Requires TagName (Tag Name defines Json name)")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("Snapshot Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Snapshot Requires Struct Object")
+	}
+
+	_, values, _, err := marshalStructToJsonElements(s, tagName, "", "", nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("Snapshot Failed: %s", err)
+	}
+
+	return values, nil
+}
+
+// MarshalChangedSince marshals a struct pointer's fields to json string, same as MarshalStructToJson,
+// except only json elements whose current stringified value differs from the given snapshot are included in output,
+// json elements present in snapshot but no longer yielded (such as due to skipblank or skipzero) are included with blank value,
+// if snapshot is nil or empty, all json elements are included,
+// if nothing has changed since snapshot, {} is returned
+func MarshalChangedSince(inputStructPtr interface{}, snapshot map[string]string, tagName string, excludeTagName string) (string, error) {
+	if inputStructPtr == nil {
+		return "", fmt.Errorf("MarshalChangedSince Requires Input Struct Variable Pointer")
+	}
+
+	if LenTrim(tagName) == 0 {
+		return "", fmt.Errorf("MarshalChangedSince Requires TagName (Tag Name defines Json name)")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return "", fmt.Errorf("MarshalChangedSince Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return "", fmt.Errorf("MarshalChangedSince Requires Struct Object")
+	}
+
+	if err := validateStructTimeZones(getStructFieldValues(s, tagName, false)); err != nil {
+		return "", fmt.Errorf("MarshalChangedSince Failed: %s", err)
+	}
+
+	keys, values, raw, err := marshalStructToJsonElements(s, tagName, excludeTagName, "", nil)
+
+	if err != nil {
+		return "", fmt.Errorf("MarshalChangedSince Failed: %s", err)
+	}
+
+	changedKeys := []string{}
+
+	for _, k := range keys {
+		if old, ok := snapshot[k]; !ok || old != values[k] {
+			changedKeys = append(changedKeys, k)
+		}
+	}
+
+	removedKeys := []string{}
+
+	for k := range snapshot {
+		if _, ok := values[k]; !ok {
+			removedKeys = append(removedKeys, k)
+		}
+	}
+
+	if len(removedKeys) > 0 {
+		if LenTrim(excludeTagName) > 0 {
+			// snapshot does not honor excludeTagName, so excluded fields must not be reported as removed
+			_, allValues, _, err := marshalStructToJsonElements(s, tagName, "", "", nil)
+
+			if err != nil {
+				return "", fmt.Errorf("MarshalChangedSince Failed: %s", err)
+			}
+
+			filtered := []string{}
+
+			for _, k := range removedKeys {
+				if _, ok := allValues[k]; !ok {
+					filtered = append(filtered, k)
+				}
+			}
+
+			removedKeys = filtered
+		}
+
+		sort.Strings(removedKeys)
+
+		for _, k := range removedKeys {
+			values[k] = ""
+			delete(raw, k)
+			changedKeys = append(changedKeys, k)
+		}
+	}
+
+	return fmt.Sprintf("{%s}", formatJsonElements(changedKeys, values, raw)), nil
+}
+
+// StructFingerprint returns sha256 hex fingerprint of a struct pointer's canonical form, such as for idempotency key,
+// the canonical form consists of each json element name and stringified value (evaluated the same as MarshalStructToJson), in sorted name order,
+// fields defining `nohash:"true"` struct tag (such as timestamps or nonces) are excluded from the canonical form,
+// so that structs differing only in such volatile fields yield the same fingerprint
+func StructFingerprint(inputStructPtr interface{}, tagName string) (string, error) {
+	if inputStructPtr == nil {
+		return "", fmt.Errorf("StructFingerprint Requires Input Struct Variable Pointer")
+	}
+
+	if LenTrim(tagName) == 0 {
+		return "", fmt.Errorf("StructFingerprint Requires TagName (Tag Name defines Json name)")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return "", fmt.Errorf("StructFingerprint Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return "", fmt.Errorf("StructFingerprint Requires Struct Object")
+	}
+
+	keys, values, _, err := marshalStructToJsonElements(s, tagName, "", "", &MarshalOptions{skipNoHash: true})
+
+	if err != nil {
+		return "", fmt.Errorf("StructFingerprint Failed: %s", err)
+	}
+
+	sorted := append([]string{}, keys...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+
+	for _, k := range sorted {
+		// quoted name and value keep the canonical form unambiguous
+		_, _ = h.Write([]byte(strconv.Quote(k) + ":" + strconv.Quote(values[k]) + "\n"))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// StructToMap converts a struct pointer's fields into map[string]interface{}, where field values retain their native go types rather than stringified,
+// output map keys are based on values given in tagName,
+// to exclude certain struct fields, use - as value in struct tag defined by tagName,
+// if there is a need to name the value of tagName, but still need to exclude from output, use the excludeTagName with -, such as `x:"-"`,
+// nested struct fields (or non-nil struct pointers) are converted recursively into nested map[string]interface{},
+// time.Time and sql.Null* fields are kept as is
+//
+// special struct tags:
+//		1) `getter:"Key"`			// same as MarshalStructToJson, the getter result value is placed into map instead of field value
+// 		2) `uniqueid:"xyz"`			// if two or more struct field is set with the same uniqueid, then only the first encountered field with the same uniqueid will be used
+//		3) `skipblank:"false"`		// if true, then any fields that is blank string will be excluded from map
+//		4) `skipzero:"false"`		// if true, then any fields that are 0, 0.00, time.Zero(), false, nil will be excluded from map
+func StructToMap(inputStructPtr interface{}, tagName string, excludeTagName string) (map[string]interface{}, error) {
+	if inputStructPtr == nil {
+		return nil, fmt.Errorf("StructToMap Requires Input Struct Variable Pointer")
+	}
+
+	if LenTrim(tagName) == 0 {
+		return nil, fmt.Errorf("StructToMap Requires TagName (Tag Name defines map key name)")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("StructToMap Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("StructToMap Requires Struct Object")
+	}
+
+	output, err := structValueToMap(s, tagName, excludeTagName, 0)
+
+	if err != nil {
+		return nil, fmt.Errorf("StructToMap Failed: %s", err)
+	}
+
+	return output, nil
+}
+
+// structValueToMap converts struct value s into map[string]interface{}, depth guards against runaway recursion
+func structValueToMap(s reflect.Value, tagName string, excludeTagName string, depth int) (map[string]interface{}, error) {
+	output := make(map[string]interface{})
+	unique := make(uniqueFieldClaims)
+
+	for _, sf := range getStructFieldValues(s, tagName, false) {
+		field := sf.Field
+		o := sf.Value
+
+		if !o.IsValid() || !o.CanInterface() {
+			continue
+		}
+
+		tag, _ := getStructFieldNameTag(field, tagName)
+
+		if LenTrim(tag) == 0 {
+			tag = field.Name
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		if LenTrim(excludeTagName) > 0 {
+			if Trim(field.Tag.Get(excludeTagName)) == "-" {
+				continue
+			}
+		}
+
+		if !unique.claim(field) {
+			continue
+		}
+
+		tags := sf.Tags
+
+		if tags.Err != nil {
+			return nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, tags.Err)
+		}
+
+		boolTrue, boolFalse, timeFormat := tags.BoolTrue, tags.BoolFalse, tags.TimeFormat
+		skipBlank, skipZero, zeroBlank := tags.SkipBlank, tags.SkipZero, tags.ZeroBlank
+
+		if tagGetter := tags.Getter; len(tagGetter) > 0 {
+			var err error
+
+			if o, err = invokeStructFieldGetter(s, o, tagGetter, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank); err != nil {
+				return nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+			}
+
+			if !o.IsValid() || !o.CanInterface() {
+				unique.release(field)
+				continue
+			}
+		} else if ev, err := marshalStructFieldEnum(field, o, skipZero); err != nil {
+			return nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+		} else {
+			o = ev
+		}
+
+		if skipBlank && o.Kind() == reflect.String && LenTrim(o.String()) == 0 {
+			unique.release(field)
+			continue
+		}
+
+		if skipZero && o.Kind() != reflect.String && o.IsZero() {
+			unique.release(field)
+			continue
+		}
+
+		v := o
+
+		if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			v = v.Elem()
+		}
+
+		if v.Kind() == reflect.Struct && v.Type().PkgPath() != "time" && v.Type().PkgPath() != "database/sql" && depth < 32 {
+			child, err := structValueToMap(v, tagName, excludeTagName, depth+1)
+
+			if err != nil {
+				return nil, err
+			}
+
+			output[tag] = child
+		} else {
+			output[tag] = o.Interface()
+		}
+	}
+
+	return output, nil
+}
+
+// MapToStruct sets values from data map into struct pointer fields, the map key for each field is based on values given in tagName,
+// any tagName value with - will be ignored, any excludeTagName defined with value of - will also cause the field to be ignored,
+// struct fields are cleared and then set with def default values before data is applied (same as UnmarshalJsonToStruct),
+// map value assignable to field type is set directly, otherwise the value is stringified and coerced into the field via ReflectStringToField,
+// map value that is nil, or map key not found, leaves the field with its default value,
+// map value that is blank string (or stringifies to blank) sets the field with its def default value
+//
+// Predefined Struct Tags Usable:
+//		1) `setter:"ParseByKey`		// same as UnmarshalJsonToStruct, the stringified map value is passed to setter method
+//		2) `def:""`					// default value to set into struct field in case data map doesn't set the struct field value
+//		3) `timeformat:"20060102"`	// for time.Time field, optional date time format used to parse string value
+//		4) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition
+//		5) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition
+func MapToStruct(inputStructPtr interface{}, data map[string]interface{}, tagName string, excludeTagName string) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("MapToStruct Requires Input Struct Variable Pointer")
+	}
+
+	if LenTrim(tagName) == 0 {
+		return fmt.Errorf("MapToStruct Requires TagName (Tag Name defines map key name)")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("MapToStruct Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("MapToStruct Requires Struct Object")
+	}
+
+	StructClearFields(inputStructPtr)
+	fields := getStructFieldValues(s, tagName, true)
+	SetStructFieldDefaultValues(inputStructPtr)
+
+	for _, sf := range fields {
+		field := sf.Field
+		o := sf.Value
+
+		if !o.IsValid() || !o.CanSet() {
+			continue
+		}
+
+		key, _ := getStructFieldNameTag(field, tagName)
+
+		if key == "-" {
+			continue
+		}
+
+		if LenTrim(excludeTagName) > 0 {
+			if Trim(field.Tag.Get(excludeTagName)) == "-" {
+				continue
+			}
+		}
+
+		if LenTrim(key) == 0 {
+			key = field.Name
+		}
+
+		mv, ok := data[key]
+
+		if !ok || mv == nil {
+			continue
+		}
+
+		tagSetter := Trim(field.Tag.Get("setter"))
+		defVal := getStructFieldDefaultValue(field)
+		rv := reflect.ValueOf(mv)
+
+		if len(defVal) > 0 && rv.Kind() == reflect.String && len(rv.String()) == 0 {
+			rv = reflect.ValueOf(defVal)
+		}
+
+		if len(tagSetter) == 0 && rv.Type().AssignableTo(o.Type()) {
+			o.Set(rv)
+			continue
+		}
+
+		timeFormat := getStructFieldTimeFormat(field, field.Tag.Get("timeformat"))
+		value := ""
+
+		if rv.Kind() == reflect.String {
+			value = rv.String()
+		} else if v, _, err := ReflectValueToString(rv, "", "", false, false, timeFormat, false); err != nil {
+			return fmt.Errorf("MapToStruct Field %s Value Stringify Failed: %s", field.Name, err)
+		} else {
+			value = v
+		}
+
+		if len(value) == 0 {
+			value = defVal
+		}
+
+		if len(value) > 0 && len(tagSetter) > 0 {
+			if v, handled, err := invokeStructFieldSetter(s, o, tagSetter, value, timeFormat); err != nil {
+				return err
+			} else if handled {
+				continue
+			} else {
+				value = v
+			}
+		} else if v, err := unmarshalStructFieldEnum(field, value); err != nil {
+			return fmt.Errorf("MapToStruct Field %s Failed: %s", field.Name, err)
+		} else {
+			value = v
+		}
+
+		boolTrue := field.Tag.Get("booltrue")
+		boolFalse := field.Tag.Get("boolfalse")
+
+		if LenTrim(boolTrue) > 0 && len(value) > 0 && boolTrue == value {
+			value = "true"
+		} else if LenTrim(boolFalse) > 0 && len(value) > 0 && boolFalse == value {
+			value = "false"
+		}
+
+		if err := ReflectStringToField(o, value, timeFormat); err != nil {
+			return fmt.Errorf("MapToStruct Field %s Set Value Failed: %s", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// SetStructFieldByPath sets value into the nested struct field resolved by dottedPath, such as address.zip,
+// each path segment is matched against field name defined in tagName (or field name if tagName value is not defined) at its struct level,
+// intermediate struct pointers that are nil are allocated, other fields of the struct are left as is,
+// value is set into the field same as UnmarshalJsonToStruct, honoring setter, timeformat, timezone, booltrue and boolfalse tags,
+// error is returned if path is not valid, such as segment not found, or intermediate segment not being a struct
+func SetStructFieldByPath(inputStructPtr interface{}, dottedPath string, value string, tagName string) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("SetStructFieldByPath Requires Input Struct Variable Pointer")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("SetStructFieldByPath Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("SetStructFieldByPath Requires Struct Object")
+	}
+
+	if LenTrim(dottedPath) == 0 {
+		return fmt.Errorf("SetStructFieldByPath Requires Path")
+	}
+
+	segments := strings.Split(Trim(dottedPath), ".")
+
+	for i, seg := range segments {
+		seg = Trim(seg)
+
+		if len(seg) == 0 {
+			return fmt.Errorf("SetStructFieldByPath Path '%s' Not Valid: Blank Segment", dottedPath)
+		}
+
+		sf, found := findStructFieldByKey(s, seg, tagName, true)
+
+		if !found {
+			return fmt.Errorf("SetStructFieldByPath Path '%s' Not Valid: Field '%s' Not Found", dottedPath, seg)
+		}
+
+		field := sf.Field
+		o := sf.Value
+
+		if !o.IsValid() || !o.CanSet() {
+			return fmt.Errorf("SetStructFieldByPath Path '%s' Not Valid: Field '%s' Not Settable", dottedPath, seg)
+		}
+
+		if i < len(segments)-1 {
+			// intermediate segment must be struct or pointer to struct
+			if o.Kind() == reflect.Ptr && o.Type().Elem().Kind() == reflect.Struct {
+				if o.IsNil() {
+					o.Set(reflect.New(o.Type().Elem()))
+				}
+
+				o = o.Elem()
+			}
+
+			if o.Kind() != reflect.Struct || o.Type().PkgPath() == "time" || o.Type().PkgPath() == "database/sql" {
+				return fmt.Errorf("SetStructFieldByPath Path '%s' Not Valid: Field '%s' Is Not a Struct", dottedPath, seg)
+			}
+
+			s = o
+			continue
+		}
+
+		// leaf segment, set value into field
+		timeFormat := getStructFieldTimeFormat(field, field.Tag.Get("timeformat"))
+		timeZone, err := getStructFieldTimeZone(field)
+
+		if err != nil {
+			return fmt.Errorf("SetStructFieldByPath Path '%s' Failed: %s", dottedPath, err)
+		}
+
+		if tagSetter := Trim(field.Tag.Get("setter")); len(tagSetter) > 0 && len(value) > 0 {
+			if v, handled, err := invokeStructFieldSetter(s, o, tagSetter, value, timeFormat); err != nil {
+				return fmt.Errorf("SetStructFieldByPath Path '%s' Failed: %s", dottedPath, err)
+			} else if handled {
+				return nil
+			} else {
+				value = v
+			}
+		} else if v, err := unmarshalStructFieldEnum(field, value); err != nil {
+			return fmt.Errorf("SetStructFieldByPath Path '%s' Failed: %s", dottedPath, err)
+		} else {
+			value = v
+		}
+
+		boolTrue := field.Tag.Get("booltrue")
+		boolFalse := field.Tag.Get("boolfalse")
+
+		if LenTrim(boolTrue) > 0 && len(value) > 0 && boolTrue == value {
+			value = "true"
+		} else if LenTrim(boolFalse) > 0 && len(value) > 0 && boolFalse == value {
+			value = "false"
+		}
+
+		if err := ReflectStringToField(o, value, timeFormat, timeZone); err != nil {
+			return fmt.Errorf("SetStructFieldByPath Path '%s' Failed: %s", dottedPath, err)
+		}
+	}
+
+	return nil
+}
+
+// GetStructFieldByPath returns the stringified value of the nested struct field resolved by dottedPath, such as address.zip,
+// each path segment is matched against field name defined in tagName (or field name if tagName value is not defined) at its struct level,
+// value is stringified via ReflectValueToString, honoring timeformat, timezone, booltrue and boolfalse tags,
+// if an intermediate struct pointer is nil, blank is returned, struct is not modified,
+// error is returned if path is not valid, such as segment not found, or intermediate segment not being a struct
+func GetStructFieldByPath(inputStructPtr interface{}, dottedPath string, tagName string) (string, error) {
+	if inputStructPtr == nil {
+		return "", fmt.Errorf("GetStructFieldByPath Requires Input Struct Variable Pointer")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return "", fmt.Errorf("GetStructFieldByPath Expects inputStructPtr To Be a Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return "", fmt.Errorf("GetStructFieldByPath Requires Struct Object")
+	}
+
+	if LenTrim(dottedPath) == 0 {
+		return "", fmt.Errorf("GetStructFieldByPath Requires Path")
+	}
+
+	segments := strings.Split(Trim(dottedPath), ".")
+	nilParent := false
+
+	for i, seg := range segments {
+		seg = Trim(seg)
+
+		if len(seg) == 0 {
+			return "", fmt.Errorf("GetStructFieldByPath Path '%s' Not Valid: Blank Segment", dottedPath)
+		}
+
+		sf, found := findStructFieldByKey(s, seg, tagName, false)
+
+		if !found {
+			return "", fmt.Errorf("GetStructFieldByPath Path '%s' Not Valid: Field '%s' Not Found", dottedPath, seg)
+		}
+
+		field := sf.Field
+		o := sf.Value
+
+		if i < len(segments)-1 {
+			// intermediate segment must be struct or pointer to struct
+			if o.Kind() == reflect.Ptr && o.Type().Elem().Kind() == reflect.Struct {
+				if o.IsNil() {
+					// remaining segments are validated against zero value of the struct type
+					nilParent = true
+					o = reflect.New(o.Type().Elem()).Elem()
+				} else {
+					o = o.Elem()
+				}
+			}
+
+			if o.Kind() != reflect.Struct || o.Type().PkgPath() == "time" || o.Type().PkgPath() == "database/sql" {
+				return "", fmt.Errorf("GetStructFieldByPath Path '%s' Not Valid: Field '%s' Is Not a Struct", dottedPath, seg)
+			}
+
+			s = o
+			continue
+		}
+
+		// leaf segment, stringify field value
+		if nilParent {
+			return "", nil
+		}
+
+		timeZone, err := getStructFieldTimeZone(field)
+
+		if err != nil {
+			return "", fmt.Errorf("GetStructFieldByPath Path '%s' Failed: %s", dottedPath, err)
+		}
+
+		v, _, err := ReflectValueToString(reflectTimeInLocation(o, timeZone), field.Tag.Get("booltrue"), field.Tag.Get("boolfalse"), false, false, getStructFieldTimeFormat(field, field.Tag.Get("timeformat")), false)
+
+		if err != nil {
+			return "", fmt.Errorf("GetStructFieldByPath Path '%s' Failed: %s", dottedPath, err)
+		}
+
+		return v, nil
+	}
+
+	return "", nil
+}
+
+// findStructFieldByKey returns the field of struct value s whose key equals key,
+// where field key is the tagName value (or field name if tagName value is not defined), fields with tagName value of - are excluded,
+// allocNilEmbedded is same as getStructFieldValues
+func findStructFieldByKey(s reflect.Value, key string, tagName string, allocNilEmbedded bool) (structFieldValue, bool) {
+	for _, sf := range getStructFieldValues(s, tagName, allocNilEmbedded) {
+		k := ""
+
+		if len(tagName) > 0 {
+			k, _ = getStructFieldNameTag(sf.Field, tagName)
+		}
+
+		if k == "-" {
+			continue
+		}
+
+		if len(k) == 0 {
+			k = sf.Field.Name
+		}
+
+		if k == key {
+			return sf, true
+		}
+	}
+
+	return structFieldValue{}, false
+}
+
+// StructClearFields will clear all fields within struct with default value
+func StructClearFields(inputStructPtr interface{}) {
+	if inputStructPtr == nil {
+		return
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+
+		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
+			switch o.Kind() {
+			case reflect.String:
+				o.SetString("")
+			case reflect.Bool:
+				o.SetBool(false)
+			case reflect.Int8:
+				fallthrough
+			case reflect.Int16:
+				fallthrough
+			case reflect.Int:
+				fallthrough
+			case reflect.Int32:
+				fallthrough
+			case reflect.Int64:
+				o.SetInt(0)
+			case reflect.Float32:
+				fallthrough
+			case reflect.Float64:
+				o.SetFloat(0)
+			case reflect.Uint8:
+				fallthrough
+			case reflect.Uint16:
+				fallthrough
+			case reflect.Uint:
+				fallthrough
+			case reflect.Uint32:
+				fallthrough
+			case reflect.Uint64:
+				o.SetUint(0)
+			case reflect.Ptr:
+				o.Set(reflect.Zero(o.Type()))
+			case reflect.Slice:
+				o.Set(reflect.Zero(o.Type()))
+			default:
+				switch o.Interface().(type) {
+				case sql.NullString:
+					o.Set(reflect.ValueOf(sql.NullString{}))
+				case sql.NullBool:
+					o.Set(reflect.ValueOf(sql.NullBool{}))
+				case sql.NullFloat64:
+					o.Set(reflect.ValueOf(sql.NullFloat64{}))
+				case sql.NullInt32:
+					o.Set(reflect.ValueOf(sql.NullInt32{}))
+				case sql.NullInt64:
+					o.Set(reflect.ValueOf(sql.NullInt64{}))
+				case sql.NullTime:
+					o.Set(reflect.ValueOf(sql.NullTime{}))
+				case time.Time:
+					o.Set(reflect.ValueOf(time.Time{}))
+				default:
+					o.Set(reflect.Zero(o.Type()))
+				}
+			}
+		}
+	}
+}
+
+// StructNonDefaultRequiredFieldsCount returns count of struct fields that are tagged as required but not having any default values pre-set
+func StructNonDefaultRequiredFieldsCount(inputStructPtr interface{}) int {
+	if inputStructPtr == nil {
+		return 0
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return 0
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return 0
+	}
+
+	count := 0
+
+	for _, sf := range getStructFieldValues(s, "", false) {
+		field := sf.Field
+
+		if o := sf.Value; o.IsValid() && o.CanSet() {
+			tagDef := getStructFieldDefaultValue(field)
+			tagReq := field.Tag.Get("req")
+
+			if len(tagDef) == 0 && strings.ToLower(tagReq) == "true" {
+				// required and no default value
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// IsStructFieldSet checks if any field value is not default blank or zero
+func IsStructFieldSet(inputStructPtr interface{}) bool {
+	if inputStructPtr == nil {
+		return false
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return false
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return false
+	}
+
+	for _, sf := range getStructFieldValues(s, "", false) {
+		field := sf.Field
+
+		if o := sf.Value; o.IsValid() && o.CanSet() {
+			if isStructFieldValueSet(field, o) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isStructFieldValueSet returns true if struct field value o is considered set,
+// being non-zero (non-blank, non-nil) and not equal to the field's default value, as evaluated by IsStructFieldSet
+func isStructFieldValueSet(field reflect.StructField, o reflect.Value) bool {
+	tagDef := getStructFieldDefaultValue(field)
+
+	switch o.Kind() {
+	case reflect.String:
+		if LenTrim(o.String()) > 0 {
+			if o.String() != tagDef	{
+				return true
+			}
+		}
+	case reflect.Bool:
+		if o.Bool() {
+			return true
+		}
+	case reflect.Int8:
+		fallthrough
+	case reflect.Int16:
+		fallthrough
+	case reflect.Int:
+		fallthrough
+	case reflect.Int32:
+		fallthrough
+	case reflect.Int64:
+		if o.Int() != 0 {
+			if Int64ToString(o.Int()) != tagDef	{
+				return true
+			}
+		}
+	case reflect.Float32:
+		fallthrough
+	case reflect.Float64:
+		if o.Float() != 0 {
+			if Float64ToString(o.Float()) != tagDef	{
+				return true
+			}
+		}
+	case reflect.Uint8:
+		fallthrough
+	case reflect.Uint16:
+		fallthrough
+	case reflect.Uint:
+		fallthrough
+	case reflect.Uint32:
+		fallthrough
+	case reflect.Uint64:
+		if o.Uint() > 0 {
+			if UInt64ToString(o.Uint()) != tagDef {
+				return true
+			}
+		}
+	case reflect.Ptr:
+		if !o.IsNil() {
+			return true
+		}
+	case reflect.Slice:
+		if o.Len() > 0 {
+			return true
+		}
+	default:
+		switch f := o.Interface().(type) {
+		case sql.NullString:
+			if f.Valid {
+				if len(tagDef) == 0 {
+					return true
+				} else {
+					if f.String != tagDef {
+						return true
+					}
+				}
+			}
+		case sql.NullBool:
+			if f.Valid {
+				if len(tagDef) == 0 {
+					return true
+				} else {
+					if f.Bool, _ = ParseBoolExtended(tagDef); f.Bool {
+						return true
+					}
+				}
+			}
+		case sql.NullFloat64:
+			if f.Valid {
+				if len(tagDef) == 0 {
+					return true
+				} else {
+					if Float64ToString(f.Float64) != tagDef {
+						return true
+					}
+				}
+			}
+		case sql.NullInt32:
+			if f.Valid {
+				if len(tagDef) == 0 {
+					return true
+				} else {
+					if Itoa(int(f.Int32)) != tagDef {
+						return true
+					}
+				}
+			}
+		case sql.NullInt64:
+			if f.Valid {
+				if len(tagDef) == 0 {
+					return true
+				} else {
+					if Int64ToString(f.Int64) != tagDef {
+						return true
+					}
+				}
+			}
+		case sql.NullTime:
+			if f.Valid {
+				if len(tagDef) == 0 {
+					return true
+				} else {
+					tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+
+					if LenTrim(tagTimeFormat) == 0 {
+						tagTimeFormat = DateTimeFormatString()
+					}
+
+					if f.Time != ParseDateTimeCustom(tagDef, tagTimeFormat) {
+						return true
+					}
+				}
+			}
+		case time.Time:
+			if !f.IsZero() {
+				if len(tagDef) == 0 {
+					return true
+				} else {
+					tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+
+					if LenTrim(tagTimeFormat) == 0 {
+						tagTimeFormat = DateTimeFormatString()
+					}
+
+					if f != ParseDateTimeCustom(tagDef, tagTimeFormat) {
+						return true
+					}
+				}
+			}
+		default:
+			if o.Kind() == reflect.Interface && o.Interface() != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ValidateStructRequiredGroups verifies that within each `reqgroup:"contact"` struct tag group,
+// at least one field is set (using the same is set evaluation as IsStructFieldSet),
+// such as requiring one of contact email, phone or fax, otherwise error naming the group(s) and their fields is returned
+func ValidateStructRequiredGroups(inputStructPtr interface{}) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("InputStructPtr is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	var groups []string
+	groupFields := make(map[string][]string)
+	groupSet := make(map[string]bool)
+
+	for _, sf := range getStructFieldValues(s, "", false) {
+		group := Trim(sf.Field.Tag.Get("reqgroup"))
+
+		if len(group) == 0 {
+			continue
+		}
+
+		if _, ok := groupFields[group]; !ok {
+			groups = append(groups, group)
+		}
+
+		groupFields[group] = append(groupFields[group], sf.Field.Name)
+
+		if o := sf.Value; o.IsValid() && o.CanSet() && isStructFieldValueSet(sf.Field, o) {
+			groupSet[group] = true
+		}
+	}
+
+	var msgs []string
+
+	for _, g := range groups {
+		if !groupSet[g] {
+			msgs = append(msgs, fmt.Sprintf("Required Group '%s' Expects At Least One of %s To Be Set", g, strings.Join(groupFields[g], ", ")))
+		}
+	}
+
+	if len(msgs) > 0 {
+		return fmt.Errorf("%s", strings.Join(msgs, "; "))
+	}
+
+	return nil
+}
+
+// indicates if ValidateStructRequiredGroups is invoked at the end of UnmarshalJsonToStruct and UnmarshalCSVToStruct
+var validateRequiredGroupsOnUnmarshal bool
+var validateRequiredGroupsOnUnmarshalMu sync.RWMutex
+
+// SetValidateRequiredGroupsOnUnmarshal sets whether UnmarshalJsonToStruct and UnmarshalCSVToStruct (and their variants)
+// invoke ValidateStructRequiredGroups upon successful unmarshal, returning its error if any required group is not satisfied,
+// default is false
+func SetValidateRequiredGroupsOnUnmarshal(enabled bool) {
+	validateRequiredGroupsOnUnmarshalMu.Lock()
+	defer validateRequiredGroupsOnUnmarshalMu.Unlock()
+	validateRequiredGroupsOnUnmarshal = enabled
+}
+
+// isValidateRequiredGroupsOnUnmarshal returns the flag as set by SetValidateRequiredGroupsOnUnmarshal
+func isValidateRequiredGroupsOnUnmarshal() bool {
+	validateRequiredGroupsOnUnmarshalMu.RLock()
+	defer validateRequiredGroupsOnUnmarshalMu.RUnlock()
+	return validateRequiredGroupsOnUnmarshal
+}
+
+// indicates if marshal yielding blank output returns error, default is true
+var blankOutputReturnsError = true
+var blankOutputReturnsErrorMu sync.RWMutex
+
+// SetBlankOutputReturnsError sets whether MarshalStructToJson, MarshalStructToQueryParams, MarshalStructToTOML,
+// MarshalSliceStructToJson and MarshalSliceStructToCSV (and their variants) return error when output is blank, default is true,
+// MarshalOptions.BlankOutputReturnsError overrides this default per call, such as via MarshalStruct, or the optional opts of MarshalStructToTOML, MarshalSliceStructToJson and MarshalSliceStructToCSV
+func SetBlankOutputReturnsError(enabled bool) {
+	blankOutputReturnsErrorMu.Lock()
+	defer blankOutputReturnsErrorMu.Unlock()
+	blankOutputReturnsError = enabled
+}
+
+// isBlankOutputReturnsError returns the flag as set by SetBlankOutputReturnsError
+func isBlankOutputReturnsError() bool {
+	blankOutputReturnsErrorMu.RLock()
+	defer blankOutputReturnsErrorMu.RUnlock()
+	return blankOutputReturnsError
+}
+
+// current runtime environment name, used to resolve environment specific default values via struct tag `defenv:""`
+var currentEnvironment string
+var currentEnvironmentMu sync.RWMutex
+
+// SetEnvironment sets the current runtime environment name (such as prod, staging, dev),
+// the environment name is used to resolve struct tag `defenv:"prod=x;staging=y;default=z"` default values,
+// environment name is matched case insensitive
+func SetEnvironment(name string) {
+	currentEnvironmentMu.Lock()
+	defer currentEnvironmentMu.Unlock()
+	currentEnvironment = Trim(name)
+}
+
+// GetEnvironment returns the current runtime environment name as set by SetEnvironment
+func GetEnvironment() string {
+	currentEnvironmentMu.RLock()
+	defer currentEnvironmentMu.RUnlock()
+	return currentEnvironment
+}
+
+// enum registry, keyed by enum name, used to convert struct field values tagged with `enum:"EnumName"`
+type enumRegistryEntry struct {
+	toName  map[int]string
+	toValue map[string]int
+}
+
+var enumRegistry map[string]enumRegistryEntry
+var enumRegistryMu sync.RWMutex
+
+// EnumRegistryRegister registers the int to string (name) mapping of enum type under enumName, for use by struct tag `enum:"EnumName"`,
+// stringToInt is the reverse mapping used by unmarshal, if nil, it is derived from intToString (each name must then be unique),
+// registering the same enumName again replaces the prior mapping
+func EnumRegistryRegister(enumName string, intToString map[int]string, stringToInt map[string]int) error {
+	if enumName = Trim(enumName); len(enumName) == 0 {
+		return fmt.Errorf("Enum Registry Register Requires Enum Name")
+	}
+
+	if len(intToString) == 0 {
+		return fmt.Errorf("Enum Registry Register '%s' Requires Int To String Map", enumName)
+	}
+
+	entry := enumRegistryEntry{
+		toName:  make(map[int]string, len(intToString)),
+		toValue: make(map[string]int),
+	}
+
+	for k, v := range intToString {
+		entry.toName[k] = v
+
+		if stringToInt == nil {
+			if prior, ok := entry.toValue[v]; ok {
+				return fmt.Errorf("Enum Registry Register '%s' Name '%s' Maps To Both %d and %d", enumName, v, prior, k)
+			}
+
+			entry.toValue[v] = k
+		}
+	}
+
+	for k, v := range stringToInt {
+		entry.toValue[k] = v
+	}
+
+	enumRegistryMu.Lock()
+	defer enumRegistryMu.Unlock()
+
+	if enumRegistry == nil {
+		enumRegistry = make(map[string]enumRegistryEntry)
+	}
+
+	enumRegistry[enumName] = entry
+	return nil
+}
+
+// getEnumRegistryEntry returns the registered enum entry by enumName
+func getEnumRegistryEntry(enumName string) (enumRegistryEntry, error) {
+	enumRegistryMu.RLock()
+	defer enumRegistryMu.RUnlock()
+
+	entry, ok := enumRegistry[enumName]
+
+	if !ok {
+		return enumRegistryEntry{}, fmt.Errorf("Enum '%s' Not Registered", enumName)
+	}
+
+	return entry, nil
+}
+
+// MarshalEnum returns the registered name of enum value, per enumName registered via EnumRegistryRegister
+func MarshalEnum(enumName string, value int) (string, error) {
+	entry, err := getEnumRegistryEntry(enumName)
+
+	if err != nil {
+		return "", err
+	}
+
+	name, ok := entry.toName[value]
+
+	if !ok {
+		return "", fmt.Errorf("Enum '%s' Value %d Not Registered", enumName, value)
+	}
+
+	return name, nil
+}
+
+// UnmarshalEnum returns the registered enum value of name, per enumName registered via EnumRegistryRegister,
+// name is matched exactly, if not matched, name is accepted if it is the numeric form of a registered enum value
+func UnmarshalEnum(enumName string, name string) (int, error) {
+	entry, err := getEnumRegistryEntry(enumName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if v, ok := entry.toValue[name]; ok {
+		return v, nil
+	}
+
+	if v, e := strconv.Atoi(Trim(name)); e == nil {
+		if _, ok := entry.toName[v]; ok {
+			return v, nil
+		}
+	}
+
+	return 0, fmt.Errorf("Enum '%s' Name '%s' Not Registered", enumName, name)
+}
+
+// marshalStructFieldEnum converts integer field value o into its registered enum name, when field defines struct tag `enum:"EnumName"`,
+// o is returned as is if enum tag is not defined, if o is nil pointer, or if o is zero and skipZero is true (so skipzero still applies)
+func marshalStructFieldEnum(field reflect.StructField, o reflect.Value, skipZero bool) (reflect.Value, error) {
+	tagEnum := Trim(field.Tag.Get("enum"))
+
+	if len(tagEnum) == 0 || !o.IsValid() {
+		return o, nil
+	}
+
+	v := o
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return o, nil
+		}
+
+		v = v.Elem()
+	}
+
+	var i int
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i = int(v.Uint())
+	default:
+		return o, fmt.Errorf("Enum Tag Requires Integer Field, %s Given", v.Kind())
+	}
+
+	if skipZero && i == 0 {
+		return o, nil
+	}
+
+	name, err := MarshalEnum(tagEnum, i)
+
+	if err != nil {
+		return o, err
+	}
+
+	return reflect.ValueOf(name), nil
+}
+
+// unmarshalStructFieldEnum converts enum name value into its registered integer value in string form, when field defines struct tag `enum:"EnumName"`,
+// value is returned as is if enum tag is not defined or value is blank
+func unmarshalStructFieldEnum(field reflect.StructField, value string) (string, error) {
+	tagEnum := Trim(field.Tag.Get("enum"))
+
+	if len(tagEnum) == 0 || len(value) == 0 {
+		return value, nil
+	}
+
+	i, err := UnmarshalEnum(tagEnum, value)
+
+	if err != nil {
+		return value, err
+	}
+
+	return strconv.Itoa(i), nil
+}
+
+// getStructFieldDefaultValue returns the default value of struct field,
+// if struct tag `defenv:""` is defined, the value matching current environment is returned, or its default= value if no environment match,
+// otherwise the struct tag `def:""` value is returned
+func getStructFieldDefaultValue(field reflect.StructField) string {
+	if tagDefEnv := Trim(field.Tag.Get("defenv")); len(tagDefEnv) > 0 {
+		env := strings.ToLower(GetEnvironment())
+		envFound := false
+		envVal := ""
+		defFound := false
+		defVal := ""
+
+		for _, v := range strings.Split(tagDefEnv, ";") {
+			if eq := strings.Index(v, "="); eq > 0 {
+				k := strings.ToLower(Trim(Left(v, eq)))
+				val := Right(v, len(v)-eq-1)
+
+				if len(env) > 0 && k == env && !envFound {
+					envFound = true
+					envVal = val
+				} else if k == "default" && !defFound {
+					defFound = true
+					defVal = val
+				}
+			}
+		}
+
+		if envFound {
+			return envVal
+		} else if defFound {
+			return defVal
+		}
+	}
+
+	return field.Tag.Get("def")
+}
+
+// SetStructFieldDefaultValues sets default value defined in struct tag `def:""` into given field,
+// this method is used during unmarshal action only,
+// default value setting is for value types and fields with `setter:""` defined only,
+// timeformat is used if field is datetime, for overriding default format of ISO style,
+// struct tag `defenv:"prod=x;staging=y;default=z"` provides environment specific default values, resolved via SetEnvironment(),
+// defenv takes precedence over def when current environment or default= is matched, otherwise def is used,
+// default value overflowing a numeric field is clamped if SetNumericOverflowMode is "clamp", otherwise it is skipped
+func SetStructFieldDefaultValues(inputStructPtr interface{}) bool {
+	if inputStructPtr == nil {
+		return false
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return false
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return false
+	}
+
+	for _, sf := range getStructFieldValues(s, "", false) {
+		field := sf.Field
+
+		if o := sf.Value; o.IsValid() && o.CanSet() {
+			tagDef := getStructFieldDefaultValue(field)
+
+			if len(tagDef) == 0 {
+				continue
+			}
+
+			switch o.Kind() {
+			case reflect.String:
+				if LenTrim(o.String()) == 0 {
+					o.SetString(tagDef)
+				}
+			case reflect.Int8:
+				fallthrough
+			case reflect.Int16:
+				fallthrough
+			case reflect.Int:
+				fallthrough
+			case reflect.Int32:
+				fallthrough
+			case reflect.Int64:
+				if o.Int() == 0 {
+					tagSetter := Trim(field.Tag.Get("setter"))
+
+					if LenTrim(tagSetter) == 0 {
+						if i64, ok := ParseInt64(tagDef); ok && i64 != 0 {
+							_ = reflectSetInt(o, i64)
+						}
+					} else {
+						if res, notFound := ReflectCall(o, tagSetter, tagDef); !notFound {
+							if len(res) == 1 {
+								if val, skip, err := ReflectValueToString(res[0], "", "", false, false, "", false); err == nil && !skip {
+									tagDef = val
+								} else {
+									continue
+								}
+							} else if len(res) > 1 {
+								if err := DerefError(res[len(res)-1:][0]); err == nil {
+									if val, skip, err := ReflectValueToString(res[0], "", "", false, false, "", false); err == nil && !skip {
+										tagDef = val
+									} else {
+										continue
+									}
+								}
+							}
+
+							if i64, ok := ParseInt64(tagDef); ok && i64 != 0 {
+								_ = reflectSetInt(o, i64)
+							}
+						}
+					}
+				}
+			case reflect.Float32:
+				fallthrough
+			case reflect.Float64:
+				if o.Float() == 0 {
+					if f64, ok := ParseFloat64(tagDef); ok && f64 != 0 {
+						_ = reflectSetFloat(o, f64)
+					}
+				}
+			case reflect.Uint8:
+				fallthrough
+			case reflect.Uint16:
+				fallthrough
+			case reflect.Uint:
+				fallthrough
+			case reflect.Uint32:
+				fallthrough
+			case reflect.Uint64:
+				if o.Uint() == 0 {
+					if u64 := StrToUint64(tagDef); u64 != 0 {
+						_ = reflectSetUint(o, u64)
+					}
+				}
+			default:
+				switch f := o.Interface().(type) {
+				case sql.NullString:
+					if !f.Valid {
+						o.Set(reflect.ValueOf(sql.NullString{String: tagDef, Valid: true}))
+					}
+				case sql.NullBool:
+					if !f.Valid {
+						b, _ := ParseBoolExtended(tagDef)
+						o.Set(reflect.ValueOf(sql.NullBool{Bool: b, Valid: true}))
+					}
+				case sql.NullFloat64:
+					if !f.Valid {
+						if f64, ok := ParseFloat64(tagDef); ok && f64 != 0 {
+							o.Set(reflect.ValueOf(sql.NullFloat64{Float64: f64, Valid: true}))
+						}
+					}
+				case sql.NullInt32:
+					if !f.Valid {
+						if i32, ok := ParseInt32(tagDef); ok && i32 != 0 {
+							o.Set(reflect.ValueOf(sql.NullInt32{Int32: int32(i32), Valid: true}))
+						}
+					}
+				case sql.NullInt64:
+					if !f.Valid {
+						if i64, ok := ParseInt64(tagDef); ok && i64 != 0 {
+							o.Set(reflect.ValueOf(sql.NullInt64{Int64: i64, Valid: true}))
+						}
+					}
+				case sql.NullTime:
+					if !f.Valid {
+						tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+
+						if LenTrim(tagTimeFormat) == 0 {
+							tagTimeFormat = DateTimeFormatString()
+						}
+
+						if t := ParseDateTimeCustom(tagDef, tagTimeFormat); !t.IsZero() {
+							o.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+						}
+					}
+				case time.Time:
+					if f.IsZero() {
+						tagTimeFormat := Trim(field.Tag.Get("timeformat"))
+
+						if LenTrim(tagTimeFormat) == 0 {
+							tagTimeFormat = DateTimeFormatString()
+						}
+
+						if t := ParseDateTimeCustom(tagDef, tagTimeFormat); !t.IsZero() {
+							o.Set(reflect.ValueOf(t))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// UnmarshalCSVToStruct will parse csvPayload string (one line of csv data) using csvDelimiter, (if csvDelimiter = "", then customDelimiterParserFunc is required)
+// and set parsed csv element value into struct fields based on Ordinal Position defined via struct tag,
+// additionally processes struct tag data validation and length / range (if not valid, will set to data type default),
+// fields of embedded (anonymous) struct are promoted and set as if they belong to the outer struct, nil embedded struct pointers are allocated
+//
+// Predefined Struct Tags Usable:
+//		1) `pos:"1"`				// ordinal position of the field in relation to the csv parsed output expected (Zero-Based Index)
+//									   NOTE: if field is mutually exclusive with one or more uniqueId, then pos # should be named the same for all uniqueIds,
+//											 if multiple fields are in exclusive condition, and skipBlank or skipZero, always include a blank default field as the last of unique field list
+//										     if value is '-', this means position value is calculated from other fields and set via `setter:"base.Xyz"` during unmarshal csv, there is no marshal to csv for this field
+//		2) `type:"xyz"`				// data type expected:
+//											A = AlphabeticOnly, N = NumericOnly 0-9, AN = AlphaNumeric, ANS = AN + PrintableSymbols,
+//											H = Hex, B64 = Base64, B = true/false, REGEX = Regular Expression, Blank = Any,
+//											EMAIL = Email Address, URL = Absolute Url With Scheme, UUID = UUID v4,
+//											(EMAIL, URL, UUID non-blank value not conforming to format fails validation, blank value fails only if req is true)
+//											(B64 value is validated by base64 decoding, value failing decode fails validation only if req is true)
+//		3) `regex:"xyz"`			// if Type = REGEX, this struct tag contains the regular expression string,
+//										 	regex express such as [^A-Za-z0-9_-]+
+//										 	method will replace any regex matched string to blank
+//		4) `size:"x..y"`			// data type size rule:
+//											x = Exact size match
+//											x.. = From x and up
+//											..y = From 0 up to y
+//											x..y = From x to y
+//											+%z = Append to x, x.., ..y, x..y; adds additional constraint that the result size must equate to 0 from modulo of z
+//		5) `range:"x..y"`			// data type range value when Type is N, if underlying data type is string, method will convert first before testing
+//		6) `req:"true"`				// indicates data value is required or not, true or false
+//		7) `getter:"Key"`			// if field type is custom struct or enum, specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
+//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
+//									   NOTE: if the method is to receive a parameter value, always in string data type, add '(x)' after the method name, such as 'XYZ(x)' or 'base.XYZ(x)'
+//									   NOTE: for multiple or non-string parameters, list arguments as x or self (field value), field:Name (sibling field value), or lit:'value',
+//									         such as 'base.Format(self, lit:'USD', field:Precision)', each argument is converted to the method's declared parameter type
+// 		8) `setter:"ParseByKey`		// if field type is custom struct or enum, specify the custom method (only 1 lookup parameter value allowed) setter that sets value(s) into the field
+//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
+//									   NOTE: setter method always intake a string parameter value
+//									   NOTE: to pass sibling field values as additional string parameters, list them after the method name, such as 'base.XYZ(#FieldA,#FieldB)',
+//									         where #FieldA resolves to the value of sibling field FieldA (declare sibling fields before this field), useful for pos:"-" computed fields
+//		9) `outprefix:""`			// for marshal method, if field value is to precede with an output prefix, such as XYZ= (affects marshal queryParams / csv methods only)
+//									   WARNING: if csv is variable elements count, rather than fixed count ordinal, then csv MUST include outprefix for all fields in order to properly identify target struct field
+//		10) `def:""`				// default value to set into struct field in case unmarshal doesn't set the struct field value
+//		11) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
+//											2006, 06 = year,
+//											01, 1, Jan, January = month,
+//											02, 2, _2 = day (_2 = width two, right justified)
+//											03, 3, 15 = hour (15 = 24 hour format)
+//											04, 4 = minute
+//											05, 5 = second
+//											PM pm = AM PM
+//		12) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//		13) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//									   booltrue.csv and boolfalse.csv tags, if defined, are preferred over booltrue and boolfalse for csv, allowing different literals per output format
+//		14) `validate:"==x"`		// if field has to match a specific value or the entire method call will fail, match data format as:
+//									   		==xyz (== refers to equal, for numbers and string match, xyz is data to match, case insensitive)
+//												[if == validate against one or more values, use ||]
+//									   		!=xyz (!= refers to not equal)
+//												[if != validate against one or more values, use &&]
+//											>=xyz >>xyz <<xyz <=xyz (greater equal, greater, less than, less equal; xyz must be int or float)
+//											:=Xyz where Xyz is a parameterless function defined at struct level, that performs validation, returns bool or error where true or nil indicates validation success
+//											==#Xyz !=#Xyz >=#Xyz >>#Xyz <<#Xyz <=#Xyz where Xyz is sibling struct field name, compares against sibling field's current value,
+//												(numbers and time.Time compare by value, others compare as exact string; for unmarshal, evaluated after all fields are set, so sibling field may be declared in any order)
+//									   note: expected source data type for validate to be effective is string, int, float64; if field is blank and req = false, then validate will be skipped
+//		15) `timezone:"UTC"`		// for time.Time field, optional time zone name (or use tz tag alias), such as UTC or America/Chicago, time value without zone info is parsed as time in this zone, invalid zone name fails unmarshal
+//		16) `subdelim:";"`			// for primitive slice field (such as []int), csv cell is split by the sub delimiter into slice elements, blank cell sets nil slice
+//		17) `trim:"false"`			// if true, leading and trailing whitespace of csv value is trimmed before bool literal, type extraction, size and validate checks
+//		18) `enum:"StatusEnum"`		// if no setter is defined, csv value is converted from enum name (or its numeric value) registered via EnumRegistryRegister into integer field
+//		19) `b64decode:"false"`		// for type b64, if true, csv value is base64 decoded into field after validation (b64 value failing decode is rejected if req is true)
+//		20) `b64mode:"std"`			// for type b64, std (default) or url, the base64 alphabet used to validate, decode and encode value
+//		21) `postime:"4"`			// for time.Time field, date column at pos and time column at postime are recombined, parsed per timeformat and timeformat2 joined by space
+func UnmarshalCSVToStruct(inputStructPtr interface{}, csvPayload string, csvDelimiter string, customDelimiterParserFunc func(string) []string) error {
+	return unmarshalCSVToStruct(inputStructPtr, csvPayload, csvDelimiter, customDelimiterParserFunc, false, nil)
+}
+
+// UnmarshalCSVToStructAggregate will parse csvPayload string into struct pointer, same as UnmarshalCSVToStruct,
+// except validation failures do not stop the unmarshal at the first failed field,
+// instead every failed field (with its rule and offending value) is collected and returned as ValidationErrors,
+// when any validation failure occurs, the struct fields are cleared before returning
+func UnmarshalCSVToStructAggregate(inputStructPtr interface{}, csvPayload string, csvDelimiter string, customDelimiterParserFunc func(string) []string) error {
+	return unmarshalCSVToStruct(inputStructPtr, csvPayload, csvDelimiter, customDelimiterParserFunc, true, nil)
+}
+
+// UnmarshalCSVToSlice reads csv records (one record per line) from csvReader,
+// and unmarshals each record via UnmarshalCSVToStruct into new struct element appended to outputSlicePtr,
+// outputSlicePtr is pointer to slice of struct or slice of struct pointer, such as &[]*Order, blank lines are skipped
+func UnmarshalCSVToSlice(csvReader io.Reader, outputSlicePtr interface{}, csvDelimiter string, customDelimiterParserFunc func(string) []string) error {
+	return UnmarshalCSVToSliceWithContext(context.Background(), csvReader, outputSlicePtr, csvDelimiter, customDelimiterParserFunc)
+}
+
+// UnmarshalCSVToSliceWithContext is same as UnmarshalCSVToSlice,
+// except ctx is checked between csv records, and ctx.Err() is returned once ctx is cancelled or timed out
+func UnmarshalCSVToSliceWithContext(ctx context.Context, csvReader io.Reader, outputSlicePtr interface{}, csvDelimiter string, customDelimiterParserFunc func(string) []string) error {
+	return unmarshalLinesToSlice(ctx, csvReader, outputSlicePtr, "CSV", func(itemPtr interface{}, line string) error {
+		return UnmarshalCSVToStruct(itemPtr, line, csvDelimiter, customDelimiterParserFunc)
+	})
+}
+
+// StreamCSVToStructs reads csv records (one record per line) from r line by line, keeping memory use flat regardless of payload size,
+// each non-blank line is unmarshaled via UnmarshalCSVToStruct into a new struct pointer obtained from newStructFn,
+// then handler is invoked with the struct pointer and its line number (1-based, blank lines are counted but skipped),
+// processing stops at the first unmarshal or handler error, which is returned with line context,
+// maxLineSize = optional maximum bytes per line (default 1MB), line exceeding maximum fails the stream
+func StreamCSVToStructs(r io.Reader, csvDelimiter string, newStructFn func() interface{}, handler func(interface{}, int) error, maxLineSize ...int) error {
+	if r == nil {
+		return fmt.Errorf("Stream CSV To Structs Requires Reader")
+	}
+
+	if newStructFn == nil {
+		return fmt.Errorf("Stream CSV To Structs Requires New Struct Func")
+	}
+
+	if handler == nil {
+		return fmt.Errorf("Stream CSV To Structs Requires Handler")
+	}
+
+	maxSize := 1024 * 1024
+
+	if len(maxLineSize) > 0 && maxLineSize[0] > 0 {
+		maxSize = maxLineSize[0]
+	}
+
+	initSize := 64 * 1024
+
+	if initSize > maxSize {
+		initSize = maxSize
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, initSize), maxSize)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if LenTrim(line) == 0 {
+			continue
+		}
+
+		item := newStructFn()
+
+		if item == nil {
+			return fmt.Errorf("Stream CSV To Structs Failed at Line %d: New Struct Func Returned Nil", lineNum)
+		}
+
+		if err := UnmarshalCSVToStruct(item, line, csvDelimiter, nil); err != nil {
+			return fmt.Errorf("Stream CSV To Structs Failed at Line %d: %w", lineNum, setFieldUnmarshalErrorLine(err, lineNum))
+		}
+
+		if err := handler(item, lineNum); err != nil {
+			return fmt.Errorf("Stream CSV To Structs Handler Failed at Line %d: %w", lineNum, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Stream CSV To Structs Read Failed at Line %d: %s", lineNum+1, err)
+	}
+
+	return nil
+}
+
+// unmarshalCSVToStruct parses csvPayload into struct pointer, if aggregate is true, validation failures are collected rather than fail fast
+func unmarshalCSVToStruct(inputStructPtr interface{}, csvPayload string, csvDelimiter string, customDelimiterParserFunc func(string) []string, aggregate bool, opts *UnmarshalOptions) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("InputStructPtr is Required")
+	}
+
+	if LenTrim(csvPayload) == 0 {
+		return fmt.Errorf("CSV Payload is Required")
+	}
+
+	if len(csvDelimiter) == 0 && customDelimiterParserFunc == nil {
+		return fmt.Errorf("CSV Delimiter or Custom Delimiter Func is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	var csvElements []string
+
+	if len(csvDelimiter) > 0 {
+		csvElements = strings.Split(csvPayload, csvDelimiter)
+	} else {
+		csvElements = customDelimiterParserFunc(csvPayload)
+	}
+
+	if len(csvElements) == 0 {
+		return fmt.Errorf("CSV Payload Contains Zero Elements")
+	}
+
+	return unmarshalCSVElementsToStruct(inputStructPtr, csvElements, nil, aggregate, false, opts)
+}
+
+// UnmarshalCSVByHeader will parse dataLine (one line of csv data) using csvDelimiter,
+// where the csv column ordering is described by headerLine (the csv header row) rather than the struct's pos tags,
+// each struct field having a pos tag is matched to its csv column by struct tag `col:"ColumnName"`, or by field name if col is not defined,
+// column name matching is case insensitive, and any struct field whose column is absent from headerLine retains its default value,
+// all other struct tags are processed the same as UnmarshalCSVToStruct
+//
+// Additional Struct Tags Usable:
+//		1) `col:"ColumnName"`		// csv header column name to match the struct field against, defaults to struct field name if not defined
+func UnmarshalCSVByHeader(inputStructPtr interface{}, headerLine string, dataLine string, csvDelimiter string) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("InputStructPtr is Required")
+	}
+
+	if LenTrim(headerLine) == 0 {
+		return fmt.Errorf("CSV Header Line is Required")
+	}
+
+	if LenTrim(dataLine) == 0 {
+		return fmt.Errorf("CSV Data Line is Required")
+	}
+
+	if len(csvDelimiter) == 0 {
+		return fmt.Errorf("CSV Delimiter is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	// map header column names to their csv ordinal index
+	headerMap := make(map[string]int)
+
+	for i, h := range strings.Split(headerLine, csvDelimiter) {
+		h = strings.ToLower(Trim(h))
+
+		if _, ok := headerMap[h]; !ok && len(h) > 0 {
+			headerMap[h] = i
+		}
+	}
+
+	dataElements := strings.Split(dataLine, csvDelimiter)
+
+	// re-order data elements into pos ordinal positions,
+	// positions whose column is absent from header are skipped during unmarshal
+	posValues := make(map[int]string)
+	maxPos := -1
+
+	for _, sf := range getStructFieldValues(s, "pos", false) {
+		field := sf.Field
+
+		tagPos, ok := ParseInt32(field.Tag.Get("pos"))
+
+		if !ok || tagPos < 0 {
+			continue
+		}
+
+		if tagPos > maxPos {
+			maxPos = tagPos
+		}
+
+		colName := Trim(field.Tag.Get("col"))
+
+		if LenTrim(colName) == 0 {
+			colName = field.Name
+		}
+
+		if idx, ok := headerMap[strings.ToLower(colName)]; ok && idx < len(dataElements) {
+			if _, exists := posValues[tagPos]; !exists {
+				posValues[tagPos] = dataElements[idx]
+			}
+		}
+	}
+
+	if maxPos < 0 {
+		return fmt.Errorf("InputStructPtr Contains No Pos Tagged Fields")
+	}
+
+	csvElements := make([]string, maxPos+1)
+	skipPos := make(map[int]bool)
+
+	for i := 0; i <= maxPos; i++ {
+		if v, ok := posValues[i]; ok {
+			csvElements[i] = v
+		} else {
+			skipPos[i] = true
+		}
+	}
+
+	return unmarshalCSVElementsToStruct(inputStructPtr, csvElements, skipPos, false, false, nil)
+}
+
+// UnmarshalSparseCSVToStruct sets sparse csv data (map of pos to csv value, such as from MarshalStructToSparseCSV) into struct pointer fields,
+// only fields whose pos appears in data are set, all other fields retain their current values (struct is not cleared, nor set with defaults),
+// this supports delta updates where absent positions mean unchanged, all other struct tags are processed the same as UnmarshalCSVToStruct,
+// if unmarshal fails, fields set prior to the failure are not reverted
+func UnmarshalSparseCSVToStruct(inputStructPtr interface{}, data map[int]string) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("InputStructPtr is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	maxPos := -1
+
+	for pos := range data {
+		if pos < 0 {
+			return fmt.Errorf("Sparse CSV Pos %d Not Valid", pos)
+		}
+
+		if pos > maxPos {
+			maxPos = pos
+		}
+	}
+
+	csvElements := make([]string, maxPos+1)
+	skipPos := make(map[int]bool)
+
+	for i := 0; i <= maxPos; i++ {
+		if v, ok := data[i]; ok {
+			csvElements[i] = v
+		} else {
+			skipPos[i] = true
+		}
+	}
+
+	return unmarshalCSVElementsToStruct(inputStructPtr, csvElements, skipPos, false, true, nil)
+}
+
+// unmarshalCSVElementsToStruct sets the already parsed csv elements into struct fields based on pos struct tag ordinal position,
+// any ordinal position marked in skipPos is treated as absent from csv, so that the struct field retains its default value,
+// if retain is true, struct fields are not cleared and set with defaults prior to unmarshal (nor cleared upon failure), so absent positions retain current values,
+// inputStructPtr must be validated as struct pointer by caller
+func unmarshalCSVElementsToStruct(inputStructPtr interface{}, csvElements []string, skipPos map[int]bool, aggregate bool, retain bool, opts *UnmarshalOptions) error {
+	s := reflect.ValueOf(inputStructPtr).Elem()
+
+	csvLen := len(csvElements)
+
+	clearFields := func() {
+		if !retain {
+			StructClearFields(inputStructPtr)
+		}
+	}
+
+	clearFields()
+	fields := getStructFieldValues(s, "pos", true)
+
+	if !retain {
+		SetStructFieldDefaultValues(inputStructPtr)
+	}
+	prefixProcessedMap := make(map[string]string)
+	var validationErrs ValidationErrors
+
+	// cross field validations are deferred until all fields are set, so that sibling field values are available
+	type crossFieldCheck struct {
+		field  reflect.StructField
+		tagReq string
+		value  string
+	}
+
+	var crossFieldChecks []crossFieldCheck
+
+	finishValidation := func() error {
+		for _, c := range crossFieldChecks {
+			if fe := validateStructFieldValidateTag(s, c.field, c.tagReq, c.value); fe != nil {
+				if !aggregate {
+					clearFields()
+					return fe
+				}
+
+				validationErrs = append(validationErrs, *fe)
+			}
+		}
+
+		if len(validationErrs) > 0 {
+			clearFields()
+			return validationErrs
+		}
+
+		return nil
+	}
+
+	for _, sf := range fields {
+		field := sf.Field
+
+		if o := sf.Value; o.IsValid() && o.CanSet() {
+			// extract struct tag values
+			tagPosBuf := field.Tag.Get("pos")
+			tagPos, ok := ParseInt32(tagPosBuf)
+			fieldTags := sf.Tags
+
+			if !ok {
+				if tagPosBuf != "-" || len(fieldTags.Setter) == 0 {
+					continue
+				}
+			} else if tagPos < 0 {
+				continue
+			}
+
+			if fieldTags.Err != nil {
+				clearFields()
+				return fmt.Errorf("Struct Field %s Failed: %s", field.Name, fieldTags.Err)
+			}
+
+			// unmarshal only validates max
+			tags := parseStructFieldValidateTags(field)
+			tagType := tags.Type
+			tagRegEx := tags.RegEx
+			sizeMax := tags.SizeMax
+			tagModulo := tags.Modulo
+			tagReq := tags.Req
+			trimValue := fieldTags.Trim
+
+			// if outPrefix exists, remove from csvValue
+			outPrefix := Trim(fieldTags.OutPrefix)
+
+			// get csv value by ordinal position
+			csvValue := ""
+			posTimeJoined := false
+
+			if tagPosBuf != "-" {
+				if LenTrim(outPrefix) == 0 {
+					// ordinal based csv parsing
+					if csvElements != nil {
+						if skipPos[tagPos] {
+							// csv value not available for this position, field retains default value
+							continue
+						} else if tagPos > csvLen-1 {
+							// no more elements to unmarshal for this position, field retains default value
+							continue
+						} else {
+							csvValue = csvElements[tagPos]
+
+							if posTime, ok := getStructFieldPosTime(field, csvLen); ok && !skipPos[posTime] && LenTrim(csvValue) > 0 && LenTrim(csvElements[posTime]) > 0 {
+								// date and time columns are recombined, and parsed per timeformat and timeformat2 joined by space
+								csvValue = Trim(csvValue) + " " + Trim(csvElements[posTime])
+								posTimeJoined = true
+							}
+
+							if trimValue {
+								csvValue = Trim(csvValue)
+							}
+
+							evalOk := false
+							if boolTrue := Trim(opts.boolTag(field, "booltrue", "csv")); len(boolTrue) > 0 {
+								if boolTrue == csvValue {
+									csvValue = "true"
+									evalOk = true
+								}
+							}
+
+							if !evalOk {
+								if boolFalse := Trim(opts.boolTag(field, "boolfalse", "csv")); len(boolFalse) > 0 {
+									if boolFalse == csvValue {
+										csvValue = "false"
+									}
+								}
+							}
+						}
+					}
+				} else {
+					// variable element based csv, using outPrefix as the identifying key
+					// instead of getting csv value from element position, acquire from outPrefix
+					notFound := true
+
+					for _, v := range csvElements {
+						if strings.ToLower(Left(v, len(outPrefix))) == strings.ToLower(outPrefix) {
+							// match
+							if _, ok := prefixProcessedMap[strings.ToLower(outPrefix)]; !ok {
+								prefixProcessedMap[strings.ToLower(outPrefix)] = Itoa(tagPos)
+
+								if len(v)-len(outPrefix) == 0 {
+									csvValue = ""
+
+									if opts.boolTag(field, "booltrue", "csv") == " " {
+										// prefix found, since data is blank, and boolTrue is space, treat this as true
+										csvValue = "true"
+									}
+								} else {
+									csvValue = Right(v, len(v)-len(outPrefix))
+
+									if trimValue {
+										csvValue = Trim(csvValue)
+									}
+
+									evalOk := false
+									if boolTrue := Trim(opts.boolTag(field, "booltrue", "csv")); len(boolTrue) > 0 {
+										if boolTrue == csvValue {
+											csvValue = "true"
+											evalOk = true
+										}
+									}
+
+									if !evalOk {
+										if boolFalse := Trim(opts.boolTag(field, "boolfalse", "csv")); len(boolFalse) > 0 {
+											if boolFalse == csvValue {
+												csvValue = "false"
+											}
+										}
+									}
+								}
+
+								notFound = false
+								break
+							}
+						}
+					}
+
+					if notFound {
+						continue
+					}
+				}
+			}
+
+			// pre-process csv value with validation
+			tagSetter := fieldTags.Setter
+			hasSetter := false
+
+			isBase := false
+			var setterArgs []interface{}
+
+			if LenTrim(tagSetter) > 0 {
+				hasSetter = true
+
+				if strings.ToLower(Left(tagSetter, 5)) == "base." {
+					isBase = true
+					tagSetter = Right(tagSetter, len(tagSetter)-5)
+				}
+
+				var e error
+
+				if tagSetter, setterArgs, e = parseStructFieldSetterArgs(s, tagSetter); e != nil {
+					clearFields()
+					return fmt.Errorf("Struct Field %s Setter Not Valid: %s", field.Name, e)
+				}
+			}
+
+			timeFormat := opts.timeFormat(field)
+			timeZone, err := getStructFieldTimeZone(field)
+
+			if posTimeJoined {
+				timeFormat += " " + Trim(field.Tag.Get("timeformat2"))
+			}
+
+			if err != nil {
+				clearFields()
+				return err
+			}
+
+			if o.Kind() != reflect.Ptr && o.Kind() != reflect.Interface && o.Kind() != reflect.Struct && o.Kind() != reflect.Slice {
+				if tagPosBuf != "-" {
+					switch tagType {
+					case "a":
+						csvValue, _ = ExtractAlpha(csvValue)
+					case "n":
+						csvValue, _ = ExtractNumeric(csvValue)
+					case "an":
+						csvValue, _ = ExtractAlphaNumeric(csvValue)
+					case "ans":
+						if !hasSetter {
+							csvValue, _ = ExtractAlphaNumericPrintableSymbols(csvValue)
+						}
+					case "b":
+						if b, _ := ParseBoolExtended(csvValue); b {
+							csvValue = "true"
+						} else {
+							csvValue = "false"
+						}
+					case "regex":
+						csvValue, _ = ExtractByRegex(csvValue, tagRegEx)
+					case "h":
+						csvValue, _ = ExtractHex(csvValue)
+					case "b64":
+						csvValue, _ = ExtractAlphaNumericPrintableSymbols(csvValue)
+					}
+
+					if tagType == "a" || tagType == "an" || tagType == "ans" || tagType == "n" || tagType == "regex" || tagType == "h" || tagType == "b64" {
+						if sizeMax > 0 {
+							if len(csvValue) > sizeMax {
+								csvValue = Left(csvValue, sizeMax)
+							}
+						}
+
+						if tagModulo > 0 {
+							if len(csvValue)%tagModulo != 0 {
+								fe := &FieldError{Field: field.Name, Rule: "size", Value: csvValue, Message: fmt.Sprintf("Struct Field %s Expects Value In Blocks of %d Characters", field.Name, tagModulo)}
+
+								if !aggregate {
+									clearFields()
+									return fe
+								}
+
+								validationErrs = append(validationErrs, *fe)
+								continue
+							}
+						}
+					}
+
+					if fe := validateStructFieldFormat(field, tags, csvValue); fe != nil {
+						if !aggregate {
+							clearFields()
+							return fe
+						}
+
+						validationErrs = append(validationErrs, *fe)
+						continue
+					}
+
+					if tagType == "b64" && len(csvValue) > 0 {
+						// b64 value is validated by decoding, and optionally decoded into field
+						if decoded, e := getStructFieldBase64Encoding(field).DecodeString(csvValue); e != nil {
+							if tagReq == "true" {
+								fe := &FieldError{Field: field.Name, Rule: "type", Value: csvValue, Message: fmt.Sprintf("%s Validation Failed: Expected Type 'b64', But Received '%s'", field.Name, csvValue)}
+
+								if !aggregate {
+									clearFields()
+									return fe
+								}
+
+								validationErrs = append(validationErrs, *fe)
+								continue
+							}
+						} else if fieldTags.B64Decode {
+							csvValue = string(decoded)
+						}
+					}
+				}
+
+				if LenTrim(tagSetter) > 0 {
+					var ov []reflect.Value
+					var notFound bool
+
+					if isBase {
+						ov, notFound = ReflectCall(s.Addr(), tagSetter, append([]interface{}{csvValue}, setterArgs...)...)
+					} else {
+						ov, notFound = ReflectCall(o, tagSetter, append([]interface{}{csvValue}, setterArgs...)...)
+					}
+
+					if !notFound {
+						if len(ov) == 1 {
+							csvValue, _, _ = ReflectValueToString(ov[0], "", "", false, false, timeFormat, false)
+						} else if len(ov) > 1 {
+							getFirstVar := true
+
+							if e, ok := ov[len(ov)-1].Interface().(error); ok {
+								// last var is error, check if error exists
+								if e != nil {
+									getFirstVar = false
+								}
+							}
+
+							if getFirstVar {
+								csvValue, _, _ = ReflectValueToString(ov[0], "", "", false, false, timeFormat, false)
+							}
+						}
+					}
+				} else if ev, err := unmarshalStructFieldEnum(field, csvValue); err != nil {
+					clearFields()
+					return newCSVFieldUnmarshalError(s, field, tagPosBuf, csvValue, err)
+				} else {
+					csvValue = ev
+				}
+
+				// validate if applicable
+				skipFieldSet := false
+
+				if valData := Trim(field.Tag.Get("validate")); len(valData) >= 3 && Left(valData, 2) == ":=" {
+					// validator method evaluates the struct itself, so field value is set prior to validate
+					skipFieldSet = true
+
+					if err := ReflectStringToField(o, csvValue, timeFormat, timeZone); err != nil {
+						clearFields()
+						return newCSVFieldUnmarshalError(s, field, tagPosBuf, csvValue, err)
+					}
+				}
+
+				if isStructFieldCrossFieldValidate(field) {
+					crossFieldChecks = append(crossFieldChecks, crossFieldCheck{field: field, tagReq: tagReq, value: csvValue})
+				} else if fe := validateStructFieldValidateTag(s, field, tagReq, csvValue); fe != nil {
+					if !aggregate {
+						clearFields()
+						return fe
+					}
+
+					validationErrs = append(validationErrs, *fe)
+					continue
+				}
+
+				// set validated csv value into corresponding struct field
+				if !skipFieldSet {
+					if err := ReflectStringToField(o, csvValue, timeFormat, timeZone); err != nil {
+						clearFields()
+						return newCSVFieldUnmarshalError(s, field, tagPosBuf, csvValue, err)
+					}
+				}
+			} else if subDelim := field.Tag.Get("subdelim"); len(subDelim) > 0 && len(tagSetter) == 0 && o.Kind() == reflect.Slice {
+				// single csv cell is split into primitive slice
+				if err := reflectDelimitedStringToSlice(o, csvValue, subDelim, timeFormat, timeZone); err != nil {
+					clearFields()
+					return newCSVFieldUnmarshalError(s, field, tagPosBuf, csvValue, err)
+				}
+			} else {
+				if LenTrim(tagSetter) > 0 {
+					if o.Kind() != reflect.Slice {
+						// get base type
+						if baseType, _, isNilPtr := DerefPointersZero(o); isNilPtr {
+							// create new struct pointer
+							o.Set(reflect.New(baseType.Type()))
+						} else {
+							if o.Kind() == reflect.Interface && o.Interface() == nil {
+								customType := ReflectTypeRegistryGet(o.Type().String())
+
+								if customType == nil {
+									clearFields()
+									return fmt.Errorf("%s Struct Field %s is Interface Without Actual Object Assignment", s.Type(), o.Type())
+								} else {
+									o.Set(reflect.New(customType))
+								}
+							}
+						}
+					}
+
+					var ov []reflect.Value
+					var notFound bool
+
+					if isBase {
+						ov, notFound = ReflectCall(s.Addr(), tagSetter, append([]interface{}{csvValue}, setterArgs...)...)
+					} else {
+						ov, notFound = ReflectCall(o, tagSetter, append([]interface{}{csvValue}, setterArgs...)...)
+					}
+
+					if !notFound {
+						if len(ov) == 1 {
+							if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
+								o.Set(ov[0])
+							}
+						} else if len(ov) > 1 {
+							getFirstVar := true
+
+							if e := DerefError(ov[len(ov)-1]); e != nil {
+								getFirstVar = false
+							}
+
+							if getFirstVar {
+								if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
+									o.Set(ov[0])
+								}
+							}
+						}
+					}
+				} else {
+					// validate if applicable (such as time.Time field compared against sibling field)
+					if isStructFieldCrossFieldValidate(field) {
+						crossFieldChecks = append(crossFieldChecks, crossFieldCheck{field: field, tagReq: tagReq, value: csvValue})
+					} else if fe := validateStructFieldValidateTag(s, field, tagReq, csvValue); fe != nil {
+						if !aggregate {
+							clearFields()
+							return fe
+						}
+
+						validationErrs = append(validationErrs, *fe)
+						continue
+					}
+
+					// set validated csv value into corresponding struct pointer field
+					if err := ReflectStringToField(o, csvValue, timeFormat, timeZone); err != nil {
+						clearFields()
+						return newCSVFieldUnmarshalError(s, field, tagPosBuf, csvValue, err)
+					}
+				}
+			}
+		}
+	}
+
+	if err := finishValidation(); err != nil {
+		return err
+	}
+
+	if isValidateRequiredGroupsOnUnmarshal() {
+		return ValidateStructRequiredGroups(inputStructPtr)
+	}
+
+	return nil
+}
+
+// MarshalStructToCSV will serialize struct fields defined with strug tags below, to csvPayload string (one line of csv data) using csvDelimiter,
+// the csv payload ordinal position is based on the struct tag pos defined for each struct field,
+// additionally processes struct tag data validation and length / range (if not valid, will set to data type default),
+// this method provides data validation and if fails, will return error (for string if size exceeds max, it will truncate),
+// two or more fields declaring the same pos without matching uniqueid will return error before serialization begins
+//
+// Predefined Struct Tags Usable:
+//		1) `pos:"1"`				// ordinal position of the field in relation to the csv parsed output expected (Zero-Based Index)
+//									   NOTE: if field is mutually exclusive with one or more uniqueId, then pos # should be named the same for all uniqueIds
+//											 if multiple fields are in exclusive condition, and skipBlank or skipZero, always include a blank default field as the last of unique field list
+//										     if value is '-', this means position value is calculated from other fields and set via `setter:"base.Xyz"` during unmarshal csv, there is no marshal to csv for this field
+//		2) `type:"xyz"`				// data type expected:
+//											A = AlphabeticOnly, N = NumericOnly 0-9, AN = AlphaNumeric, ANS = AN + PrintableSymbols,
+//											H = Hex, B64 = Base64, B = true/false, REGEX = Regular Expression, Blank = Any,
+//											EMAIL = Email Address, URL = Absolute Url With Scheme, UUID = UUID v4,
+//											(EMAIL, URL, UUID non-blank value not conforming to format fails validation, blank value fails only if req is true)
+//		3) `regex:"xyz"`			// if Type = REGEX, this struct tag contains the regular expression string,
+//										 	regex express such as [^A-Za-z0-9_-]+
+//										 	method will replace any regex matched string to blank
+//		4) `size:"x..y"`			// data type size rule:
+//											x = Exact size match
+//											x.. = From x and up
+//											..y = From 0 up to y
+//											x..y = From x to y
+//											+%z = Append to x, x.., ..y, x..y; adds additional constraint that the result size must equate to 0 from modulo of z
+//		5) `range:"x..y"`			// data type range value when Type is N, if underlying data type is string, method will convert first before testing
+//		6) `req:"true"`				// indicates data value is required or not, true or false
+//		7) `getter:"Key"`			// if field type is custom struct or enum, specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
+//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
+//									   NOTE: if the method is to receive a parameter value, always in string data type, add '(x)' after the method name, such as 'XYZ(x)' or 'base.XYZ(x)'
+//									   NOTE: for multiple or non-string parameters, list arguments as x or self (field value), field:Name (sibling field value), or lit:'value',
+//									         such as 'base.Format(self, lit:'USD', field:Precision)', each argument is converted to the method's declared parameter type
+//									   NOTE: map field is passed into getter as is when using (x), so that getter may flatten the map into csv value
+// 		8) `setter:"ParseByKey`		// if field type is custom struct or enum, specify the custom method (only 1 lookup parameter value allowed) setter that sets value(s) into the field
+//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
+//									   NOTE: setter method always intake a string parameter value
+//		9) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//		10) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
+//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
+//									   booltrue.csv and boolfalse.csv tags, if defined, are preferred over booltrue and boolfalse for csv, allowing different literals per output format
+// 		11) `uniqueid:"xyz"`		// if two or more struct field is set with the same uniqueid, then only the first encountered field with the same uniqueid will be used in marshal,
+//									   NOTE: if field is mutually exclusive with one or more uniqueId, then pos # should be named the same for all uniqueIds
+//		12) `skipblank:"false"`		// if true, then any fields that is blank string will be excluded from marshal (this only affects fields that are string)
+//		13) `skipzero:"false"`		// if true, then any fields that are 0, 0.00, time.Zero(), false, nil will be excluded from marshal (this only affects fields that are number, bool, time, pointer)
+//		14) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
+//											2006, 06 = year,
+//											01, 1, Jan, January = month,
+//											02, 2, _2 = day (_2 = width two, right justified)
+//											03, 3, 15 = hour (15 = 24 hour format)
+//											04, 4 = minute
+//											05, 5 = second
+//											PM pm = AM PM
+//		15) `outprefix:""`			// for marshal method, if field value is to precede with an output prefix, such as XYZ= (affects marshal queryParams / csv methods only)
+//									   WARNING: if csv is variable elements count, rather than fixed count ordinal, then csv MUST include outprefix for all fields in order to properly identify target struct field
+// 		16) `zeroblank:"false"`		// set true to set blank to data when value is 0, 0.00, or time.IsZero
+//		17) `validate:"==x"`		// if field has to match a specific value or the entire method call will fail, match data format as:
+//									   		==xyz (== refers to equal, for numbers and string match, xyz is data to match, case insensitive)
+//												[if == validate against one or more values, use ||]
+//									   		!=xyz (!= refers to not equal)
+//												[if != validate against one or more values, use &&]
+//											>=xyz >>xyz <<xyz <=xyz (greater equal, greater, less than, less equal; xyz must be int or float)
+//											:=Xyz where Xyz is a parameterless function defined at struct level, that performs validation, returns bool or error where true or nil indicates validation success
+//											==#Xyz !=#Xyz >=#Xyz >>#Xyz <<#Xyz <=#Xyz where Xyz is sibling struct field name, compares against sibling field's current value,
+//												(numbers and time.Time compare by value, others compare as exact string; for unmarshal, evaluated after all fields are set, so sibling field may be declared in any order)
+//									   note: expected source data type for validate to be effective is string, int, float64; if field is blank and req = false, then validate will be skipped
+//		18) `exclusive:"xyz"`		// fields sharing the same exclusive group are mutually exclusive, if more than one field in the group holds non-zero value,
+//									   marshal fails with error naming the group and its conflicting fields (unlike uniqueid, which silently uses the first field)
+//		19) `usestringer:"false"`	// if true and no getter is defined, field value implementing fmt.Stringer is marshaled via its String() method
+//		20) `durationformat:""`		// for time.Duration field, blank renders go duration string such as 1h30m0s, or set ns, us, ms, s, m, h to render numeric value in the given unit
+//		21) `timezone:"UTC"`		// for time.Time field, optional time zone name (or use tz tag alias), such as UTC or America/Chicago, time value is converted to this zone before formatting, invalid zone name fails marshal
+//		22) `subdelim:";"`			// for primitive slice field (such as []int), elements are joined with the sub delimiter into single csv cell, empty slice renders blank cell
+//		23) `csvheader:"Order ID"`	// column name used by GetCSVHeaderFromStruct for the field, defaults to field name if not defined
+//		24) `enum:"StatusEnum"`		// if no getter is defined, integer field value is marshaled as its enum name registered via EnumRegistryRegister, unregistered value fails marshal
+//		25) `b64encode:"false"`		// if true, field value is base64 encoded into csv value, per b64mode tag (std or url), typically used with type b64
+//		26) `onlyif:"Type==card"`	// field is marshaled only if the condition on sibling field(s) is met, same as MarshalStructToJson, unmarshal ignores this tag
+//		27) `postime:"4"`			// for time.Time field, splits value into date column at pos (per timeformat) and time column at postime (per timeformat2),
+//		28) `timeformat2:"150405"`	// time format of the postime column, such as pos:"3" timeformat:"20060102" postime:"4" timeformat2:"150405"
+//		29) `mask:"last4"`			// redaction mode applied only when marshaled via MarshalStruct with MarshalOptions.Redact, see RedactString
+//
+// note: marshal itself only reads struct fields, however getter methods are invoked on inputStructPtr (or its fields) as is,
+// so concurrent marshal of the same struct pointer is not safe if getters mutate state, use MarshalStructToCSVCopy in such case
+func MarshalStructToCSV(inputStructPtr interface{}, csvDelimiter string) (csvPayload string, err error) {
+	return marshalStructToCSV(inputStructPtr, csvDelimiter, false, nil, nil)
+}
+
+// MarshalStructToCSVCopy marshals struct pointer to csv payload, same as MarshalStructToCSV,
+// except marshal operates on a shallow copy of the struct, so getter methods mutating struct level state affect the copy only,
+// allowing concurrent marshal of the same struct pointer from multiple goroutines,
+// note: being shallow copy, state reached via pointer, slice or map fields is still shared with inputStructPtr
+func MarshalStructToCSVCopy(inputStructPtr interface{}, csvDelimiter string) (csvPayload string, err error) {
+	if inputStructPtr == nil {
+		return "", fmt.Errorf("InputStructPtr is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr || s.IsNil() {
+		return "", fmt.Errorf("InputStructPtr Must Be Pointer")
+	}
+
+	if s.Elem().Kind() != reflect.Struct {
+		return "", fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	c := reflect.New(s.Elem().Type())
+	c.Elem().Set(s.Elem())
+
+	return marshalStructToCSV(c.Interface(), csvDelimiter, false, nil, nil)
+}
+
+// MarshalStructToCSVAggregate marshals struct pointer to csv payload, same as MarshalStructToCSV,
+// except validation failures do not stop the marshal at the first failed field,
+// instead every failed field (with its rule and offending value) is collected and returned as ValidationErrors,
+// when any validation failure occurs, blank csv payload is returned
+func MarshalStructToCSVAggregate(inputStructPtr interface{}, csvDelimiter string) (csvPayload string, err error) {
+	return marshalStructToCSV(inputStructPtr, csvDelimiter, true, nil, nil)
+}
+
+// MarshalStructToCSVWithWarnings marshals struct pointer to csv payload, same as MarshalStructToCSV,
+// in addition, non-fatal lossy conditions encountered are returned as warnings, such as:
+//		1) value truncated to size maximum
+//		2) characters removed from value not conforming to type tag (a, n, an, ans, regex, h, b64)
+//		3) unknown enum value marshaled as blank (or default value)
+//		4) getter method not found, field value marshaled as is
+func MarshalStructToCSVWithWarnings(inputStructPtr interface{}, csvDelimiter string) (csvPayload string, warnings []string, err error) {
+	warnings = []string{}
+	csvPayload, err = marshalStructToCSV(inputStructPtr, csvDelimiter, false, &warnings, nil)
+	return csvPayload, warnings, err
+}
+
+// MarshalStructToSparseCSV marshals struct pointer's fields same as MarshalStructToCSV, except only positions whose field is set are returned,
+// as map of pos to csv value, for transmitting sparse records (changed columns only), a field is set when its value is not blank or zero,
+// and not equal to its def tag value (same as IsStructFieldSet, evaluated per field), if no field is set, empty map is returned,
+// time field defining postime that is set returns both its date column at pos and time column at postime
+func MarshalStructToSparseCSV(inputStructPtr interface{}) (map[int]string, error) {
+	csvList, columns, err := marshalStructToCSVList(inputStructPtr, false, nil, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sparse := make(map[int]string)
+
+	for _, col := range columns {
+		if col.Pos >= len(csvList) || csvList[col.Pos] == "{?}" {
+			continue
+		}
+
+		if o := col.Field.Value; o.IsValid() && isStructFieldValueSet(col.Field.Field, o) {
+			sparse[col.Pos] = csvList[col.Pos]
+
+			if posTime, ok := getStructFieldPosTime(col.Field.Field, len(csvList)); ok && csvList[posTime] != "{?}" {
+				// time field split into date and time columns, both columns are returned
+				sparse[posTime] = csvList[posTime]
+			}
+		}
+	}
+
+	return sparse, nil
+}
+
+// marshalStructToCSV marshals struct pointer to csv payload, if aggregate is true, validation failures are collected rather than fail fast,
+// if warnings is not nil, non-fatal lossy conditions encountered are appended to it, opts if not nil applies marshal defaults
+func marshalStructToCSV(inputStructPtr interface{}, csvDelimiter string, aggregate bool, warnings *[]string, opts *MarshalOptions) (csvPayload string, err error) {
+	csvList, _, err := marshalStructToCSVList(inputStructPtr, aggregate, warnings, opts)
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range csvList {
+		if v != "{?}" {
+			if LenTrim(csvPayload) > 0 {
+				csvPayload += csvDelimiter
+			}
+
+			csvPayload += v
+		}
+	}
+
+	return csvPayload, nil
+}
+
+// marshalStructToCSVList marshals struct pointer's fields into csv values ordered by pos, see marshalStructToCSV,
+// positions not set by any field hold {?}, columns is the csv column layout of the struct fields,
+// if the struct is not set while having required fields, nil csvList is returned without error
+func marshalStructToCSVList(inputStructPtr interface{}, aggregate bool, warnings *[]string, opts *MarshalOptions) (csvList []string, columns []csvStructColumn, err error) {
+	if inputStructPtr == nil {
+		return nil, nil, fmt.Errorf("InputStructPtr is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return nil, nil, fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	fields := getStructFieldValues(s, "pos", false)
+
+	if errs := validateCSVStructPosDuplicates(fields); len(errs) > 0 {
+		return nil, nil, errs[0]
+	}
+
+	if err := validateStructExclusiveGroups(fields); err != nil {
+		return nil, nil, err
+	}
+
+	if err := validateStructTimeZones(fields); err != nil {
+		return nil, nil, err
+	}
+
+	if !IsStructFieldSet(inputStructPtr) && StructNonDefaultRequiredFieldsCount(inputStructPtr) > 0 {
+		return nil, nil, nil
+	}
+
+	csvLen, columns := getCSVStructColumns(fields)
+	csvList = make([]string, csvLen)
+
+	for i := 0; i < csvLen; i++ {
+		csvList[i] = "{?}"	// indicates value not set, to be excluded
+	}
+
+	unique := make(uniqueFieldClaims)
+	var validationErrs ValidationErrors
+
+	warn := func(format string, a ...interface{}) {
+		if warnings != nil {
+			*warnings = append(*warnings, fmt.Sprintf(format, a...))
+		}
+	}
+
+	for _, col := range columns {
+		field := col.Field.Field
+		tagPos := col.Pos
+
+		if o := col.Field.Value; o.IsValid() && o.CanSet() {
+			if ok, err := isStructFieldOnlyIfMet(s, field); err != nil {
+				return nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+			} else if !ok {
+				continue
+			}
+
+			if !unique.claim(field) {
+				continue
+			}
+
+			tags := parseStructFieldValidateTags(field)
+			tagType := tags.Type
+			tagRegEx := tags.RegEx
+			sizeMax := tags.SizeMax
+			tagReq := tags.Req
+			fieldTags := col.Field.Tags
+
+			if fieldTags.Err != nil {
+				return nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, fieldTags.Err)
+			}
+
+			// get csv value from current struct field
+			boolTrue, boolFalse, timeFormat, outPrefix := fieldTags.BoolTrueCSV, fieldTags.BoolFalseCSV, fieldTags.TimeFormat, fieldTags.OutPrefix
+			skipBlank, skipZero, zeroBlank := fieldTags.SkipBlank, fieldTags.SkipZero, fieldTags.ZeroBlank
+
+			opts.apply(field, &boolTrue, &boolFalse, &timeFormat, &skipBlank, &skipZero)
+
+			// cache old value prior to getter invoke
+			oldVal := o
+			hasGetter := false
+			useStringer := fieldTags.UseStringer
+
+			if tagGetter := fieldTags.Getter; len(tagGetter) > 0 {
+				hasGetter = true
+				useStringer = false
+
+				if warnings != nil && !isStructFieldGetterFound(s, o, tagGetter) {
+					warn("Struct Field %s Getter '%s' Not Found, Value Marshaled As Is", field.Name, tagGetter)
+				}
+
+				var err error
+
+				if o, err = invokeStructFieldGetter(s, o, tagGetter, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank); err != nil {
+					return nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+				}
+			} else if ev, err := marshalStructFieldEnum(field, o, skipZero); err != nil {
+				return nil, nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+			} else {
+				o = ev
+			}
+
+			var fv string
+			var skip bool
+			var e error
+
+			loc, _ := getStructFieldTimeZone(field)
+
+			if subDelim := field.Tag.Get("subdelim"); len(subDelim) > 0 && !hasGetter && o.Kind() == reflect.Slice {
+				// primitive slice is joined into single csv cell
+				fv, e = reflectSliceToDelimitedString(o, subDelim, boolTrue, boolFalse, timeFormat, loc)
+			} else {
+				fv, skip, e = ReflectValueToString(reflectTimeInLocation(o, loc), boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank, useStringer)
+			}
+
+			if e != nil {
+				unique.release(field)
+
+				return nil, nil, e
+			}
+
+			if skip {
+				unique.release(field)
+				continue
+			}
+
+			defVal := getStructFieldDefaultValue(field)
+
+			if oldVal.Kind() == reflect.Int && oldVal.Int() == 0 && strings.ToLower(fv) == "unknown" {
+				// unknown enum value will be serialized as blank
+				fv = ""
+
+				if len(defVal) > 0 {
+					fv = defVal
+					warn("Struct Field %s Unknown Enum Value Marshaled As Default '%s'", field.Name, defVal)
+				} else {
+					warn("Struct Field %s Unknown Enum Value Marshaled As Blank", field.Name)
+
+					if unique.release(field) {
+						continue
+					}
+				}
+			}
+
+			if fieldTags.B64Encode && len(fv) > 0 {
+				fv = getStructFieldBase64Encoding(field).EncodeToString([]byte(fv))
+			}
+
+			// validate output csv value
+			if oldVal.Kind() != reflect.Slice {
+				origFv := fv
+
+				switch tagType {
+				case "a":
+					fv, _ = ExtractAlpha(fv)
+				case "n":
+					fv, _ = ExtractNumeric(fv)
+				case "an":
+					fv, _ = ExtractAlphaNumeric(fv)
+				case "ans":
+					if !hasGetter {
+						fv, _ = ExtractAlphaNumericPrintableSymbols(fv)
+					}
+				case "b":
+					if len(boolTrue) == 0 && len(boolFalse) == 0 {
+						if b, _ := ParseBoolExtended(fv); b {
+							fv = "true"
+						} else {
+							fv = "false"
+						}
+					} else {
+						if Trim(boolTrue) == Trim(boolFalse) {
+							if fv == "false" {
+								fv = ""
+								csvList[tagPos] = fv
+								continue
+							}
+						}
+					}
+				case "regex":
+					fv, _ = ExtractByRegex(fv, tagRegEx)
+				case "h":
+					fv, _ = ExtractHex(fv)
+				case "b64":
+					fv, _ = ExtractAlphaNumericPrintableSymbols(fv)
+				}
+
+				if fv != origFv && isStructFieldSizeType(tagType) {
+					warn("Struct Field %s Characters Not Conforming To Type '%s' Removed From Value '%s'", field.Name, tagType, origFv)
+				}
+
+				if boolFalse == " " && origFv == "false" && len(outPrefix) > 0 {
+					// just in case fv is not defined type type b
+					fv = ""
+					csvList[tagPos] = fv
+					continue
+				}
+
+				if len(fv) == 0 && len(defVal) > 0 {
+					fv = defVal
+				}
+
+				if isStructFieldSizeType(tagType) && sizeMax > 0 && len(fv) > sizeMax {
+					warn("Struct Field %s Value Truncated From %d To %d Characters", field.Name, len(fv), sizeMax)
+					fv = Left(fv, sizeMax)
+				}
+
+				if fe := validateStructFieldFormat(field, tags, fv); fe != nil {
+					if !aggregate {
+						return nil, nil, fe
+					}
+
+					validationErrs = append(validationErrs, *fe)
+					continue
+				}
+
+				if fe := validateStructFieldRules(field, tags, fv); fe != nil {
+					if !aggregate {
+						return nil, nil, fe
+					}
+
+					validationErrs = append(validationErrs, *fe)
+					continue
+				}
+			}
+
+			// validate if applicable
+			if fe := validateStructFieldValidateTag(s, field, tagReq, fv); fe != nil {
+				if !aggregate {
+					return nil, nil, fe
+				}
+
+				validationErrs = append(validationErrs, *fe)
+				continue
+			}
+
+			// store fv into sorted slice
+			if skipBlank && LenTrim(fv) == 0 {
+				csvList[tagPos] = ""
+			} else if skipZero && fv == "0" {
+				csvList[tagPos] = ""
+			} else {
+				csvList[tagPos] = outPrefix + opts.redact(field, fv)
+			}
+
+			if posTime, ok := getStructFieldPosTime(field, len(csvList)); ok {
+				// time field split into date and time columns, time column is formatted per timeformat2
+				csvList[posTime] = ""
+
+				if len(fv) > 0 {
+					csvList[posTime], _, _ = ReflectValueToString(reflectTimeInLocation(o, loc), "", "", false, false, Trim(field.Tag.Get("timeformat2")), zeroBlank)
+				}
+			}
+		}
+	}
+
+	if len(validationErrs) > 0 {
+		return nil, nil, validationErrs
+	}
+
+	return csvList, columns, nil
+}
+
+// FixedWidthRecord is optionally implemented by struct marshaled via MarshalStructToFixedWidth or UnmarshalFixedWidthToStruct,
+// FixedWidthRecordLength returns the expected total record length, the record is validated against it to catch layout drift
+type FixedWidthRecord interface {
+	FixedWidthRecordLength() int
+}
+
+// MarshalStructToFixedWidth marshals a struct pointer's fields to fixed width record, where each positional field is padded to its exact width,
+// fields are evaluated the same as MarshalStructToCSV (such as getter, def, timeformat, validate), and written in pos order without delimiter,
+// every field with numeric pos must declare width, positions not mapped by any field are excluded,
+// value longer than width fails marshal, unless truncate is true,
+// struct with no field set (while declaring required fields) fails marshal rather than yielding blank record,
+// if struct implements FixedWidthRecord, the record length must equal FixedWidthRecordLength,
+// postime tag (date and time columns split) is not supported in fixed width record, field declaring postime fails marshal
+//
+// Additional Struct Tags Usable:
+//		1) `width:"10"`				// exact width of the field in record, required for every field with numeric pos
+//		2) `align:"left"`			// left (default) pads value on the right, right pads value on the left
+//		3) `padchar:" "`			// pad character, defaults to space, such as 0 for zero padded numbers (sign is written ahead of zero padding, such as -0005)
+//		4) `truncate:"false"`		// if true, value longer than width is truncated to width, rather than failing marshal
+func MarshalStructToFixedWidth(inputStructPtr interface{}) (string, error) {
+	csvList, columns, err := marshalStructToCSVList(inputStructPtr, false, nil, nil)
+
+	if err != nil {
+		return "", err
+	} else if csvList == nil {
+		return "", fmt.Errorf("MarshalStructToFixedWidth Yielded Blank Record, Struct Fields Not Set")
+	}
+
+	posColumns := make(map[int]csvStructColumn)
+
+	for _, c := range columns {
+		if _, ok := posColumns[c.Pos]; !ok {
+			posColumns[c.Pos] = c
+		}
+	}
+
+	var sb strings.Builder
+
+	for i, v := range csvList {
+		c, ok := posColumns[i]
+
+		if !ok {
+			continue
+		}
+
+		width, alignRight, padChar, truncate, err := getStructFieldFixedWidth(c.Field.Field)
+
+		if err != nil {
+			return "", err
+		}
+
+		if v == "{?}" {
+			v = ""
+		}
+
+		if len(v) > width {
+			if !truncate {
+				return "", fmt.Errorf("Field %s Value '%s' Exceeds width %d", c.Field.Field.Name, v, width)
+			}
+
+			v = Left(v, width)
+		}
+
+		pad := strings.Repeat(padChar, width-len(v))
+
+		if alignRight {
+			if padChar == "0" && len(v) > 0 && (v[0] == '-' || v[0] == '+') {
+				// sign precedes zero padding, such as -0005
+				sb.WriteString(v[:1] + pad + v[1:])
+			} else {
+				sb.WriteString(pad + v)
+			}
+		} else {
+			sb.WriteString(v + pad)
+		}
+	}
+
+	if err := validateFixedWidthRecordLength(inputStructPtr, sb.Len()); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// UnmarshalFixedWidthToStruct parses fixed width record into struct pointer fields, see MarshalStructToFixedWidth,
+// each positional field's value is sliced from record by its width in pos order, with pad characters trimmed per align,
+// then unmarshaled the same as UnmarshalCSVToStruct (such as setter, def, timeformat, validate),
+// record shorter than the total width of positional fields fails unmarshal, field declaring postime fails unmarshal (not supported),
+// if struct implements FixedWidthRecord, the record length must equal FixedWidthRecordLength
+func UnmarshalFixedWidthToStruct(inputStructPtr interface{}, record string) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("InputStructPtr is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	if err := validateFixedWidthRecordLength(inputStructPtr, len(record)); err != nil {
+		return err
+	}
+
+	csvLen, columns := getCSVStructColumns(getStructFieldValues(s, "pos", false))
+	posColumns := make(map[int]csvStructColumn)
+
+	for _, c := range columns {
+		if _, ok := posColumns[c.Pos]; !ok {
+			posColumns[c.Pos] = c
+		}
+	}
+
+	csvElements := make([]string, csvLen)
+	offset := 0
+
+	for i := 0; i < csvLen; i++ {
+		c, ok := posColumns[i]
+
+		if !ok {
+			continue
+		}
+
+		width, alignRight, padChar, _, err := getStructFieldFixedWidth(c.Field.Field)
+
+		if err != nil {
+			return err
+		}
+
+		if offset+width > len(record) {
+			return fmt.Errorf("Record Length %d Too Short For Field %s At Offset %d With width %d", len(record), c.Field.Field.Name, offset, width)
+		}
+
+		v := record[offset : offset+width]
+		offset += width
+
+		if alignRight {
+			csvElements[i] = trimFixedWidthLeftPad(v, padChar)
+		} else {
+			csvElements[i] = strings.TrimRight(v, padChar)
+		}
+	}
+
+	return unmarshalCSVElementsToStruct(inputStructPtr, csvElements, nil, false, false, nil)
+}
+
+// trimFixedWidthLeftPad trims left pad characters of right aligned fixed width value v,
+// for zero padding, leading sign is kept ahead of the digits, and at least one digit remains, such as 00000 to 0, and -0005 to -5
+func trimFixedWidthLeftPad(v string, padChar string) string {
+	if padChar != "0" {
+		return strings.TrimLeft(v, padChar)
+	}
+
+	sign := ""
+
+	if len(v) > 0 && (v[0] == '-' || v[0] == '+') {
+		sign = v[:1]
+		v = v[1:]
+	}
+
+	trimmed := strings.TrimLeft(v, padChar)
+
+	if len(trimmed) == 0 && len(v) > 0 {
+		trimmed = "0"
+	} else if len(trimmed) > 0 && trimmed[0] == '.' {
+		trimmed = "0" + trimmed
+	}
+
+	return sign + trimmed
+}
+
+// getStructFieldFixedWidth returns the fixed width layout of field as defined by width, align, padchar and truncate tags
+func getStructFieldFixedWidth(field reflect.StructField) (width int, alignRight bool, padChar string, truncate bool, err error) {
+	tagWidth := Trim(field.Tag.Get("width"))
+
+	if width, _ = ParseInt32(tagWidth); width <= 0 {
+		return 0, false, "", false, fmt.Errorf("Field %s Requires Positive Numeric width, '%s' Not Valid", field.Name, tagWidth)
+	}
+
+	if len(Trim(field.Tag.Get("postime"))) > 0 {
+		return 0, false, "", false, fmt.Errorf("Field %s Declares postime, Not Supported In Fixed Width Record", field.Name)
+	}
+
+	switch align := strings.ToLower(Trim(field.Tag.Get("align"))); align {
+	case "", "left":
+	case "right":
+		alignRight = true
+	default:
+		return 0, false, "", false, fmt.Errorf("Field %s Declares align '%s', Expects left or right", field.Name, align)
+	}
+
+	if padChar = field.Tag.Get("padchar"); len(padChar) == 0 {
+		padChar = " "
+	} else if len(padChar) > 1 {
+		return 0, false, "", false, fmt.Errorf("Field %s Declares padchar '%s', Expects Single Character", field.Name, padChar)
+	}
+
+	if truncate, err = getStructFieldBoolTagValue(field, "truncate"); err != nil {
+		return 0, false, "", false, err
+	}
+
+	return width, alignRight, padChar, truncate, nil
+}
+
+// validateFixedWidthRecordLength returns error if inputStructPtr implements FixedWidthRecord, and recordLen is not its expected record length
+func validateFixedWidthRecordLength(inputStructPtr interface{}, recordLen int) error {
+	if r, ok := inputStructPtr.(FixedWidthRecord); ok {
+		if expected := r.FixedWidthRecordLength(); expected != recordLen {
+			return fmt.Errorf("Fixed Width Record Length %d Does Not Match Expected Length %d", recordLen, expected)
+		}
+	}
+
+	return nil
+}
+
+// csvStructColumn describes a struct field mapped to csv column position via its pos tag
+type csvStructColumn struct {
+	Pos   int
+	Field structFieldValue
+}
+
+// getStructFieldPosTime returns the time column position per struct tag `postime:""` of time field, ok is false if not defined,
+// or not within 0 to csvLen - 1
+func getStructFieldPosTime(field reflect.StructField, csvLen int) (posTime int, ok bool) {
+	if posTime, ok = ParseInt32(field.Tag.Get("postime")); !ok || posTime < 0 || posTime >= csvLen {
+		return 0, false
+	}
+
+	return posTime, true
+}
+
+// getCSVStructColumns returns the csv column count (being the struct field count, plus one for each field defining postime),
+// and the fields whose pos tag is within 0 to column count - 1, in struct field order,
+// fields with pos:"-", or non-numeric or out of range pos are excluded,
+// this is the shared column layout used by MarshalStructToCSV and GetCSVHeaderFromStruct
+func getCSVStructColumns(fields []structFieldValue) (csvLen int, columns []csvStructColumn) {
+	csvLen = len(fields)
+
+	for _, sf := range fields {
+		if len(Trim(sf.Field.Tag.Get("postime"))) > 0 {
+			// time field split into date and time columns occupies an additional column
+			csvLen++
+		}
+	}
+
+	for _, sf := range fields {
+		if tagPos, ok := ParseInt32(sf.Field.Tag.Get("pos")); ok && tagPos >= 0 && tagPos < csvLen {
+			columns = append(columns, csvStructColumn{Pos: tagPos, Field: sf})
+		}
+	}
+
+	return csvLen, columns
+}
+
+// GetCSVHeaderFromStruct returns csv header row for inputStructPtr, with columns ordered by pos tag, the same as MarshalStructToCSV,
+// column name is taken from `csvheader:"Order ID"` struct tag, or the field name if csvheader tag is not defined,
+// for fields sharing the same uniqueid, only the first field is included, fields with pos:"-" are skipped,
+// and positions not mapped by any field are excluded (same as MarshalStructToCSV {?} exclusion)
+//
+// note: MarshalStructToCSV also excludes column of field skipped due to skipblank or skipzero, in such case header may not align with data
+func GetCSVHeaderFromStruct(inputStructPtr interface{}, csvDelimiter string) (string, error) {
+	if inputStructPtr == nil {
+		return "", fmt.Errorf("InputStructPtr is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return "", fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return "", fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	fields := getStructFieldValues(s, "pos", false)
+
+	if errs := validateCSVStructPosDuplicates(fields); len(errs) > 0 {
+		return "", errs[0]
+	}
+
+	csvLen, columns := getCSVStructColumns(fields)
+	headerList := make([]string, csvLen)
+
+	for i := 0; i < csvLen; i++ {
+		headerList[i] = "{?}"	// indicates column not mapped, to be excluded
+	}
+
+	unique := make(uniqueFieldClaims)
+
+	for _, col := range columns {
+		if !unique.claim(col.Field.Field) {
+			continue
+		}
+
+		if name := Trim(col.Field.Field.Tag.Get("csvheader")); len(name) > 0 {
+			headerList[col.Pos] = name
+		} else {
+			headerList[col.Pos] = col.Field.Field.Name
+		}
+
+		if posTime, ok := getStructFieldPosTime(col.Field.Field, csvLen); ok {
+			headerList[posTime] = headerList[col.Pos] + " Time"
+		}
+	}
+
+	header := ""
+
+	for _, v := range headerList {
+		if v != "{?}" {
+			if LenTrim(header) > 0 {
+				header += csvDelimiter
+			}
+
+			header += v
+		}
+	}
+
+	return header, nil
+}
+
+// StructToCSVValues returns the field values of inputStructPtr ordered by pos tag, the same column order as MarshalStructToCSV,
+// with each value in its native type (such as string, int, float64, bool, time.Time), for use with spreadsheet writers,
+// getter tag is honored (getter result is returned as is), pointer fields are dereferenced (nil pointer yields nil),
+// and database/sql null types yield their underlying value (or nil if not valid),
+// for fields sharing the same uniqueid, the first field holding non-zero value is used,
+// time field defining postime yields time.Time at pos, and its time string formatted per timeformat2 at postime (nil if time is zero),
+// fields with pos:"-" are skipped, and positions not mapped by any field are excluded
+func StructToCSVValues(inputStructPtr interface{}) ([]interface{}, error) {
+	if inputStructPtr == nil {
+		return nil, fmt.Errorf("InputStructPtr is Required")
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("InputStructPtr Must Be Struct")
+	}
+
+	fields := getStructFieldValues(s, "pos", false)
+
+	if errs := validateCSVStructPosDuplicates(fields); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	csvLen, columns := getCSVStructColumns(fields)
+	valueList := make([]interface{}, csvLen)
+	filled := make([]bool, csvLen)
+	nonZero := make([]bool, csvLen)
+
+	for _, col := range columns {
+		field := col.Field.Field
+		o := col.Field.Value
+
+		if !o.IsValid() || !o.CanSet() {
+			continue
+		}
+
+		if filled[col.Pos] && (nonZero[col.Pos] || o.IsZero()) {
+			// position already claimed by prior field of the same uniqueid
+			continue
+		}
+
+		if tags := col.Field.Tags; tags.Err != nil {
+			return nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, tags.Err)
+		} else if tagGetter := tags.Getter; len(tagGetter) > 0 {
+			var err error
+
+			if o, err = invokeStructFieldGetter(s, o, tagGetter, tags.BoolTrueCSV, tags.BoolFalseCSV, tags.SkipBlank, tags.SkipZero, tags.TimeFormat, tags.ZeroBlank); err != nil {
+				return nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+			}
+		} else if ev, err := marshalStructFieldEnum(field, o, false); err != nil {
+			return nil, fmt.Errorf("Struct Field %s Failed: %s", field.Name, err)
+		} else {
+			o = ev
+		}
+
+		valueList[col.Pos] = reflectNativeValue(o)
+		filled[col.Pos] = true
+		nonZero[col.Pos] = o.IsValid() && !o.IsZero()
+
+		if posTime, ok := getStructFieldPosTime(field, csvLen); ok {
+			// time field split into date and time columns, time column holds time value formatted per timeformat2
+			valueList[posTime] = nil
+			filled[posTime] = true
+
+			if t, ok := valueList[col.Pos].(time.Time); ok && !t.IsZero() {
+				loc, _ := getStructFieldTimeZone(field)
+				valueList[posTime], _, _ = ReflectValueToString(reflectTimeInLocation(reflect.ValueOf(t), loc), "", "", false, false, Trim(field.Tag.Get("timeformat2")), false)
+			}
+		}
+	}
+
+	var values []interface{}
+
+	for i, v := range valueList {
+		if filled[i] {
+			values = append(values, v)
+		}
+	}
+
+	return values, nil
+}
+
+// reflectNativeValue returns the underlying native value of o, pointers are dereferenced (nil pointer returns nil),
+// and driver.Valuer types (such as sql.NullString) return their driver value (nil if not valid)
+func reflectNativeValue(o reflect.Value) interface{} {
+	for o.IsValid() && o.Kind() == reflect.Ptr {
+		if o.IsNil() {
+			return nil
+		}
+
+		o = o.Elem()
+	}
+
+	if !o.IsValid() || !o.CanInterface() {
+		return nil
+	}
+
+	if valuer, ok := o.Interface().(driver.Valuer); ok {
+		if v, err := valuer.Value(); err == nil {
+			return v
+		}
+	}
+
+	return o.Interface()
+}
+
+// reflectSliceToDelimitedString joins elements of slice o into one string separated by subDelim,
+// each element is stringified via ReflectValueToString honoring boolTrue, boolFalse, timeFormat and loc,
+// nil or empty slice returns blank
+func reflectSliceToDelimitedString(o reflect.Value, subDelim string, boolTrue string, boolFalse string, timeFormat string, loc *time.Location) (string, error) {
+	if o.Kind() != reflect.Slice {
+		return "", fmt.Errorf("%s Is Not Slice", o.Type())
+	}
+
+	var elems []string
+
+	for i := 0; i < o.Len(); i++ {
+		if v, _, err := ReflectValueToString(reflectTimeInLocation(o.Index(i), loc), boolTrue, boolFalse, false, false, timeFormat, false); err != nil {
+			return "", err
+		} else {
+			elems = append(elems, v)
+		}
+	}
+
+	return strings.Join(elems, subDelim), nil
+}
+
+// newCSVFieldUnmarshalError returns FieldUnmarshalError for struct s field failing to convert csvValue at ordinal position tagPosBuf
+func newCSVFieldUnmarshalError(s reflect.Value, field reflect.StructField, tagPosBuf string, csvValue string, err error) *FieldUnmarshalError {
+	pos := -1
+
+	if p, ok := ParseInt32(tagPosBuf); ok {
+		pos = p
+	}
+
+	return &FieldUnmarshalError{StructType: s.Type().String(), FieldName: field.Name, Pos: pos, RawValue: csvValue, Err: err}
+}
+
+// reflectDelimitedStringToSlice splits v by subDelim, and sets the split elements into slice o as new slice,
+// each element is converted via ReflectStringToField honoring timeFormat and loc,
+// blank v sets o to nil slice
+func reflectDelimitedStringToSlice(o reflect.Value, v string, subDelim string, timeFormat string, loc *time.Location) error {
+	if o.Kind() != reflect.Slice {
+		return fmt.Errorf("%s Is Not Slice", o.Type())
+	}
+
+	if len(v) == 0 {
+		o.Set(reflect.Zero(o.Type()))
+		return nil
+	}
+
+	parts := strings.Split(v, subDelim)
+	sv := reflect.MakeSlice(o.Type(), len(parts), len(parts))
+
+	for i, p := range parts {
+		if err := ReflectStringToField(sv.Index(i), p, timeFormat, loc); err != nil {
+			return err
+		}
+	}
+
+	o.Set(sv)
+	return nil
+}
+
+// FieldError describes a struct field that failed validation against its validation related struct tags
+type FieldError struct {
+	Field   string // struct field name
+	Rule    string // rule that failed: req, size, range, type, regex, or validate
+	Value   string // actual field value evaluated, in string form
+	Message string // descriptive error message
+}
+
+// Error returns the descriptive error message of the field error
+func (e FieldError) Error() string {
+	return e.Message
+}
+
+// FieldUnmarshalError describes a struct field whose source value failed to convert into the field type during unmarshal,
+// such as UnmarshalJsonToStruct (JsonKey is set) or UnmarshalCSVToStruct (Pos is set), use errors.As to extract from returned error,
+// including errors returned by line based unmarshal such as UnmarshalCSVToSlice, UnmarshalNDJSONToSlice and StreamCSVToStructs (Line is set)
+type FieldUnmarshalError struct {
+	StructType string // struct type name (of the nested struct if field is nested)
+	FieldName  string // struct field name
+	JsonKey    string // json key (dot delimited path if nested), blank for csv
+	Pos        int    // csv ordinal position, -1 for json, or if csv field has no position
+	Line       int    // 1-based line number for line based unmarshal, 0 otherwise
+	RawValue   string // source value that failed to convert
+	Err        error  // underlying conversion error
+}
+
+// Error returns the descriptive error message of the field unmarshal error
+func (e *FieldUnmarshalError) Error() string {
+	if len(e.JsonKey) > 0 {
+		return fmt.Sprintf("Unmarshal Json Element %s Into %s.%s Failed for Value '%s': %s", e.JsonKey, e.StructType, e.FieldName, e.RawValue, e.Err)
+	}
+
+	return fmt.Sprintf("Unmarshal CSV Pos %d Into %s.%s Failed for Value '%s': %s", e.Pos, e.StructType, e.FieldName, e.RawValue, e.Err)
+}
+
+// Unwrap returns the underlying conversion error
+func (e *FieldUnmarshalError) Unwrap() error {
+	return e.Err
+}
+
+// setFieldUnmarshalErrorLine sets line into FieldUnmarshalError contained in err if any, and returns err as is
+func setFieldUnmarshalErrorLine(err error, line int) error {
+	var fe *FieldUnmarshalError
+
+	if errors.As(err, &fe) {
+		fe.Line = line
+	}
+
+	return err
+}
+
+// ValidationErrors contains every struct field validation failure, as collected by aggregate mode validation,
+// such as MarshalStructToCSVAggregate and UnmarshalCSVToStructAggregate
+type ValidationErrors []FieldError
+
+// Error returns all field errors joined into one string
+func (e ValidationErrors) Error() string {
+	var msgs []string
+
+	for _, v := range e {
+		msgs = append(msgs, v.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Is returns true if any of the individual field errors matches target, allowing errors.Is to inspect each field error
+func (e ValidationErrors) Is(target error) bool {
+	for _, v := range e {
+		if errors.Is(v, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first individual field error matching target, and if so, sets target to it and returns true,
+// allowing errors.As to extract FieldError from ValidationErrors
+func (e ValidationErrors) As(target interface{}) bool {
+	for _, v := range e {
+		if errors.As(v, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// structFieldValidateTags contains the parsed validation related struct tags of a struct field
+type structFieldValidateTags struct {
+	Type     string // a, n, an, ans, b, b64, regex, h, email, url, uuid; blank if not defined or invalid
+	RegEx    string // regex pattern, only when type is regex
+	SizeMin  int
+	SizeMax  int
+	Modulo   int // value length must be in blocks of modulo characters
+	RangeMin int
+	RangeMax int
+	Req      string // true, false, or blank if not defined
+}
+
+// parseStructFieldValidateTags parses type, regex, size, range and req struct tags of field,
+// parsed result is cached by the field's struct tag, see ClearStructTagCache
+func parseStructFieldValidateTags(field reflect.StructField) structFieldValidateTags {
+	structTagCacheMu.RLock()
+	tags, ok := structValidateTagsCache[field.Tag]
+	structTagCacheMu.RUnlock()
+
+	if ok {
+		return tags
+	}
+
+	tags = parseStructFieldValidateTagsUncached(field)
+
+	structTagCacheMu.Lock()
+	structValidateTagsCache[field.Tag] = tags
+	structTagCacheMu.Unlock()
+
+	return tags
+}
+
+// parseStructFieldValidateTagsUncached parses type, regex, size, range and req struct tags of field without consulting cache
+func parseStructFieldValidateTagsUncached(field reflect.StructField) structFieldValidateTags {
+	tags := structFieldValidateTags{}
+
+	tags.Type = Trim(strings.ToLower(field.Tag.Get("type")))
+	switch tags.Type {
+	case "a":
+		fallthrough
+	case "n":
+		fallthrough
+	case "an":
+		fallthrough
+	case "ans":
+		fallthrough
+	case "b":
+		fallthrough
+	case "b64":
+		fallthrough
+	case "regex":
+		fallthrough
+	case "h":
+		fallthrough
+	case "email":
+		fallthrough
+	case "url":
+		fallthrough
+	case "uuid":
+		// valid type
+	default:
+		tags.Type = ""
+	}
+
+	if tags.Type == "regex" {
+		if tags.RegEx = Trim(field.Tag.Get("regex")); LenTrim(tags.RegEx) == 0 {
+			tags.Type = ""
+		}
+	}
+
+	tagSize := Trim(strings.ToLower(field.Tag.Get("size")))
+	arModulo := strings.Split(tagSize, "+%")
+	if len(arModulo) == 2 {
+		tagSize = arModulo[0]
+		if tags.Modulo, _ = ParseInt32(arModulo[1]); tags.Modulo < 0 {
+			tags.Modulo = 0
+		}
+	}
+	arSize := strings.Split(tagSize, "..")
+	if len(arSize) == 2 {
+		tags.SizeMin, _ = ParseInt32(arSize[0])
+		tags.SizeMax, _ = ParseInt32(arSize[1])
+	} else {
+		tags.SizeMin, _ = ParseInt32(tagSize)
+		tags.SizeMax = tags.SizeMin
 	}
 
-	if LenTrim(csvPayload) == 0 {
-		return fmt.Errorf("CSV Payload is Required")
+	tagRange := Trim(strings.ToLower(field.Tag.Get("range")))
+	arRange := strings.Split(tagRange, "..")
+	if len(arRange) == 2 {
+		tags.RangeMin, _ = ParseInt32(arRange[0])
+		tags.RangeMax, _ = ParseInt32(arRange[1])
+	} else {
+		tags.RangeMin, _ = ParseInt32(tagRange)
+		tags.RangeMax = tags.RangeMin
 	}
 
-	if len(csvDelimiter) == 0 && customDelimiterParserFunc == nil {
-		return fmt.Errorf("CSV Delimiter or Custom Delimiter Func is Required")
+	tags.Req = Trim(strings.ToLower(field.Tag.Get("req")))
+	if tags.Req != "true" && tags.Req != "false" {
+		tags.Req = ""
 	}
 
-	s := reflect.ValueOf(inputStructPtr)
+	return tags
+}
 
-	if s.Kind() != reflect.Ptr {
-		return fmt.Errorf("InputStructPtr Must Be Pointer")
-	} else {
-		s = s.Elem()
+// isStructFieldSizeType returns true if the given type tag value is subject to size validation
+func isStructFieldSizeType(tagType string) bool {
+	return tagType == "a" || tagType == "an" || tagType == "ans" || tagType == "n" || tagType == "regex" || tagType == "h" || tagType == "b64"
+}
+
+// structFieldEmailRegex is a practical subset of rfc 5322 email address format
+var structFieldEmailRegex = regexp.MustCompile("^[A-Za-z0-9.!#$%&'*+/=?^_`{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?(?:\\.[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?)+$")
+
+// structFieldUUIDv4Regex is the uuid version 4 format
+var structFieldUUIDv4Regex = regexp.MustCompile("^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-4[0-9A-Fa-f]{3}-[89ABab][0-9A-Fa-f]{3}-[0-9A-Fa-f]{12}$")
+
+// isStructFieldFormatType returns true if the given type tag value is validated by format rather than by character extraction
+func isStructFieldFormatType(tagType string) bool {
+	return tagType == "email" || tagType == "url" || tagType == "uuid"
+}
+
+// getStructFieldBase64Encoding returns the base64 encoding per struct tag `b64mode:""` of field,
+// url = url safe alphabet, otherwise standard alphabet, both padded
+func getStructFieldBase64Encoding(field reflect.StructField) *base64.Encoding {
+	if strings.ToLower(Trim(field.Tag.Get("b64mode"))) == "url" {
+		return base64.URLEncoding
 	}
 
-	if s.Kind() != reflect.Struct {
-		return fmt.Errorf("InputStructPtr Must Be Struct")
+	return base64.StdEncoding
+}
+
+// validateStructFieldFormat evaluates value against the email, url or uuid type tag of field,
+// blank value fails only when req tag is true, other type tags are not evaluated
+func validateStructFieldFormat(field reflect.StructField, tags structFieldValidateTags, value string) *FieldError {
+	if !isStructFieldFormatType(tags.Type) {
+		return nil
 	}
 
-	trueList := []string{"true", "yes", "on", "1", "enabled"}
+	if len(value) == 0 {
+		if tags.Req == "true" {
+			return &FieldError{Field: field.Name, Rule: "req", Value: value, Message: fmt.Sprintf("%s is a Required Field", field.Name)}
+		}
+
+		return nil
+	}
 
-	var csvElements []string
+	valid := false
 
-	if len(csvDelimiter) > 0 {
-		csvElements = strings.Split(csvPayload, csvDelimiter)
-	} else {
-		csvElements = customDelimiterParserFunc(csvPayload)
+	switch tags.Type {
+	case "email":
+		valid = len(value) <= 254 && structFieldEmailRegex.MatchString(value)
+	case "url":
+		if u, e := url.ParseRequestURI(value); e == nil && len(u.Scheme) > 0 {
+			valid = true
+		}
+	case "uuid":
+		valid = structFieldUUIDv4Regex.MatchString(value)
 	}
 
-	csvLen := len(csvElements)
+	if !valid {
+		return &FieldError{Field: field.Name, Rule: "type", Value: value, Message: fmt.Sprintf("%s Validation Failed: Expected Type '%s', But Received '%s'", field.Name, tags.Type, value)}
+	}
 
-	if csvLen == 0 {
-		return fmt.Errorf("CSV Payload Contains Zero Elements")
+	return nil
+}
+
+// validateStructFieldRules evaluates size minimum, size block modulo, range and req tags of field against value,
+// size maximum is not evaluated since marshal truncates value to size maximum
+func validateStructFieldRules(field reflect.StructField, tags structFieldValidateTags, value string) *FieldError {
+	if isStructFieldSizeType(tags.Type) {
+		if tags.SizeMin > 0 && len(value) > 0 && len(value) < tags.SizeMin {
+			return &FieldError{Field: field.Name, Rule: "size", Value: value, Message: fmt.Sprintf("%s Min Length is %d", field.Name, tags.SizeMin)}
+		}
+
+		if tags.Modulo > 0 && len(value)%tags.Modulo != 0 {
+			return &FieldError{Field: field.Name, Rule: "size", Value: value, Message: fmt.Sprintf("Struct Field %s Expects Value In Blocks of %d Characters", field.Name, tags.Modulo)}
+		}
 	}
 
-	StructClearFields(inputStructPtr)
-	SetStructFieldDefaultValues(inputStructPtr)
-	prefixProcessedMap := make(map[string]string)
+	if tags.Type == "n" {
+		if n, ok := ParseInt32(value); ok {
+			if tags.RangeMin > 0 && n < tags.RangeMin && !(n == 0 && tags.Req != "true") {
+				return &FieldError{Field: field.Name, Rule: "range", Value: value, Message: fmt.Sprintf("%s Range Minimum is %d", field.Name, tags.RangeMin)}
+			}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+			if tags.RangeMax > 0 && n > tags.RangeMax {
+				return &FieldError{Field: field.Name, Rule: "range", Value: value, Message: fmt.Sprintf("%s Range Maximum is %d", field.Name, tags.RangeMax)}
+			}
+		}
+	}
 
-		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
-			// extract struct tag values
-			tagPosBuf := field.Tag.Get("pos")
-			tagPos, ok := ParseInt32(tagPosBuf)
-			if !ok {
-				if tagPosBuf != "-" || LenTrim(field.Tag.Get("setter")) == 0 {
-					continue
+	if tags.Req == "true" && len(value) == 0 {
+		return &FieldError{Field: field.Name, Rule: "req", Value: value, Message: fmt.Sprintf("%s is a Required Field", field.Name)}
+	}
+
+	return nil
+}
+
+// validateStructFieldValidateTag evaluates the validate tag of field against value, s is the struct containing field,
+// where := validator method is invoked against, tagReq indicates if blank value is to be validated
+func validateStructFieldValidateTag(s reflect.Value, field reflect.StructField, tagReq string, value string) *FieldError {
+	valData := Trim(field.Tag.Get("validate"))
+
+	if len(valData) < 3 {
+		return nil
+	}
+
+	valComp := Left(valData, 2)
+	valData = Right(valData, len(valData)-2)
+
+	fail := func(msg string) *FieldError {
+		return &FieldError{Field: field.Name, Rule: "validate", Value: value, Message: msg}
+	}
+
+	if valComp != ":=" && Left(valData, 1) == "#" {
+		// cross field validation against sibling field
+		return validateStructFieldCrossField(s, field, tagReq, value, valComp, Right(valData, len(valData)-1))
+	}
+
+	switch valComp {
+	case "==":
+		valAr := strings.Split(valData, "||")
+
+		if len(valAr) <= 1 {
+			if strings.ToLower(value) != strings.ToLower(valData) {
+				if len(value) > 0 || tagReq == "true" {
+					return fail(fmt.Sprintf("%s Validation Failed: Expected To Match '%s', But Received '%s'", field.Name, valData, value))
 				}
-			} else if tagPos < 0 {
-				continue
 			}
+		} else {
+			found := false
 
-			tagType := Trim(strings.ToLower(field.Tag.Get("type")))
-			switch tagType {
-			case "a":
-				fallthrough
-			case "n":
-				fallthrough
-			case "an":
-				fallthrough
-			case "ans":
-				fallthrough
-			case "b":
-				fallthrough
-			case "b64":
-				fallthrough
-			case "regex":
-				fallthrough
-			case "h":
-				// valid type
-			default:
-				tagType = ""
+			for _, va := range valAr {
+				if strings.ToLower(value) == strings.ToLower(va) {
+					found = true
+					break
+				}
 			}
 
-			tagRegEx := Trim(field.Tag.Get("regex"))
-			if tagType != "regex" {
-				tagRegEx = ""
-			} else {
-				if LenTrim(tagRegEx) == 0 {
-					tagType = ""
-				}
+			if !found && (len(value) > 0 || tagReq == "true") {
+				return fail(fmt.Sprintf("%s Validation Failed: Expected To Match '%s', But Received '%s'", field.Name, strings.ReplaceAll(valData, "||", " or "), value))
 			}
+		}
+	case "!=":
+		valAr := strings.Split(valData, "&&")
 
-			// unmarshal only validates max
-			tagSize := Trim(strings.ToLower(field.Tag.Get("size")))
-			arModulo := strings.Split(tagSize, "+%")
-			tagModulo := 0
-			if len(arModulo) == 2 {
-				tagSize = arModulo[0]
-				if tagModulo, _ = ParseInt32(arModulo[1]); tagModulo < 0 {
-					tagModulo = 0
+		if len(valAr) <= 1 {
+			if strings.ToLower(value) == strings.ToLower(valData) {
+				if len(value) > 0 || tagReq == "true" {
+					return fail(fmt.Sprintf("%s Validation Failed: Expected To Not Match '%s', But Received '%s'", field.Name, valData, value))
 				}
 			}
-			arSize := strings.Split(tagSize, "..")
-			sizeMin := 0
-			sizeMax := 0
-			if len(arSize) == 2 {
-				sizeMin, _ = ParseInt32(arSize[0])
-				sizeMax, _ = ParseInt32(arSize[1])
-			} else {
-				sizeMin, _ = ParseInt32(tagSize)
-				sizeMax = sizeMin
-			}
-
-			/*
-			// tagRange not used in unmarshal
-			tagRange := Trim(strings.ToLower(field.Tag.Get("range")))
-			arRange := strings.Split(tagRange, "..")
-			rangeMin := 0
-			rangeMax := 0
-			if len(arRange) == 2 {
-				rangeMin, _ = ParseInt32(arRange[0])
-				rangeMax, _ = ParseInt32(arRange[1])
-			} else {
-				rangeMin, _ = ParseInt32(tagRange)
-				rangeMax = rangeMin
+		} else {
+			found := false
+
+			for _, va := range valAr {
+				if strings.ToLower(value) == strings.ToLower(va) {
+					found = true
+					break
+				}
 			}
-			*/
 
-			// tagReq not used in unmarshal
-			tagReq := Trim(strings.ToLower(field.Tag.Get("req")))
-			if tagReq != "true" && tagReq != "false" {
-				tagReq = ""
+			if found && (len(value) > 0 || tagReq == "true") {
+				return fail(fmt.Sprintf("%s Validation Failed: Expected To Not Match '%s', But Received '%s'", field.Name, strings.ReplaceAll(valData, "&&", " and "), value))
+			}
+		}
+	case "<=":
+		if valNum, valOk := ParseFloat64(valData); valOk {
+			if srcNum, _ := ParseFloat64(value); srcNum > valNum {
+				if len(value) > 0 || tagReq == "true" {
+					return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Less or Equal To '%s', But Received '%s'", field.Name, valData, value))
+				}
+			}
+		}
+	case "<<":
+		if valNum, valOk := ParseFloat64(valData); valOk {
+			if srcNum, _ := ParseFloat64(value); srcNum >= valNum {
+				if len(value) > 0 || tagReq == "true" {
+					return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Less Than '%s', But Received '%s'", field.Name, valData, value))
+				}
+			}
+		}
+	case ">=":
+		if valNum, valOk := ParseFloat64(valData); valOk {
+			if srcNum, _ := ParseFloat64(value); srcNum < valNum {
+				if len(value) > 0 || tagReq == "true" {
+					return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Greater or Equal To '%s', But Received '%s'", field.Name, valData, value))
+				}
+			}
+		}
+	case ">>":
+		if valNum, valOk := ParseFloat64(valData); valOk {
+			if srcNum, _ := ParseFloat64(value); srcNum <= valNum {
+				if len(value) > 0 || tagReq == "true" {
+					return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Greater Than '%s', But Received '%s'", field.Name, valData, value))
+				}
+			}
+		}
+	case ":=":
+		if len(valData) > 0 {
+			if retV, nf := ReflectCall(s.Addr(), valData); !nf {
+				if len(retV) > 0 {
+					if retV[0].Kind() == reflect.Bool && !retV[0].Bool() {
+						// validation failed with bool false
+						return fail(fmt.Sprintf("%s Validation Failed: %s() Returned Result is False", field.Name, valData))
+					} else if retErr := DerefError(retV[0]); retErr != nil {
+						// validation failed with error
+						return fail(fmt.Sprintf("%s Validation On %s() Failed: %s", field.Name, valData, retErr.Error()))
+					}
+				}
 			}
+		}
+	}
 
-			// if outPrefix exists, remove from csvValue
-			outPrefix := Trim(field.Tag.Get("outprefix"))
+	return nil
+}
 
-			// get csv value by ordinal position
-			csvValue := ""
+// isStructFieldCrossFieldValidate returns true if validate tag of field compares against sibling field, such as `validate:"<=#End"`
+func isStructFieldCrossFieldValidate(field reflect.StructField) bool {
+	valData := Trim(field.Tag.Get("validate"))
+	return len(valData) >= 3 && Left(valData, 2) != ":=" && valData[2] == '#'
+}
 
-			if tagPosBuf != "-" {
-				if LenTrim(outPrefix) == 0 {
-					// ordinal based csv parsing
-					if csvElements != nil {
-						if tagPos > csvLen-1 {
-							// no more elements to unmarshal, rest of fields using default values
-							return nil
-						} else {
-							csvValue = csvElements[tagPos]
+// validateStructFieldCrossField evaluates value of field against the current value of sibling field named siblingName within struct s,
+// using comparison operator valComp (==, !=, <=, <<, >=, >>),
+// numbers and time.Time values are compared by value, otherwise values are compared as exact string,
+// if value is blank and tagReq is not true, validation is skipped, and ordering comparison is skipped if sibling value is blank or zero time
+func validateStructFieldCrossField(s reflect.Value, field reflect.StructField, tagReq string, value string, valComp string, siblingName string) *FieldError {
+	fail := func(msg string) *FieldError {
+		return &FieldError{Field: field.Name, Rule: "validate", Value: value, Message: msg}
+	}
 
-							evalOk := false
-							if boolTrue := Trim(field.Tag.Get("booltrue")); len(boolTrue) > 0 {
-								if boolTrue == csvValue {
-									csvValue = "true"
-									evalOk = true
-								}
-							}
+	if len(value) == 0 && tagReq != "true" {
+		return nil
+	}
 
-							if !evalOk {
-								if boolFalse := Trim(field.Tag.Get("boolfalse")); len(boolFalse) > 0 {
-									if boolFalse == csvValue {
-										csvValue = "false"
-									}
-								}
-							}
-						}
-					}
-				} else {
-					// variable element based csv, using outPrefix as the identifying key
-					// instead of getting csv value from element position, acquire from outPrefix
-					notFound := true
+	siblingName = Trim(siblingName)
+	siblingField, found := s.Type().FieldByName(siblingName)
 
-					for _, v := range csvElements {
-						if strings.ToLower(Left(v, len(outPrefix))) == strings.ToLower(outPrefix) {
-							// match
-							if _, ok := prefixProcessedMap[strings.ToLower(outPrefix)]; !ok {
-								prefixProcessedMap[strings.ToLower(outPrefix)] = Itoa(tagPos)
+	if !found {
+		return fail(fmt.Sprintf("%s Validation Failed: Sibling Field '%s' Not Found", field.Name, siblingName))
+	}
 
-								if len(v)-len(outPrefix) == 0 {
-									csvValue = ""
+	sibling := s
 
-									if field.Tag.Get("booltrue") == " " {
-										// prefix found, since data is blank, and boolTrue is space, treat this as true
-										csvValue = "true"
-									}
-								} else {
-									csvValue = Right(v, len(v)-len(outPrefix))
+	for _, idx := range siblingField.Index {
+		if sibling.Kind() == reflect.Ptr {
+			if sibling.IsNil() {
+				return fail(fmt.Sprintf("%s Validation Failed: Sibling Field '%s' Not Reachable Via Nil Embedded Struct", field.Name, siblingName))
+			}
 
-									evalOk := false
-									if boolTrue := Trim(field.Tag.Get("booltrue")); len(boolTrue) > 0 {
-										if boolTrue == csvValue {
-											csvValue = "true"
-											evalOk = true
-										}
-									}
+			sibling = sibling.Elem()
+		}
 
-									if !evalOk {
-										if boolFalse := Trim(field.Tag.Get("boolfalse")); len(boolFalse) > 0 {
-											if boolFalse == csvValue {
-												csvValue = "false"
-											}
-										}
-									}
-								}
+		sibling = sibling.Field(idx)
+	}
 
-								notFound = false
-								break
-							}
-						}
-					}
+	siblingStr, _, err := ReflectValueToString(sibling, siblingField.Tag.Get("booltrue"), siblingField.Tag.Get("boolfalse"), false, false, getStructFieldTimeFormat(siblingField, siblingField.Tag.Get("timeformat")), false)
 
-					if notFound {
-						continue
-					}
-				}
-			}
+	if err != nil {
+		return fail(fmt.Sprintf("%s Validation Failed: Sibling Field '%s' Value Not Readable: %s", field.Name, siblingName, err))
+	}
 
-			// pre-process csv value with validation
-			tagSetter := Trim(field.Tag.Get("setter"))
-			hasSetter := false
+	// compare value against sibling, cmp = -1 (less), 0 (equal), 1 (greater)
+	cmp := strings.Compare(value, siblingStr)
+	siblingBlank := len(siblingStr) == 0
 
-			isBase := false
-			if LenTrim(tagSetter) > 0 {
-				hasSetter = true
+	siblingBase, _, _ := DerefPointersZero(sibling)
 
-				if strings.ToLower(Left(tagSetter, 5)) == "base." {
-					isBase = true
-					tagSetter = Right(tagSetter, len(tagSetter)-5)
+	if st, isTime := siblingBase.Interface().(time.Time); isTime {
+		siblingBlank = st.IsZero()
+
+		// time values are compared by value, parsing value per this field's timeformat
+		tv := reflect.New(field.Type).Elem()
+
+		if e := ReflectStringToField(tv, value, getStructFieldTimeFormat(field, field.Tag.Get("timeformat"))); e == nil {
+			tvBase, _, _ := DerefPointersZero(tv)
+
+			if vt, ok := tvBase.Interface().(time.Time); ok {
+				switch {
+				case vt.Before(st):
+					cmp = -1
+				case vt.After(st):
+					cmp = 1
+				default:
+					cmp = 0
 				}
 			}
+		}
+	} else if valNum, valOk := ParseFloat64(value); valOk {
+		// numbers are compared by value
+		if sibNum, sibOk := ParseFloat64(siblingStr); sibOk {
+			switch {
+			case valNum < sibNum:
+				cmp = -1
+			case valNum > sibNum:
+				cmp = 1
+			default:
+				cmp = 0
+			}
+		}
+	}
 
-			timeFormat := Trim(field.Tag.Get("timeformat"))
+	switch valComp {
+	case "==":
+		if cmp != 0 {
+			return fail(fmt.Sprintf("%s Validation Failed: Expected To Match %s '%s', But Received '%s'", field.Name, siblingName, siblingStr, value))
+		}
+	case "!=":
+		if cmp == 0 {
+			return fail(fmt.Sprintf("%s Validation Failed: Expected To Not Match %s '%s', But Received '%s'", field.Name, siblingName, siblingStr, value))
+		}
+	case "<=":
+		if !siblingBlank && cmp > 0 {
+			return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Less or Equal To %s '%s', But Received '%s'", field.Name, siblingName, siblingStr, value))
+		}
+	case "<<":
+		if !siblingBlank && cmp >= 0 {
+			return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Less Than %s '%s', But Received '%s'", field.Name, siblingName, siblingStr, value))
+		}
+	case ">=":
+		if !siblingBlank && cmp < 0 {
+			return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Greater or Equal To %s '%s', But Received '%s'", field.Name, siblingName, siblingStr, value))
+		}
+	case ">>":
+		if !siblingBlank && cmp <= 0 {
+			return fail(fmt.Sprintf("%s Validation Failed: Expected To Be Greater Than %s '%s', But Received '%s'", field.Name, siblingName, siblingStr, value))
+		}
+	}
 
-			if o.Kind() != reflect.Ptr && o.Kind() != reflect.Interface && o.Kind() != reflect.Struct && o.Kind() != reflect.Slice {
-				if tagPosBuf != "-" {
-					switch tagType {
-					case "a":
-						csvValue, _ = ExtractAlpha(csvValue)
-					case "n":
-						csvValue, _ = ExtractNumeric(csvValue)
-					case "an":
-						csvValue, _ = ExtractAlphaNumeric(csvValue)
-					case "ans":
-						if !hasSetter {
-							csvValue, _ = ExtractAlphaNumericPrintableSymbols(csvValue)
-						}
-					case "b":
-						if StringSliceContains(&trueList, strings.ToLower(csvValue)) {
-							csvValue = "true"
-						} else {
-							csvValue = "false"
-						}
-					case "regex":
-						csvValue, _ = ExtractByRegex(csvValue, tagRegEx)
-					case "h":
-						csvValue, _ = ExtractHex(csvValue)
-					case "b64":
-						csvValue, _ = ExtractAlphaNumericPrintableSymbols(csvValue)
-					}
+	return nil
+}
+
+// validateStructFieldType evaluates if value conforms to the type (and regex) tag of field,
+// where value must not contain characters that marshal would otherwise strip out
+func validateStructFieldType(field reflect.StructField, tags structFieldValidateTags, value string) *FieldError {
+	if len(value) == 0 {
+		return nil
+	}
+
+	extracted := value
+
+	switch tags.Type {
+	case "a":
+		extracted, _ = ExtractAlpha(value)
+	case "n":
+		extracted, _ = ExtractNumeric(value)
+	case "an":
+		extracted, _ = ExtractAlphaNumeric(value)
+	case "ans", "b64":
+		extracted, _ = ExtractAlphaNumericPrintableSymbols(value)
+	case "h":
+		extracted, _ = ExtractHex(value)
+	case "email", "url", "uuid":
+		return validateStructFieldFormat(field, tags, value)
+	case "regex":
+		if extracted, _ = ExtractByRegex(value, tags.RegEx); extracted != value {
+			return &FieldError{Field: field.Name, Rule: "regex", Value: value, Message: fmt.Sprintf("%s Validation Failed: Expected To Match Regex '%s', But Received '%s'", field.Name, tags.RegEx, value)}
+		}
+
+		return nil
+	}
+
+	if extracted != value {
+		return &FieldError{Field: field.Name, Rule: "type", Value: value, Message: fmt.Sprintf("%s Validation Failed: Expected Type '%s', But Received '%s'", field.Name, tags.Type, value)}
+	}
+
+	return nil
+}
+
+// ValidateStruct validates current field values of inputStructPtr against the validation related struct tags,
+// without marshaling the struct, the first failed field is returned as *FieldError,
+// see ValidateStructCollect for the rules evaluated
+func ValidateStruct(inputStructPtr interface{}) error {
+	fieldErrors, err := validateStruct(inputStructPtr, true)
+
+	if err != nil {
+		return err
+	}
+
+	if len(fieldErrors) > 0 {
+		return &fieldErrors[0]
+	}
+
+	return nil
+}
+
+// ValidateStructCollect validates current field values of inputStructPtr against the validation related struct tags,
+// without marshaling the struct, all failed fields are collected and returned, nil is returned if all fields are valid,
+// field values are stringified via ReflectValueToString (honoring booltrue, boolfalse and timeformat tags), then evaluated as follows:
+//		1) `type:"xyz"`			// value must not contain characters outside of the type (a, n, an, ans, h, b64)
+//		2) `regex:"xyz"`		// when type is regex, value must fully match the regex
+//		3) `size:"x..y+%z"`		// value length must be within min and max, and in blocks of z characters if defined
+//		4) `range:"x..y"`		// when type is n, value must be within min and max
+//		5) `req:"true"`			// value must not be blank (slice must not be empty)
+//		6) `validate:"==x"`		// same validate rules as MarshalStructToCSV, including := validator method
+func ValidateStructCollect(inputStructPtr interface{}) ([]FieldError, error) {
+	return validateStruct(inputStructPtr, false)
+}
 
-					if tagType == "a" || tagType == "an" || tagType == "ans" || tagType == "n" || tagType == "regex" || tagType == "h" || tagType == "b64" {
-						if sizeMax > 0 {
-							if len(csvValue) > sizeMax {
-								csvValue = Left(csvValue, sizeMax)
-							}
-						}
+// validateStruct evaluates struct field values of inputStructPtr, if stopOnFirst is true, only the first field error is returned
+func validateStruct(inputStructPtr interface{}, stopOnFirst bool) (fieldErrors []FieldError, err error) {
+	if inputStructPtr == nil {
+		return nil, fmt.Errorf("InputStructPtr is Required")
+	}
 
-						if tagModulo > 0 {
-							if len(csvValue)%tagModulo != 0 {
-								return fmt.Errorf("Struct Field %s Expects Value In Blocks of %d Characters", field.Name, tagModulo)
-							}
-						}
-					}
-				}
+	s := reflect.ValueOf(inputStructPtr)
 
-				if LenTrim(tagSetter) > 0 {
-					var ov []reflect.Value
-					var notFound bool
+	if s.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
 
-					if isBase {
-						ov, notFound = ReflectCall(s.Addr(), tagSetter, csvValue)
-					} else {
-						ov, notFound = ReflectCall(o, tagSetter, csvValue)
-					}
+	if s.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("InputStructPtr Must Be Struct")
+	}
 
-					if !notFound {
-						if len(ov) == 1 {
-							csvValue, _, _ = ReflectValueToString(ov[0], "", "", false, false, timeFormat, false)
-						} else if len(ov) > 1 {
-							getFirstVar := true
+	for _, sf := range getStructFieldValues(s, "", false) {
+		field := sf.Field
+		o := sf.Value
 
-							if e, ok := ov[len(ov)-1].Interface().(error); ok {
-								// last var is error, check if error exists
-								if e != nil {
-									getFirstVar = false
-								}
-							}
+		if !o.IsValid() {
+			continue
+		}
 
-							if getFirstVar {
-								csvValue, _, _ = ReflectValueToString(ov[0], "", "", false, false, timeFormat, false)
-							}
-						}
-					}
+		tags := parseStructFieldValidateTags(field)
+		valData := Trim(field.Tag.Get("validate"))
+
+		if len(tags.Type) == 0 && tags.SizeMin == 0 && tags.SizeMax == 0 && tags.RangeMin == 0 && tags.RangeMax == 0 && tags.Req != "true" && len(valData) < 3 {
+			// no validation rules defined
+			continue
+		}
+
+		var fe *FieldError
+
+		if o.Kind() == reflect.Slice || o.Kind() == reflect.Map {
+			if tags.Req == "true" && o.Len() == 0 {
+				fe = &FieldError{Field: field.Name, Rule: "req", Message: fmt.Sprintf("%s is a Required Field", field.Name)}
+			} else {
+				fe = validateStructFieldValidateTag(s, field, tags.Req, "")
+			}
+		} else {
+			value, _, e := ReflectValueToString(o, field.Tag.Get("booltrue"), field.Tag.Get("boolfalse"), false, false, getStructFieldTimeFormat(field, field.Tag.Get("timeformat")), false)
+
+			if e != nil {
+				// field type not convertible to string, only validator method is applicable
+				value = ""
+			}
+
+			if fe = validateStructFieldType(field, tags, value); fe == nil {
+				if isStructFieldSizeType(tags.Type) && tags.SizeMax > 0 && len(value) > tags.SizeMax {
+					fe = &FieldError{Field: field.Name, Rule: "size", Value: value, Message: fmt.Sprintf("%s Max Length is %d", field.Name, tags.SizeMax)}
+				} else if fe = validateStructFieldRules(field, tags, value); fe == nil {
+					fe = validateStructFieldValidateTag(s, field, tags.Req, value)
 				}
+			}
+		}
 
-				// validate if applicable
-				skipFieldSet := false
+		if fe != nil {
+			fieldErrors = append(fieldErrors, *fe)
 
-				if valData := Trim(field.Tag.Get("validate")); len(valData) >= 3 {
-					valComp := Left(valData, 2)
-					valData = Right(valData, len(valData)-2)
+			if stopOnFirst {
+				break
+			}
+		}
+	}
 
-					switch valComp {
-					case "==":
-						valAr := strings.Split(valData, "||")
+	return fieldErrors, nil
+}
 
-						if len(valAr) <= 1 {
-							if strings.ToLower(csvValue) != strings.ToLower(valData) {
-								if len(csvValue) > 0 || tagReq == "true" {
-									StructClearFields(inputStructPtr)
-									return fmt.Errorf("%s Validation Failed: Expected To Match '%s', But Received '%s'", field.Name, valData, csvValue)
-								}
-							}
-						} else {
-							found := false
+// ValidateCSVStructTags validates the csv related struct tags defined in inputStructPtr,
+// this is intended to be called at startup or within unit tests, to catch struct tag mistakes early,
+// returns error describing all of the following issues found:
+//		1) two or more fields declare the same pos, without being linked by the same uniqueid
+//		2) pos is beyond the struct field count (such field is never marshaled to csv)
+//		3) pos, size, or range tag values that are not numeric
+//		4) pos is negative (use pos:"-" to declare field without ordinal position)
+//		5) gaps in pos sequence, where one or more positions between 0 and the highest pos are not declared by any field
+func ValidateCSVStructTags(inputStructPtr interface{}) error {
+	if inputStructPtr == nil {
+		return fmt.Errorf("InputStructPtr is Required")
+	}
 
-							for _, va := range valAr {
-								if strings.ToLower(csvValue) == strings.ToLower(va) {
-									found = true
-									break
-								}
-							}
+	s := reflect.ValueOf(inputStructPtr)
 
-							if !found && (len(csvValue) > 0 || tagReq == "true") {
-								return fmt.Errorf("%s Validation Failed: Expected To Match '%s', But Received '%s'", field.Name, strings.ReplaceAll(valData, "||", " or "), csvValue)
-							}
-						}
-					case "!=":
-						valAr := strings.Split(valData, "&&")
-
-						if len(valAr) <= 1 {
-							if strings.ToLower(csvValue) == strings.ToLower(valData) {
-								if len(csvValue) > 0 || tagReq == "true" {
-									StructClearFields(inputStructPtr)
-									return fmt.Errorf("%s Validation Failed: Expected To Not Match '%s', But Received '%s'", field.Name, valData, csvValue)
-								}
-							}
-						} else {
-							found := false
+	if s.Kind() != reflect.Ptr {
+		return fmt.Errorf("InputStructPtr Must Be Pointer")
+	} else {
+		s = s.Elem()
+	}
 
-							for _, va := range valAr {
-								if strings.ToLower(csvValue) == strings.ToLower(va) {
-									found = true
-									break
-								}
-							}
+	if s.Kind() != reflect.Struct {
+		return fmt.Errorf("InputStructPtr Must Be Struct")
+	}
 
-							if found && (len(csvValue) > 0 || tagReq == "true") {
-								return fmt.Errorf("%s Validation Failed: Expected To Not Match '%s', But Received '%s'", field.Name, strings.ReplaceAll(valData, "&&", " and "), csvValue)
-							}
-						}
-					case "<=":
-						if valNum, valOk := ParseFloat64(valData); valOk {
-							if srcNum, _ := ParseFloat64(csvValue); srcNum > valNum {
-								if len(csvValue) > 0 || tagReq == "true" {
-									StructClearFields(inputStructPtr)
-									return fmt.Errorf("%s Validation Failed: Expected To Be Less or Equal To '%s', But Received '%s'", field.Name, valData, csvValue)
-								}
-							}
-						}
-					case "<<":
-						if valNum, valOk := ParseFloat64(valData); valOk {
-							if srcNum, _ := ParseFloat64(csvValue); srcNum >= valNum {
-								if len(csvValue) > 0 || tagReq == "true" {
-									StructClearFields(inputStructPtr)
-									return fmt.Errorf("%s Validation Failed: Expected To Be Less Than '%s', But Received '%s'", field.Name, valData, csvValue)
-								}
-							}
-						}
-					case ">=":
-						if valNum, valOk := ParseFloat64(valData); valOk {
-							if srcNum, _ := ParseFloat64(csvValue); srcNum < valNum {
-								if len(csvValue) > 0 || tagReq == "true" {
-									StructClearFields(inputStructPtr)
-									return fmt.Errorf("%s Validation Failed: Expected To Be Greater or Equal To '%s', But Received '%s'", field.Name, valData, csvValue)
-								}
-							}
-						}
-					case ">>":
-						if valNum, valOk := ParseFloat64(valData); valOk {
-							if srcNum, _ := ParseFloat64(csvValue); srcNum <= valNum {
-								if len(csvValue) > 0 || tagReq == "true" {
-									StructClearFields(inputStructPtr)
-									return fmt.Errorf("%s Validation Failed: Expected To Be Greater Than '%s', But Received '%s'", field.Name, valData, csvValue)
-								}
-							}
-						}
-					case ":=":
-						if len(valData) > 0 {
-							skipFieldSet = true
+	fields := getStructFieldValues(s, "pos", false)
+	errs := validateCSVStructPosDuplicates(fields)
+	posDeclared := make(map[int]bool)
+	posMax := -1
 
-							if err := ReflectStringToField(o, csvValue, timeFormat); err != nil {
-								return err
-							}
+	for _, sf := range fields {
+		field := sf.Field
 
-							if retV, nf := ReflectCall(s.Addr(), valData); !nf {
-								if len(retV) > 0 {
-									if retV[0].Kind() == reflect.Bool && !retV[0].Bool() {
-										// validation failed with bool false
-										StructClearFields(inputStructPtr)
-										return fmt.Errorf("%s Validation Failed: %s() Returned Result is False", field.Name, valData)
-									} else if retErr := DerefError(retV[0]); retErr != nil {
-										// validation failed with error
-										StructClearFields(inputStructPtr)
-										return fmt.Errorf("%s Validation On %s() Failed: %s", field.Name, valData, retErr.Error())
-									}
-								}
-							}
-						}
-					}
-				}
+		tagPosBuf := Trim(field.Tag.Get("pos"))
 
-				// set validated csv value into corresponding struct field
-				if !skipFieldSet {
-					if err := ReflectStringToField(o, csvValue, timeFormat); err != nil {
-						return err
-					}
-				}
+		if len(tagPosBuf) > 0 && tagPosBuf != "-" {
+			if tagPos, ok := ParseInt32(tagPosBuf); !ok {
+				errs = append(errs, fmt.Errorf("Field %s Declares Non-Numeric pos '%s'", field.Name, tagPosBuf))
+			} else if tagPos < 0 {
+				errs = append(errs, fmt.Errorf("Field %s Declares Negative pos %d", field.Name, tagPos))
 			} else {
-				if LenTrim(tagSetter) > 0 {
-					if o.Kind() != reflect.Slice {
-						// get base type
-						if baseType, _, isNilPtr := DerefPointersZero(o); isNilPtr {
-							// create new struct pointer
-							o.Set(reflect.New(baseType.Type()))
-						} else {
-							if o.Kind() == reflect.Interface && o.Interface() == nil {
-								customType := ReflectTypeRegistryGet(o.Type().String())
+				if tagPos > len(fields)-1 {
+					errs = append(errs, fmt.Errorf("Field %s Declares pos %d Beyond Struct Field Count %d", field.Name, tagPos, len(fields)))
+				}
 
-								if customType == nil {
-									return fmt.Errorf("%s Struct Field %s is Interface Without Actual Object Assignment", s.Type(), o.Type())
-								} else {
-									o.Set(reflect.New(customType))
-								}
-							}
-						}
-					}
+				posDeclared[tagPos] = true
 
-					var ov []reflect.Value
-					var notFound bool
+				if tagPos > posMax {
+					posMax = tagPos
+				}
+			}
+		}
 
-					if isBase {
-						ov, notFound = ReflectCall(s.Addr(), tagSetter, csvValue)
-					} else {
-						ov, notFound = ReflectCall(o, tagSetter, csvValue)
-					}
+		if tagSize := Trim(strings.ToLower(field.Tag.Get("size"))); len(tagSize) > 0 {
+			arModulo := strings.Split(tagSize, "+%")
 
-					if !notFound {
-						if len(ov) == 1 {
-							if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
-								o.Set(ov[0])
-							}
-						} else if len(ov) > 1 {
-							getFirstVar := true
+			if len(arModulo) == 2 {
+				tagSize = arModulo[0]
 
-							if e := DerefError(ov[len(ov)-1]); e != nil {
-								getFirstVar = false
-							}
+				if _, ok := ParseInt32(arModulo[1]); !ok {
+					errs = append(errs, fmt.Errorf("Field %s Declares Non-Numeric size Modulo '%s'", field.Name, arModulo[1]))
+				}
+			}
 
-							if getFirstVar {
-								if ov[0].Kind() == reflect.Ptr || ov[0].Kind() == reflect.Slice {
-									o.Set(ov[0])
-								}
-							}
-						}
-					}
-				} else {
-					// set validated csv value into corresponding struct pointer field
-					if err := ReflectStringToField(o, csvValue, timeFormat); err != nil {
-						return err
-					}
+			for _, v := range strings.Split(tagSize, "..") {
+				if _, ok := ParseInt32(v); !ok && len(v) > 0 {
+					errs = append(errs, fmt.Errorf("Field %s Declares Non-Numeric size '%s'", field.Name, field.Tag.Get("size")))
+					break
+				}
+			}
+		}
+
+		if tagRange := Trim(strings.ToLower(field.Tag.Get("range"))); len(tagRange) > 0 {
+			for _, v := range strings.Split(tagRange, "..") {
+				if _, ok := ParseInt32(v); !ok && len(v) > 0 {
+					errs = append(errs, fmt.Errorf("Field %s Declares Non-Numeric range '%s'", field.Name, field.Tag.Get("range")))
+					break
 				}
 			}
 		}
 	}
 
+	for i := 0; i < posMax; i++ {
+		if !posDeclared[i] {
+			errs = append(errs, fmt.Errorf("pos %d Not Declared By Any Field (Gap Below Highest pos %d)", i, posMax))
+		}
+	}
+
+	if len(errs) > 0 {
+		var msgs []string
+
+		for _, e := range errs {
+			msgs = append(msgs, e.Error())
+		}
+
+		return fmt.Errorf("%s CSV Struct Tags Invalid: %s", s.Type().Name(), strings.Join(msgs, "; "))
+	}
+
 	return nil
 }
 
-// MarshalStructToCSV will serialize struct fields defined with strug tags below, to csvPayload string (one line of csv data) using csvDelimiter,
-// the csv payload ordinal position is based on the struct tag pos defined for each struct field,
-// additionally processes struct tag data validation and length / range (if not valid, will set to data type default),
-// this method provides data validation and if fails, will return error (for string if size exceeds max, it will truncate)
-//
-// Predefined Struct Tags Usable:
-//		1) `pos:"1"`				// ordinal position of the field in relation to the csv parsed output expected (Zero-Based Index)
-//									   NOTE: if field is mutually exclusive with one or more uniqueId, then pos # should be named the same for all uniqueIds
-//											 if multiple fields are in exclusive condition, and skipBlank or skipZero, always include a blank default field as the last of unique field list
-//										     if value is '-', this means position value is calculated from other fields and set via `setter:"base.Xyz"` during unmarshal csv, there is no marshal to csv for this field
-//		2) `type:"xyz"`				// data type expected:
-//											A = AlphabeticOnly, N = NumericOnly 0-9, AN = AlphaNumeric, ANS = AN + PrintableSymbols,
-//											H = Hex, B64 = Base64, B = true/false, REGEX = Regular Expression, Blank = Any,
-//		3) `regex:"xyz"`			// if Type = REGEX, this struct tag contains the regular expression string,
-//										 	regex express such as [^A-Za-z0-9_-]+
-//										 	method will replace any regex matched string to blank
-//		4) `size:"x..y"`			// data type size rule:
-//											x = Exact size match
-//											x.. = From x and up
-//											..y = From 0 up to y
-//											x..y = From x to y
-//											+%z = Append to x, x.., ..y, x..y; adds additional constraint that the result size must equate to 0 from modulo of z
-//		5) `range:"x..y"`			// data type range value when Type is N, if underlying data type is string, method will convert first before testing
-//		6) `req:"true"`				// indicates data value is required or not, true or false
-//		7) `getter:"Key"`			// if field type is custom struct or enum, specify the custom method getter (no parameters allowed) that returns the expected value in first ordinal result position
-//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
-//									   NOTE: if the method is to receive a parameter value, always in string data type, add '(x)' after the method name, such as 'XYZ(x)' or 'base.XYZ(x)'
-// 		8) `setter:"ParseByKey`		// if field type is custom struct or enum, specify the custom method (only 1 lookup parameter value allowed) setter that sets value(s) into the field
-//									   NOTE: if the method to invoke resides at struct level, precede the method name with 'base.', for example, 'base.XYZ' where XYZ is method name to invoke
-//									   NOTE: setter method always intake a string parameter value
-//		9) `booltrue:"1"` 			// if field is defined, contains bool literal for true condition, such as 1 or true, that overrides default system bool literal value,
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-//		10) `boolfalse:"0"`			// if field is defined, contains bool literal for false condition, such as 0 or false, that overrides default system bool literal value
-//									   if bool literal value is determined by existence of outprefix and itself is blank, place a space in both booltrue and boolfalse (setting blank will negate literal override)
-// 		11) `uniqueid:"xyz"`		// if two or more struct field is set with the same uniqueid, then only the first encountered field with the same uniqueid will be used in marshal,
-//									   NOTE: if field is mutually exclusive with one or more uniqueId, then pos # should be named the same for all uniqueIds
-//		12) `skipblank:"false"`		// if true, then any fields that is blank string will be excluded from marshal (this only affects fields that are string)
-//		13) `skipzero:"false"`		// if true, then any fields that are 0, 0.00, time.Zero(), false, nil will be excluded from marshal (this only affects fields that are number, bool, time, pointer)
-//		14) `timeformat:"20060102"`	// for time.Time field, optional date time format, specified as:
-//											2006, 06 = year,
-//											01, 1, Jan, January = month,
-//											02, 2, _2 = day (_2 = width two, right justified)
-//											03, 3, 15 = hour (15 = 24 hour format)
-//											04, 4 = minute
-//											05, 5 = second
-//											PM pm = AM PM
-//		15) `outprefix:""`			// for marshal method, if field value is to precede with an output prefix, such as XYZ= (affects marshal queryParams / csv methods only)
-//									   WARNING: if csv is variable elements count, rather than fixed count ordinal, then csv MUST include outprefix for all fields in order to properly identify target struct field
-// 		16) `zeroblank:"false"`		// set true to set blank to data when value is 0, 0.00, or time.IsZero
-//		17) `validate:"==x"`		// if field has to match a specific value or the entire method call will fail, match data format as:
-//									   		==xyz (== refers to equal, for numbers and string match, xyz is data to match, case insensitive)
-//												[if == validate against one or more values, use ||]
-//									   		!=xyz (!= refers to not equal)
-//												[if != validate against one or more values, use &&]
-//											>=xyz >>xyz <<xyz <=xyz (greater equal, greater, less than, less equal; xyz must be int or float)
-//											:=Xyz where Xyz is a parameterless function defined at struct level, that performs validation, returns bool or error where true or nil indicates validation success
-//									   note: expected source data type for validate to be effective is string, int, float64; if field is blank and req = false, then validate will be skipped
-func MarshalStructToCSV(inputStructPtr interface{}, csvDelimiter string) (csvPayload string, err error) {
+// boolStructTags is the set of struct tag keys whose value is a bool literal, parsed via ParseBoolExtended
+var boolStructTags = map[string]bool{
+	"skipblank":   true,
+	"skipzero":    true,
+	"zeroblank":   true,
+	"usestringer": true,
+	"jsonnull":    true,
+	"trim":        true,
+	"encrypt":     true,
+	"attr":        true,
+	"truncate":    true,
+	"jsonraw":     true,
+	"nohash":      true,
+	"b64encode":   true,
+	"b64decode":   true,
+}
+
+// knownStructTags is the set of struct tag keys recognized by the struct helpers, used by ValidateKnownTags
+var knownStructTags = map[string]bool{
+	"def":            true,
+	"defenv":         true,
+	"req":            true,
+	"pos":            true,
+	"col":            true,
+	"type":           true,
+	"size":           true,
+	"range":          true,
+	"getter":         true,
+	"setter":         true,
+	"enum":           true,
+	"onlyif":         true,
+	"mask":           true,
+	"nohash":         true,
+	"booltrue":       true,
+	"boolfalse":      true,
+	"booltrue.csv":   true,
+	"boolfalse.csv":  true,
+	"booltrue.json":  true,
+	"boolfalse.json": true,
+	"uniqueid":       true,
+	"skipblank":      true,
+	"skipzero":       true,
+	"zeroblank":      true,
+	"timeformat":     true,
+	"timeformat2":    true,
+	"postime":        true,
+	"timezone":       true,
+	"tz":             true,
+	"durationformat": true,
+	"outprefix":      true,
+	"attr":           true,
+	"width":          true,
+	"align":          true,
+	"padchar":        true,
+	"truncate":       true,
+	"trim":           true,
+	"encrypt":        true,
+	"b64encode":      true,
+	"b64decode":      true,
+	"b64mode":        true,
+	"regex":          true,
+	"validate":       true,
+	"usestringer":    true,
+	"mapstyle":       true,
+	"exclusive":      true,
+	"group":          true,
+	"subdelim":       true,
+	"reqgroup":       true,
+	"csvheader":      true,
+	"jsonnull":       true,
+	"jsonraw":        true,
+}
+
+// ValidateKnownTags inspects the struct tags defined on each field of inputStructPtr,
+// and returns error for each tag key that is not recognized by the struct helpers (such as misspelled skipblnk instead of skipblank),
+// as well as each boolean tag (such as skipblank) whose value is not a bool literal recognized by ParseBoolExtended,
+// tagName is the custom tag name in use (such as json), which is also treated as recognized,
+// this is intended to be called at startup or within unit tests, to catch struct tag mistakes early
+func ValidateKnownTags(inputStructPtr interface{}, tagName string) []error {
+	if inputStructPtr == nil {
+		return []error{fmt.Errorf("InputStructPtr is Required")}
+	}
+
+	s := reflect.ValueOf(inputStructPtr)
+
+	if s.Kind() != reflect.Ptr {
+		return []error{fmt.Errorf("InputStructPtr Must Be Pointer")}
+	} else {
+		s = s.Elem()
+	}
+
+	if s.Kind() != reflect.Struct {
+		return []error{fmt.Errorf("InputStructPtr Must Be Struct")}
+	}
+
+	tagName = Trim(tagName)
+	var errs []error
+
+	for _, sf := range getStructFieldValues(s, tagName, false) {
+		keys, ok := parseStructTagKeys(sf.Field.Tag)
+
+		if !ok {
+			errs = append(errs, fmt.Errorf("Field %s Declares Malformed Struct Tag '%s'", sf.Field.Name, string(sf.Field.Tag)))
+		}
+
+		for _, k := range keys {
+			if !knownStructTags[k] && k != tagName {
+				errs = append(errs, fmt.Errorf("Field %s Declares Unknown Struct Tag '%s'", sf.Field.Name, k))
+			} else if boolStructTags[k] {
+				if _, err := ParseBoolExtended(sf.Field.Tag.Get(k)); err != nil {
+					errs = append(errs, fmt.Errorf("Field %s Declares Struct Tag '%s' With %s", sf.Field.Name, k, err))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// RequireTypedPositions inspects the csv struct tags defined on each field of inputStructPtr,
+// and returns error for each field declaring numeric pos without type tag (such field is not validated against its expected data type),
+// fields without pos, or with pos:"-" (setter driven field), are not required to declare type,
+// this is intended to be called within unit tests of strict fixed format structs, to ensure every positional field is typed
+func RequireTypedPositions(inputStructPtr interface{}) []error {
 	if inputStructPtr == nil {
-		return "", fmt.Errorf("InputStructPtr is Required")
+		return []error{fmt.Errorf("InputStructPtr is Required")}
 	}
 
 	s := reflect.ValueOf(inputStructPtr)
 
 	if s.Kind() != reflect.Ptr {
-		return "", fmt.Errorf("InputStructPtr Must Be Pointer")
+		return []error{fmt.Errorf("InputStructPtr Must Be Pointer")}
 	} else {
 		s = s.Elem()
 	}
 
 	if s.Kind() != reflect.Struct {
-		return "", fmt.Errorf("InputStructPtr Must Be Struct")
+		return []error{fmt.Errorf("InputStructPtr Must Be Struct")}
 	}
 
-	if !IsStructFieldSet(inputStructPtr) && StructNonDefaultRequiredFieldsCount(inputStructPtr) > 0 {
-		return "", nil
-	}
+	var errs []error
 
-	trueList := []string{"true", "yes", "on", "1", "enabled"}
+	for _, sf := range getStructFieldValues(s, "pos", false) {
+		field := sf.Field
+		tagPosBuf := Trim(field.Tag.Get("pos"))
 
-	csvList := make([]string, s.NumField())
-	csvLen := len(csvList)
+		if len(tagPosBuf) == 0 || tagPosBuf == "-" {
+			continue
+		}
 
-	for i := 0; i < csvLen; i++ {
-		csvList[i] = "{?}"	// indicates value not set, to be excluded
+		if LenTrim(field.Tag.Get("type")) == 0 {
+			errs = append(errs, fmt.Errorf("Field %s Declares pos %s Without type", field.Name, tagPosBuf))
+		}
 	}
 
-	uniqueMap := make(map[string]string)
+	return errs
+}
 
-	for i := 0; i < s.NumField(); i++ {
-		field := s.Type().Field(i)
+// parseStructTagKeys returns the keys declared in struct tag, following the conventional key:"value" format,
+// ok is false if the struct tag is not well formed, in which case keys parsed prior to the malformed portion are returned
+func parseStructTagKeys(tag reflect.StructTag) (keys []string, ok bool) {
+	t := string(tag)
 
-		if o := s.FieldByName(field.Name); o.IsValid() && o.CanSet() {
-			// extract struct tag values
-			tagPos, ok := ParseInt32(field.Tag.Get("pos"))
-			if !ok {
-				continue
-			} else if tagPos < 0 {
-				continue
-			} else if tagPos > csvLen-1 {
-				continue
-			}
+	for t != "" {
+		// skip leading space
+		i := 0
+		for i < len(t) && t[i] == ' ' {
+			i++
+		}
 
-			if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-				if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-					continue
-				} else {
-					uniqueMap[strings.ToLower(tagUniqueId)] = field.Name
-				}
-			}
+		t = t[i:]
 
-			tagType := Trim(strings.ToLower(field.Tag.Get("type")))
-			switch tagType {
-			case "a":
-				fallthrough
-			case "n":
-				fallthrough
-			case "an":
-				fallthrough
-			case "ans":
-				fallthrough
-			case "b":
-				fallthrough
-			case "b64":
-				fallthrough
-			case "regex":
-				fallthrough
-			case "h":
-				// valid type
-			default:
-				tagType = ""
-			}
+		if t == "" {
+			break
+		}
 
-			tagRegEx := Trim(field.Tag.Get("regex"))
-			if tagType != "regex" {
-				tagRegEx = ""
-			} else {
-				if LenTrim(tagRegEx) == 0 {
-					tagType = ""
-				}
-			}
+		// scan to colon, key must be non-blank and exclude space, quote, control characters
+		i = 0
+		for i < len(t) && t[i] > ' ' && t[i] != ':' && t[i] != '"' && t[i] != 0x7f {
+			i++
+		}
 
-			tagSize := Trim(strings.ToLower(field.Tag.Get("size")))
-			arModulo := strings.Split(tagSize, "+%")
-			tagModulo := 0
-			if len(arModulo) == 2 {
-				tagSize = arModulo[0]
-				if tagModulo, _ = ParseInt32(arModulo[1]); tagModulo < 0 {
-					tagModulo = 0
-				}
-			}
-			arSize := strings.Split(tagSize, "..")
-			sizeMin := 0
-			sizeMax := 0
-			if len(arSize) == 2 {
-				sizeMin, _ = ParseInt32(arSize[0])
-				sizeMax, _ = ParseInt32(arSize[1])
-			} else {
-				sizeMin, _ = ParseInt32(tagSize)
-				sizeMax = sizeMin
-			}
+		if i == 0 || i+1 >= len(t) || t[i] != ':' || t[i+1] != '"' {
+			return keys, false
+		}
 
-			tagRange := Trim(strings.ToLower(field.Tag.Get("range")))
-			arRange := strings.Split(tagRange, "..")
-			rangeMin := 0
-			rangeMax := 0
-			if len(arRange) == 2 {
-				rangeMin, _ = ParseInt32(arRange[0])
-				rangeMax, _ = ParseInt32(arRange[1])
-			} else {
-				rangeMin, _ = ParseInt32(tagRange)
-				rangeMax = rangeMin
-			}
+		keys = append(keys, t[:i])
+		t = t[i+1:]
 
-			tagReq := Trim(strings.ToLower(field.Tag.Get("req")))
-			if tagReq != "true" && tagReq != "false" {
-				tagReq = ""
+		// scan quoted value
+		i = 1
+		for i < len(t) && t[i] != '"' {
+			if t[i] == '\\' {
+				i++
 			}
 
-			// get csv value from current struct field
-			var boolTrue, boolFalse, timeFormat, outPrefix string
-			var skipBlank, skipZero, zeroBlank bool
-
-			if vs := GetStructTagsValueSlice(field, "booltrue", "boolfalse", "skipblank", "skipzero", "timeformat", "outprefix", "zeroblank"); len(vs) == 7 {
-				boolTrue = vs[0]
-				boolFalse = vs[1]
-				skipBlank, _ = ParseBool(vs[2])
-				skipZero, _ = ParseBool(vs[3])
-				timeFormat = vs[4]
-				outPrefix = vs[5]
-				zeroBlank, _ = ParseBool(vs[6])
-			}
+			i++
+		}
 
-			// cache old value prior to getter invoke
-			oldVal := o
-			hasGetter := false
+		if i >= len(t) {
+			return keys, false
+		}
 
-			if tagGetter := Trim(field.Tag.Get("getter")); len(tagGetter) > 0 {
-				hasGetter = true
+		t = t[i+1:]
+	}
 
-				isBase := false
-				useParam := false
-				paramVal := ""
-				var paramSlice interface{}
+	return keys, true
+}
 
-				if strings.ToLower(Left(tagGetter, 5)) == "base." {
-					isBase = true
-					tagGetter = Right(tagGetter, len(tagGetter)-5)
-				}
+// validateCSVStructPosDuplicates returns errors for fields declaring the same pos without being linked by the same uniqueid,
+// fields sharing pos are legal only when each declares the same uniqueid (mutually exclusive fields)
+func validateCSVStructPosDuplicates(fields []structFieldValue) (errs []error) {
+	type posField struct {
+		name     string
+		uniqueId string
+	}
 
-				if strings.ToLower(Right(tagGetter, 3)) == "(x)" {
-					useParam = true
+	posMap := make(map[int]posField)
 
-					if o.Kind() != reflect.Slice {
-						paramVal, _, _ = ReflectValueToString(o, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank)
-					} else {
-						if o.Len() > 0 {
-							paramSlice = o.Slice(0, o.Len()).Interface()
-						}
-					}
+	for _, sf := range fields {
+		tagPos, ok := ParseInt32(sf.Field.Tag.Get("pos"))
 
-					tagGetter = Left(tagGetter, len(tagGetter)-3)
-				}
+		if !ok || tagPos < 0 {
+			continue
+		}
 
-				var ov []reflect.Value
-				var notFound bool
+		uniqueId := strings.ToLower(Trim(sf.Field.Tag.Get("uniqueid")))
 
-				if isBase {
-					if useParam {
-						if paramSlice == nil {
-							ov, notFound = ReflectCall(s.Addr(), tagGetter, paramVal)
-						} else {
-							ov, notFound = ReflectCall(s.Addr(), tagGetter, paramSlice)
-						}
-					} else {
-						ov, notFound = ReflectCall(s.Addr(), tagGetter)
-					}
-				} else {
-					if useParam {
-						if paramSlice == nil {
-							ov, notFound = ReflectCall(o, tagGetter, paramVal)
-						} else {
-							ov, notFound = ReflectCall(o, tagGetter, paramSlice)
-						}
-					} else {
-						ov, notFound = ReflectCall(o, tagGetter)
-					}
-				}
+		if prior, exists := posMap[tagPos]; exists {
+			if len(uniqueId) == 0 || uniqueId != prior.uniqueId {
+				errs = append(errs, fmt.Errorf("Fields %s and %s Both Declare pos %d Without Matching uniqueid", prior.name, sf.Field.Name, tagPos))
+			}
+		} else {
+			posMap[tagPos] = posField{name: sf.Field.Name, uniqueId: uniqueId}
+		}
+	}
 
-				if !notFound {
-					if len(ov) > 0 {
-						o = ov[0]
-					}
-				}
+	for _, sf := range fields {
+		if posTime, ok := ParseInt32(sf.Field.Tag.Get("postime")); ok && posTime >= 0 {
+			if prior, exists := posMap[posTime]; exists {
+				errs = append(errs, fmt.Errorf("Fields %s and %s Both Declare pos %d (postime)", prior.name, sf.Field.Name, posTime))
+			} else {
+				posMap[posTime] = posField{name: sf.Field.Name}
 			}
+		}
+	}
 
-			fv, skip, e := ReflectValueToString(o, boolTrue, boolFalse, skipBlank, skipZero, timeFormat, zeroBlank)
+	return errs
+}
 
-			if e != nil {
-				if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-					if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-						// remove uniqueid if skip
-						delete(uniqueMap, strings.ToLower(tagUniqueId))
-					}
-				}
+// MarshalSliceStructToCSV accepts a slice of struct pointer, then marshals each struct via MarshalStructToCSV into one csv line,
+// and returns the csv document with each csv line terminated by LF (\n),
+// To pass in inputSliceStructPtr, convert slice of actual objects at the calling code, using SliceObjectsToSliceInterface(),
+// opts (optional) overrides marshal defaults for this call and each struct, such as BlankOutputReturnsError
+func MarshalSliceStructToCSV(inputSliceStructPtr []interface{}, csvDelimiter string, opts ...*MarshalOptions) (csvDocument string, err error) {
+	return marshalSliceStructToCSVDocument(inputSliceStructPtr, csvDelimiter, "\n", false, getMarshalOptions(opts))
+}
 
-				return "", e
-			}
+// MarshalStructsToExcelCSV accepts a slice of struct pointer, then marshals each struct via MarshalStructToCSV into one csv line,
+// and returns the csv document that is Excel friendly, where the document is prefixed with UTF-8 BOM,
+// and each csv line is terminated by CRLF (\r\n),
+// To pass in inputSliceStructPtr, convert slice of actual objects at the calling code, using SliceObjectsToSliceInterface(),
+// opts (optional) overrides marshal defaults for this call and each struct, such as BlankOutputReturnsError
+func MarshalStructsToExcelCSV(inputSliceStructPtr []interface{}, csvDelimiter string, opts ...*MarshalOptions) (csvDocument string, err error) {
+	return marshalSliceStructToCSVDocument(inputSliceStructPtr, csvDelimiter, "\r\n", true, getMarshalOptions(opts))
+}
 
-			if skip {
-				if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-					if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-						// remove uniqueid if skip
-						delete(uniqueMap, strings.ToLower(tagUniqueId))
-					}
-				}
+// marshalSliceStructToCSVDocument marshals each struct pointer in slice into csv line terminated by lineTerminator,
+// if includeBOM is true, the UTF-8 BOM is prefixed to the csv document,
+// struct that yields blank csv line is excluded from csv document, opts may be nil
+func marshalSliceStructToCSVDocument(inputSliceStructPtr []interface{}, csvDelimiter string, lineTerminator string, includeBOM bool, opts *MarshalOptions) (string, error) {
+	if len(inputSliceStructPtr) == 0 {
+		if !opts.blankOutputReturnsError() {
+			return "", nil
+		}
 
-				continue
-			}
+		return "", fmt.Errorf("Input Slice Struct Pointer Nil")
+	}
 
-			defVal := field.Tag.Get("def")
+	var buf strings.Builder
 
-			if oldVal.Kind() == reflect.Int && oldVal.Int() == 0 && strings.ToLower(fv) == "unknown" {
-				// unknown enum value will be serialized as blank
-				fv = ""
+	if includeBOM {
+		buf.WriteString("\xEF\xBB\xBF")
+	}
 
-				if len(defVal) > 0 {
-					fv = defVal
-				} else {
-					if tagUniqueId := Trim(field.Tag.Get("uniqueid")); len(tagUniqueId) > 0 {
-						if _, ok := uniqueMap[strings.ToLower(tagUniqueId)]; ok {
-							// remove uniqueid if skip
-							delete(uniqueMap, strings.ToLower(tagUniqueId))
-							continue
-						}
-					}
-				}
-			}
+	lineCount := 0
 
-			// validate output csv value
-			if oldVal.Kind() != reflect.Slice {
-				origFv := fv
+	for _, v := range inputSliceStructPtr {
+		if line, e := marshalStructToCSV(v, csvDelimiter, false, nil, opts); e != nil {
+			return "", fmt.Errorf("MarshalSliceStructToCSV Failed: %s", e)
+		} else if len(line) > 0 {
+			buf.WriteString(line)
+			buf.WriteString(lineTerminator)
+			lineCount++
+		}
+	}
 
-				switch tagType {
-				case "a":
-					fv, _ = ExtractAlpha(fv)
-				case "n":
-					fv, _ = ExtractNumeric(fv)
-				case "an":
-					fv, _ = ExtractAlphaNumeric(fv)
-				case "ans":
-					if !hasGetter {
-						fv, _ = ExtractAlphaNumericPrintableSymbols(fv)
-					}
-				case "b":
-					if len(boolTrue) == 0 && len(boolFalse) == 0 {
-						if StringSliceContains(&trueList, strings.ToLower(fv)) {
-							fv = "true"
-						} else {
-							fv = "false"
-						}
-					} else {
-						if Trim(boolTrue) == Trim(boolFalse) {
-							if fv == "false" {
-								fv = ""
-								csvList[tagPos] = fv
-								continue
-							}
-						}
-					}
-				case "regex":
-					fv, _ = ExtractByRegex(fv, tagRegEx)
-				case "h":
-					fv, _ = ExtractHex(fv)
-				case "b64":
-					fv, _ = ExtractAlphaNumericPrintableSymbols(fv)
-				}
+	if lineCount == 0 {
+		if !opts.blankOutputReturnsError() {
+			return "", nil
+		}
 
-				if boolFalse == " " && origFv == "false" && len(outPrefix) > 0 {
-					// just in case fv is not defined type type b
-					fv = ""
-					csvList[tagPos] = fv
-					continue
-				}
+		return "", fmt.Errorf("MarshalSliceStructToCSV Yielded Blank String")
+	}
 
-				if len(fv) == 0 && len(defVal) > 0 {
-					fv = defVal
-				}
+	return buf.String(), nil
+}
 
-				if tagType == "a" || tagType == "an" || tagType == "ans" || tagType == "n" || tagType == "regex" || tagType == "h" || tagType == "b64" {
-					if sizeMin > 0 && len(fv) > 0 {
-						if len(fv) < sizeMin {
-							return "", fmt.Errorf("%s Min Length is %d", field.Name, sizeMin)
-						}
-					}
+// ExampleJSON returns example json payload for the given struct type, such as for api documentation,
+// the example struct is populated with def tag values, and fields without def value are populated with type appropriate placeholders,
+// then marshaled via MarshalStructToJson using tagName, structType may be struct or pointer to struct type
+func ExampleJSON(structType reflect.Type, tagName string) (string, error) {
+	v, err := newExampleStruct(structType, tagName)
 
-					if sizeMax > 0 && len(fv) > sizeMax {
-						fv = Left(fv, sizeMax)
-					}
+	if err != nil {
+		return "", fmt.Errorf("ExampleJSON Failed: %s", err)
+	}
 
-					if tagModulo > 0 {
-						if len(fv)%tagModulo != 0 {
-							return "", fmt.Errorf("Struct Field %s Expects Value In Blocks of %d Characters", field.Name, tagModulo)
-						}
-					}
-				}
+	return MarshalStructToJson(v, tagName, "")
+}
 
-				if tagType == "n" {
-					n, ok := ParseInt32(fv)
+// ExampleCSV returns example csv payload for the given struct type, such as for api documentation,
+// the example struct is populated with def tag values, and fields without def value are populated with type appropriate placeholders,
+// then marshaled via MarshalStructToCSV using comma delimiter, structType may be struct or pointer to struct type
+func ExampleCSV(structType reflect.Type) (string, error) {
+	v, err := newExampleStruct(structType, "pos")
 
-					if ok {
-						if rangeMin > 0 {
-							if n < rangeMin {
-								if !(n == 0 && tagReq != "true") {
-									return "", fmt.Errorf("%s Range Minimum is %d", field.Name, rangeMin)
-								}
-							}
-						}
+	if err != nil {
+		return "", fmt.Errorf("ExampleCSV Failed: %s", err)
+	}
 
-						if rangeMax > 0 {
-							if n > rangeMax {
-								return "", fmt.Errorf("%s Range Maximum is %d", field.Name, rangeMax)
-							}
-						}
-					}
-				}
+	return MarshalStructToCSV(v, ",")
+}
 
-				if tagReq == "true" && len(fv) == 0 {
-					return "", fmt.Errorf("%s is a Required Field", field.Name)
-				}
-			}
+// newExampleStruct creates new struct pointer of structType, populated with def tag values,
+// zero value fields without def value are set with placeholder honoring validate, type, size and range tags where practical
+func newExampleStruct(structType reflect.Type, tagName string) (interface{}, error) {
+	if structType == nil {
+		return nil, fmt.Errorf("Struct Type is Required")
+	}
 
-			// validate if applicable
-			if valData := Trim(field.Tag.Get("validate")); len(valData) >= 3 {
-				valComp := Left(valData, 2)
-				valData = Right(valData, len(valData)-2)
-
-				switch valComp {
-				case "==":
-					valAr := strings.Split(valData, "||")
-
-					if len(valAr) <= 1 {
-						if strings.ToLower(fv) != strings.ToLower(valData) {
-							if len(fv) > 0 || tagReq == "true" {
-								return "", fmt.Errorf("%s Validation Failed: Expected To Match '%s', But Received '%s'", field.Name, valData, fv)
-							}
-						}
-					} else {
-						found := false
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
 
-						for _, va := range valAr {
-							if strings.ToLower(fv) == strings.ToLower(va) {
-								found = true
-								break
-							}
-						}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Struct Type Must Be Struct")
+	}
 
-						if !found && (len(fv) > 0 || tagReq == "true") {
-							return "", fmt.Errorf("%s Validation Failed: Expected To Match '%s', But Received '%s'", field.Name, strings.ReplaceAll(valData, "||", " or "), fv)
-						}
-					}
-				case "!=":
-					valAr := strings.Split(valData, "&&")
+	p := reflect.New(structType)
+	SetStructFieldDefaultValues(p.Interface())
 
-					if len(valAr) <= 1 {
-						if strings.ToLower(fv) == strings.ToLower(valData) {
-							if len(fv) > 0 || tagReq == "true" {
-								return "", fmt.Errorf("%s Validation Failed: Expected To Not Match '%s', But Received '%s'", field.Name, valData, fv)
-							}
-						}
-					} else {
-						found := false
+	for _, sf := range getStructFieldValues(p.Elem(), tagName, true) {
+		field := sf.Field
+		o := sf.Value
 
-						for _, va := range valAr {
-							if strings.ToLower(fv) == strings.ToLower(va) {
-								found = true
-								break
-							}
-						}
+		if !o.IsValid() || !o.CanSet() || !o.IsZero() || len(getStructFieldDefaultValue(field)) > 0 {
+			continue
+		}
 
-						if found && (len(fv) > 0 || tagReq == "true") {
-							return "", fmt.Errorf("%s Validation Failed: Expected To Not Match '%s', But Received '%s'", field.Name, strings.ReplaceAll(valData, "&&", " and "), fv)
-						}
-					}
-				case "<=":
-					if valNum, valOk := ParseFloat64(valData); valOk {
-						if srcNum, _ := ParseFloat64(fv); srcNum > valNum {
-							if len(fv) > 0 || tagReq == "true" {
-								return "", fmt.Errorf("%s Validation Failed: Expected To Be Less or Equal To '%s', But Received '%s'", field.Name, valData, fv)
-							}
-						}
-					}
-				case "<<":
-					if valNum, valOk := ParseFloat64(valData); valOk {
-						if srcNum, _ := ParseFloat64(fv); srcNum >= valNum {
-							if len(fv) > 0 || tagReq == "true" {
-								return "", fmt.Errorf("%s Validation Failed: Expected To Be Less Than '%s', But Received '%s'", field.Name, valData, fv)
-							}
-						}
-					}
-				case ">=":
-					if valNum, valOk := ParseFloat64(valData); valOk {
-						if srcNum, _ := ParseFloat64(fv); srcNum < valNum {
-							if len(fv) > 0 || tagReq == "true" {
-								return "", fmt.Errorf("%s Validation Failed: Expected To Be Greater or Equal To '%s', But Received '%s'", field.Name, valData, fv)
-							}
-						}
-					}
-				case ">>":
-					if valNum, valOk := ParseFloat64(valData); valOk {
-						if srcNum, _ := ParseFloat64(fv); srcNum <= valNum {
-							if len(fv) > 0 || tagReq == "true" {
-								return "", fmt.Errorf("%s Validation Failed: Expected To Be Greater Than '%s', But Received '%s'", field.Name, valData, fv)
-							}
-						}
-					}
-				case ":=":
-					if len(valData) > 0 {
-						if retV, nf := ReflectCall(s.Addr(), valData); !nf {
-							if len(retV) > 0 {
-								if retV[0].Kind() == reflect.Bool && !retV[0].Bool() {
-									// validation failed with bool false
-									return "", fmt.Errorf("%s Validation Failed: %s() Returned Result is False", field.Name, valData)
-								} else if retErr := DerefError(retV[0]); retErr != nil {
-									// validation failed with error
-									return "", fmt.Errorf("%s Validation On %s() Failed: %s", field.Name, valData, retErr.Error())
-								}
-							}
-						}
-					}
-				}
+		tags := parseStructFieldValidateTags(field)
+
+		switch o.Kind() {
+		case reflect.String:
+			o.SetString(getExampleStringValue(field, tags))
+		case reflect.Bool:
+			o.SetBool(true)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if o.Type() == reflect.TypeOf(time.Duration(0)) {
+				o.SetInt(int64(time.Second))
+			} else if tags.RangeMin > 0 {
+				o.SetInt(int64(tags.RangeMin))
+			} else {
+				o.SetInt(1)
 			}
-
-			// store fv into sorted slice
-			if skipBlank && LenTrim(fv) == 0 {
-				csvList[tagPos] = ""
-			} else if skipZero && fv == "0" {
-				csvList[tagPos] = ""
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if tags.RangeMin > 0 {
+				o.SetUint(uint64(tags.RangeMin))
 			} else {
-				csvList[tagPos] = outPrefix + fv
+				o.SetUint(1)
+			}
+		case reflect.Float32, reflect.Float64:
+			o.SetFloat(1.5)
+		case reflect.Struct:
+			if o.Type() == reflect.TypeOf(time.Time{}) {
+				o.Set(reflect.ValueOf(time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)))
 			}
 		}
 	}
 
-	for _, v := range csvList {
-		if v != "{?}" {
-			if LenTrim(csvPayload) > 0 {
-				csvPayload += csvDelimiter
-			}
+	return p.Interface(), nil
+}
 
-			csvPayload += v
-		}
+// getExampleStringValue returns example placeholder string for field, honoring validate == rule, type and size tags
+func getExampleStringValue(field reflect.StructField, tags structFieldValidateTags) string {
+	if valData := Trim(field.Tag.Get("validate")); len(valData) >= 3 && Left(valData, 2) == "==" {
+		return strings.Split(Right(valData, len(valData)-2), "||")[0]
 	}
 
-	return csvPayload, nil
-}
+	v := "example"
+
+	switch tags.Type {
+	case "n":
+		v = "1"
+	case "h":
+		v = "0a"
+	case "b":
+		v = "true"
+	case "b64":
+		v = "ZXhhbXBsZQ=="
+	case "email":
+		v = "user@example.com"
+	case "url":
+		v = "https://example.com"
+	case "uuid":
+		v = "123e4567-e89b-42d3-a456-426614174000"
+	}
+
+	if tags.SizeMin > len(v) {
+		v += strings.Repeat(Right(v, 1), tags.SizeMin-len(v))
+	}
 
+	if tags.SizeMax > 0 && len(v) > tags.SizeMax {
+		v = Left(v, tags.SizeMax)
+	}
+
+	if tags.Modulo > 0 && len(v)%tags.Modulo != 0 {
+		v += strings.Repeat(Right(v, 1), tags.Modulo-len(v)%tags.Modulo)
+	}
 
+	return v
+}
@@ -0,0 +1,1708 @@
+package helper
+
+/*
+ * Copyright 2020-2021 Aldelo, LP
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalJsonToStructStrict_TaggedFieldNameIsUnknown(t *testing.T) {
+	type order struct {
+		Amount int `json:"amt"`
+		Note   string
+	}
+
+	o := &order{}
+
+	if err := UnmarshalJsonToStructStrict(o, `{"Amount":5}`, "json", ""); err == nil {
+		t.Fatalf("expected unknown key error for Go field name of tagged field, got nil (Amount=%d)", o.Amount)
+	}
+
+	if err := UnmarshalJsonToStructStrict(o, `{"amt":5,"Note":"x"}`, "json", ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if o.Amount != 5 || o.Note != "x" {
+		t.Fatalf("expected Amount=5 Note=x, got Amount=%d Note=%s", o.Amount, o.Note)
+	}
+}
+
+func TestUnmarshalCSVToStruct_CrossFieldValidateUsesParsedSibling(t *testing.T) {
+	type rangeInt struct {
+		Start int `pos:"0" validate:"<=#End"`
+		End   int `pos:"1"`
+	}
+
+	r := &rangeInt{}
+
+	if err := UnmarshalCSVToStruct(r, "1,5", ",", nil); err != nil {
+		t.Fatalf("expected valid range to pass, got: %s", err)
+	}
+
+	if r.Start != 1 || r.End != 5 {
+		t.Fatalf("expected Start=1 End=5, got Start=%d End=%d", r.Start, r.End)
+	}
+
+	if err := UnmarshalCSVToStruct(r, "9,5", ",", nil); err == nil {
+		t.Fatalf("expected inverted range to fail")
+	}
+
+	type rangeTime struct {
+		From time.Time `pos:"0" timeformat:"2006-01-02" validate:"<=#To"`
+		To   time.Time `pos:"1" timeformat:"2006-01-02"`
+	}
+
+	rt := &rangeTime{}
+
+	if err := UnmarshalCSVToStruct(rt, "2024-01-01,2024-05-01", ",", nil); err != nil {
+		t.Fatalf("expected valid time range to pass, got: %s", err)
+	}
+
+	if err := UnmarshalCSVToStruct(rt, "2024-05-01,2024-01-01", ",", nil); err == nil {
+		t.Fatalf("expected inverted time range to fail")
+	}
+}
+
+func TestMarshalStructToJsonEncrypted_RoundTrip(t *testing.T) {
+	type account struct {
+		ID     string `json:"id"`
+		Secret string `json:"secret" encrypt:"true"`
+		Pin    int    `json:"pin" encrypt:"true"`
+	}
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	a := &account{ID: "a1", Secret: "s3cr3t value", Pin: 1234}
+
+	out, err := MarshalStructToJsonEncrypted(a, "json", "", key)
+
+	if err != nil {
+		t.Fatalf("marshal failed: %s", err)
+	}
+
+	if strings.Contains(out, "s3cr3t") || strings.Contains(out, "1234") {
+		t.Fatalf("encrypted output contains clear text: %s", out)
+	}
+
+	b := &account{}
+
+	if err := UnmarshalJsonToStructEncrypted(b, out, "json", "", key); err != nil {
+		t.Fatalf("unmarshal failed: %s", err)
+	}
+
+	if *b != *a {
+		t.Fatalf("round trip mismatch, expected %+v, got %+v", *a, *b)
+	}
+
+	if err := UnmarshalJsonToStructEncrypted(&account{}, out, "json", "", []byte("fedcba9876543210fedcba9876543210")); err == nil {
+		t.Fatalf("expected wrong key to fail unmarshal")
+	}
+}
+
+func TestMarshalStructToJsonEncrypted_RejectsSliceAndMap(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	type withSlice struct {
+		Tags []string `json:"tags" encrypt:"true"`
+	}
+
+	if out, err := MarshalStructToJsonEncrypted(&withSlice{Tags: []string{"secret"}}, "json", "", key); err == nil {
+		t.Fatalf("expected error for encrypted slice field, got %s", out)
+	}
+
+	type withMap struct {
+		Attrs map[string]string `json:"attrs" encrypt:"true"`
+	}
+
+	if out, err := MarshalStructToJsonEncrypted(&withMap{Attrs: map[string]string{"k": "secret"}}, "json", "", key); err == nil {
+		t.Fatalf("expected error for encrypted map field, got %s", out)
+	}
+}
+
+func TestMarshalStructToJsonIndent_NestedStructRoundTrip(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+
+	type customer struct {
+		Name    string   `json:"name"`
+		Home    address  `json:"home"`
+		Billing *address `json:"billing"`
+		Missing *address `json:"missing"`
+	}
+
+	a := &customer{Name: "Ann", Home: address{City: "Reno", Zip: "89501"}, Billing: &address{City: "Elko", Zip: "89801"}}
+
+	out, err := MarshalStructToJson(a, "json", "")
+
+	if err != nil {
+		t.Fatalf("marshal failed: %s", err)
+	}
+
+	if !strings.Contains(out, `"home":{"city":"Reno", "zip":"89501"}`) || !strings.Contains(out, `"billing":{"city":"Elko", "zip":"89801"}`) {
+		t.Fatalf("nested struct not rendered as json object: %s", out)
+	}
+
+	indented, err := MarshalStructToJsonIndent(a, "json", "", "  ")
+
+	if err != nil {
+		t.Fatalf("marshal indent failed: %s", err)
+	}
+
+	if !strings.Contains(indented, "\n    \"city\": \"Reno\"") {
+		t.Fatalf("nested struct not indented: %s", indented)
+	}
+
+	for _, payload := range []string{out, indented} {
+		b := &customer{}
+
+		if err := UnmarshalJsonToStruct(b, payload, "json", ""); err != nil {
+			t.Fatalf("unmarshal failed: %s", err)
+		}
+
+		if b.Name != a.Name || b.Home != a.Home || b.Billing == nil || *b.Billing != *a.Billing || b.Missing != nil {
+			t.Fatalf("round trip mismatch, expected %+v, got %+v", *a, *b)
+		}
+	}
+}
+
+type fixedWidthAmount struct {
+	Code   string `pos:"0" width:"3"`
+	Amount int    `pos:"1" width:"5" align:"right" padchar:"0"`
+}
+
+func (f *fixedWidthAmount) FixedWidthRecordLength() int {
+	return 8
+}
+
+func TestMarshalStructToFixedWidth_ZeroPadSignAndZeroValue(t *testing.T) {
+	cases := []struct {
+		amount int
+		record string
+	}{
+		{0, "ABC00000"},
+		{-5, "ABC-0005"},
+		{42, "ABC00042"},
+	}
+
+	for _, c := range cases {
+		out, err := MarshalStructToFixedWidth(&fixedWidthAmount{Code: "ABC", Amount: c.amount})
+
+		if err != nil {
+			t.Fatalf("marshal %d failed: %s", c.amount, err)
+		}
+
+		if out != c.record {
+			t.Fatalf("marshal %d expected %q, got %q", c.amount, c.record, out)
+		}
+
+		b := &fixedWidthAmount{Amount: 99}
+
+		if err := UnmarshalFixedWidthToStruct(b, out); err != nil {
+			t.Fatalf("unmarshal %q failed: %s", out, err)
+		}
+
+		if b.Code != "ABC" || b.Amount != c.amount {
+			t.Fatalf("unmarshal %q expected amount %d, got %+v", out, c.amount, *b)
+		}
+	}
+}
+
+func TestMarshalStructToFixedWidth_UnsetStructFails(t *testing.T) {
+	type required struct {
+		Code string `pos:"0" width:"3" req:"true"`
+	}
+
+	if out, err := MarshalStructToFixedWidth(&required{}); err == nil {
+		t.Fatalf("expected error for unset struct, got %q", out)
+	}
+}
+
+func TestStreamCSVToStructs_HandlerErrorIsWrapped(t *testing.T) {
+	type row struct {
+		Name string `pos:"0"`
+	}
+
+	errStop := errors.New("stop")
+
+	err := StreamCSVToStructs(strings.NewReader("a\nb\n"), ",", func() interface{} { return &row{} }, func(interface{}, int) error {
+		return errStop
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected handler error to be wrapped, got %v", err)
+	}
+}
+
+func TestStreamCSVToStructs_LineExceedsMaxLineSize(t *testing.T) {
+	type row struct {
+		Name string `pos:"0"`
+	}
+
+	var seen []string
+
+	payload := "short\n" + strings.Repeat("x", 100) + "\nafter\n"
+
+	err := StreamCSVToStructs(strings.NewReader(payload), ",", func() interface{} { return &row{} }, func(v interface{}, _ int) error {
+		seen = append(seen, v.(*row).Name)
+		return nil
+	}, 32)
+
+	if err == nil || !strings.Contains(err.Error(), "Read Failed at Line 2") {
+		t.Fatalf("expected read failure at line 2, got %v", err)
+	}
+
+	if len(seen) != 1 || seen[0] != "short" {
+		t.Fatalf("expected only line 1 handled before overflow, got %v", seen)
+	}
+
+	if err := StreamCSVToStructs(strings.NewReader(payload), ",", func() interface{} { return &row{} }, func(interface{}, int) error { return nil }, 128); err != nil {
+		t.Fatalf("line within max line size should stream, got %v", err)
+	}
+}
+
+func TestValidationErrors_IsAndAsWalkFieldErrors(t *testing.T) {
+	type order struct {
+		Code string `pos:"0" type:"a" size:"3..5"`
+		Name string `pos:"1" type:"a" size:"2..10"`
+	}
+
+	_, err := MarshalStructToCSVAggregate(&order{Code: "A", Name: "B"}, ",")
+
+	if err == nil {
+		t.Fatalf("expected validation errors")
+	}
+
+	wrapped := fmt.Errorf("submit failed: %w", err)
+
+	var ve ValidationErrors
+
+	if !errors.As(wrapped, &ve) || len(ve) < 2 {
+		t.Fatalf("expected ValidationErrors with 2 field errors, got %v", err)
+	}
+
+	var fe FieldError
+
+	if !errors.As(wrapped, &fe) || fe.Field != ve[0].Field {
+		t.Fatalf("expected errors.As to extract first FieldError %+v, got %+v", ve[0], fe)
+	}
+
+	if !errors.Is(wrapped, ve[1]) {
+		t.Fatalf("expected errors.Is to match field error %+v", ve[1])
+	}
+
+	if errors.Is(wrapped, FieldError{Field: "Other"}) {
+		t.Fatalf("unexpected errors.Is match")
+	}
+}
+
+func TestMarshalStruct_BoolTagTypoFails(t *testing.T) {
+	type typo struct {
+		Name string `json:"name" pos:"0" skipblank:"ture"`
+	}
+
+	if out, err := MarshalStructToJson(&typo{Name: "x"}, "json", ""); err == nil {
+		t.Fatalf("expected json marshal error for skipblank typo, got %s", out)
+	}
+
+	if out, err := MarshalStructToCSV(&typo{Name: "x"}, ","); err == nil {
+		t.Fatalf("expected csv marshal error for skipblank typo, got %s", out)
+	}
+
+	type valid struct {
+		Code string `json:"code"`
+		Name string `json:"name" skipblank:"Yes"`
+	}
+
+	if out, err := MarshalStructToJson(&valid{Code: "A"}, "json", ""); err != nil || out != `{"code":"A"}` {
+		t.Fatalf("expected skipblank yes to omit blank name, got %s, %v", out, err)
+	}
+}
+
+// benchTwentyFields is a 20 field struct for struct tag cache benchmarks,
+// run with -benchtime=100000x to marshal 100k times
+type benchTwentyFields struct {
+	F01 string    `json:"f01" pos:"0" skipblank:"true"`
+	F02 string    `json:"f02" pos:"1" size:"0..20"`
+	F03 int       `json:"f03" pos:"2" skipzero:"true"`
+	F04 int64     `json:"f04" pos:"3"`
+	F05 float64   `json:"f05" pos:"4" zeroblank:"true"`
+	F06 bool      `json:"f06" pos:"5" booltrue:"Y" boolfalse:"N"`
+	F07 bool      `json:"f07" pos:"6" booltrue.csv:"1" boolfalse.csv:"0"`
+	F08 time.Time `json:"f08" pos:"7" timeformat:"20060102"`
+	F09 string    `json:"f09" pos:"8" outprefix:"x"`
+	F10 string    `json:"f10" pos:"9" def:"none"`
+	F11 string    `json:"f11" pos:"10" type:"a"`
+	F12 string    `json:"f12" pos:"11" type:"an"`
+	F13 int       `json:"f13" pos:"12" type:"n" range:"0..1000"`
+	F14 string    `json:"f14" pos:"13" trim:"true"`
+	F15 uint      `json:"f15" pos:"14"`
+	F16 float32   `json:"f16" pos:"15"`
+	F17 string    `json:"f17" pos:"16" skipblank:"yes"`
+	F18 int       `json:"f18" pos:"17" skipzero:"on"`
+	F19 string    `json:"f19" pos:"18"`
+	F20 bool      `json:"f20" pos:"19"`
+}
+
+func newBenchTwentyFields() *benchTwentyFields {
+	return &benchTwentyFields{
+		F01: "alpha", F02: "beta", F03: 3, F04: 4, F05: 5.5, F06: true, F07: true, F08: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		F09: "nine", F11: "eleven", F12: "twelve12", F13: 13, F14: " fourteen ", F15: 15, F16: 16.5, F17: "seventeen", F18: 18, F19: "nineteen", F20: true,
+	}
+}
+
+func BenchmarkMarshalStructToCSV_TagCache(b *testing.B) {
+	v := newBenchTwentyFields()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := MarshalStructToCSV(v, ","); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalStructToCSV_NoTagCache(b *testing.B) {
+	v := newBenchTwentyFields()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		ClearStructTagCache()
+
+		if _, err := MarshalStructToCSV(v, ","); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalStructToJson_TagCache(b *testing.B) {
+	v := newBenchTwentyFields()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := MarshalStructToJson(v, "json", ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalStructToJson_NoTagCache(b *testing.B) {
+	v := newBenchTwentyFields()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		ClearStructTagCache()
+
+		if _, err := MarshalStructToJson(v, "json", ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalStructToJsonWriter_Allocs(b *testing.B) {
+	v := newBenchTwentyFields()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := MarshalStructToJsonWriter(ioutil.Discard, v, "json", ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalSliceStructToJsonStream_10k(b *testing.B) {
+	items := make([]interface{}, 10000)
+
+	for i := range items {
+		items[i] = newBenchTwentyFields()
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := MarshalSliceStructToJsonStream(ioutil.Discard, items, "json", ""); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// csvLineGenReader generates n csv lines on demand, so stream benchmarks do not hold the payload in memory
+type csvLineGenReader struct {
+	n   int
+	i   int
+	buf []byte
+}
+
+func (r *csvLineGenReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.i >= r.n {
+			return 0, io.EOF
+		}
+
+		r.i++
+		r.buf = []byte(fmt.Sprintf("ORD%06d,Item %d,%d,%d.25,true\n", r.i, r.i, r.i%100, r.i))
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func BenchmarkStreamCSVToStructs_100k(b *testing.B) {
+	type row struct {
+		ID     string  `pos:"0"`
+		Name   string  `pos:"1"`
+		Qty    int     `pos:"2"`
+		Price  float64 `pos:"3"`
+		Active bool    `pos:"4"`
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		count := 0
+
+		err := StreamCSVToStructs(&csvLineGenReader{n: 100000}, ",", func() interface{} { return &row{} }, func(interface{}, int) error {
+			count++
+			return nil
+		})
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		if count != 100000 {
+			b.Fatalf("streamed %d rows, want 100000", count)
+		}
+	}
+}
+
+func TestBlankOutputReturnsError_PerCallOverride(t *testing.T) {
+	type blank struct {
+		Name string `json:"name" pos:"0" skipblank:"true"`
+	}
+
+	off := false
+	on := true
+	empty := []interface{}{}
+	blanks := []interface{}{&blank{}}
+
+	// package default is true, per call override turns blank output error off
+	if out, err := MarshalSliceStructToJson(empty, "json", "", &MarshalOptions{BlankOutputReturnsError: &off}); err != nil || out != "[]" {
+		t.Fatalf("slice json override off expected [], got %q, %v", out, err)
+	}
+
+	if out, err := MarshalSliceStructToJson(blanks, "json", "", &MarshalOptions{BlankOutputReturnsError: &off}); err != nil || out != "[{}]" {
+		t.Fatalf("slice json override off expected [{}], got %q, %v", out, err)
+	}
+
+	if _, err := MarshalSliceStructToJson(blanks, "json", ""); err == nil {
+		t.Fatalf("slice json default expected blank output error")
+	}
+
+	if out, err := MarshalSliceStructToCSV(blanks, ",", &MarshalOptions{BlankOutputReturnsError: &off}); err != nil || out != "" {
+		t.Fatalf("slice csv override off expected blank, got %q, %v", out, err)
+	}
+
+	if _, err := MarshalSliceStructToCSV(blanks, ","); err == nil {
+		t.Fatalf("slice csv default expected blank output error")
+	}
+
+	if out, err := MarshalStructToTOML(&blank{}, "json", "", &MarshalOptions{BlankOutputReturnsError: &off}); err != nil || out != "" {
+		t.Fatalf("toml override off expected blank, got %q, %v", out, err)
+	}
+
+	if _, err := MarshalStructToTOML(&blank{}, "json", ""); err == nil {
+		t.Fatalf("toml default expected blank output error")
+	}
+
+	// package default turned off, per call override turns blank output error back on
+	SetBlankOutputReturnsError(false)
+	defer SetBlankOutputReturnsError(true)
+
+	if out, err := MarshalStructToTOML(&blank{}, "json", ""); err != nil || out != "" {
+		t.Fatalf("toml default off expected blank, got %q, %v", out, err)
+	}
+
+	if _, err := MarshalStructToTOML(&blank{}, "json", "", &MarshalOptions{BlankOutputReturnsError: &on}); err == nil {
+		t.Fatalf("toml override on expected blank output error")
+	}
+
+	if _, err := MarshalSliceStructToJson(empty, "json", "", &MarshalOptions{BlankOutputReturnsError: &on}); err == nil {
+		t.Fatalf("slice json override on expected error for empty slice")
+	}
+
+	if _, err := MarshalSliceStructToCSV(blanks, ",", &MarshalOptions{BlankOutputReturnsError: &on}); err == nil {
+		t.Fatalf("slice csv override on expected blank output error")
+	}
+}
+
+func TestMapToStruct_BlankValueAppliesDef(t *testing.T) {
+	type rec struct {
+		Name  string `json:"name" def:"N/A"`
+		Count int    `json:"count" def:"5"`
+		Note  string `json:"note"`
+	}
+
+	r := &rec{}
+
+	if err := MapToStruct(r, map[string]interface{}{"name": "", "count": "", "note": ""}, "json", ""); err != nil {
+		t.Fatalf("MapToStruct failed: %v", err)
+	}
+
+	if r.Name != "N/A" || r.Count != 5 || r.Note != "" {
+		t.Fatalf("blank values expected def defaults, got %+v", *r)
+	}
+
+	if err := MapToStruct(r, map[string]interface{}{"name": "abc", "count": 7}, "json", ""); err != nil {
+		t.Fatalf("MapToStruct failed: %v", err)
+	}
+
+	if r.Name != "abc" || r.Count != 7 {
+		t.Fatalf("non blank values expected as given, got %+v", *r)
+	}
+}
+
+func TestMarshalStructToJson_EmbeddedCollisionByTagName(t *testing.T) {
+	type base struct {
+		Ref     string `json:"id"`
+		Created string `json:"created"`
+	}
+
+	type rec struct {
+		base
+		ID string `json:"id"`
+	}
+
+	r := &rec{base: base{Ref: "inner", Created: "2020"}, ID: "outer"}
+
+	out, err := MarshalStructToJson(r, "json", "")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToJson failed: %v", err)
+	}
+
+	if strings.Contains(out, "inner") || strings.Count(out, `"id"`) != 1 || !strings.Contains(out, "outer") || !strings.Contains(out, "2020") {
+		t.Fatalf("outer tagged field expected to win collision, got %s", out)
+	}
+
+	r2 := &rec{}
+
+	if err := UnmarshalJsonToStruct(r2, out, "json", ""); err != nil {
+		t.Fatalf("UnmarshalJsonToStruct failed: %v", err)
+	}
+
+	if r2.ID != "outer" || r2.Ref != "" || r2.Created != "2020" {
+		t.Fatalf("round trip mismatch, got %+v", *r2)
+	}
+}
+
+func TestUniqueId_ConsistentAcrossJsonQueryAndCSV(t *testing.T) {
+	type rec struct {
+		Phone  string `json:"phone" pos:"0" uniqueid:"contact" skipblank:"true"`
+		Email  string `json:"email" pos:"0" uniqueid:"contact"`
+		Code   string `json:"code" pos:"1" uniqueid:"code"`
+		CodeV2 string `json:"code2" pos:"1" uniqueid:"code"`
+	}
+
+	r := &rec{Phone: "", Email: "a@b.c", Code: "X1", CodeV2: "X2"}
+
+	js, err := MarshalStructToJson(r, "json", "")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToJson failed: %v", err)
+	}
+
+	qp, err := MarshalStructToQueryParams(r, "json", "")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToQueryParams failed: %v", err)
+	}
+
+	csv, err := MarshalStructToCSV(r, ",")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToCSV failed: %v", err)
+	}
+
+	for _, out := range []string{js, qp, csv} {
+		if !strings.Contains(out, "a@b.c") || !strings.Contains(out, "X1") || strings.Contains(out, "X2") {
+			t.Fatalf("uniqueid resolution differs across formats, json=%s query=%s csv=%s", js, qp, csv)
+		}
+	}
+
+	if strings.Contains(js, "phone") || strings.Contains(qp, "phone") || csv != "a@b.c,X1" {
+		t.Fatalf("skipped field expected to release uniqueid, json=%s query=%s csv=%s", js, qp, csv)
+	}
+}
+
+type csvStatefulGetter struct {
+	Name  string `pos:"0" getter:"base.NextName"`
+	Calls int    `pos:"1"`
+}
+
+func (c *csvStatefulGetter) NextName() string {
+	c.Calls++
+	return c.Name
+}
+
+func TestMarshalStructToCSVCopy_ConcurrentStatefulGetter(t *testing.T) {
+	shared := &csvStatefulGetter{Name: "abc"}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if out, err := MarshalStructToCSVCopy(shared, ","); err != nil {
+				errs <- err
+			} else if out != "abc,1" {
+				errs <- fmt.Errorf("unexpected csv %q", out)
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if shared.Calls != 0 {
+		t.Fatalf("getter expected to mutate copy only, shared struct calls = %d", shared.Calls)
+	}
+}
+
+func TestMarshalStructToCSV_PosTimeRoundTrip(t *testing.T) {
+	type rec struct {
+		ID      string    `pos:"0"`
+		Settled time.Time `pos:"1" timeformat:"20060102" postime:"2" timeformat2:"150405"`
+		Amount  int       `pos:"3"`
+	}
+
+	ts := time.Date(2021, 3, 4, 15, 6, 7, 0, time.UTC)
+	r := &rec{ID: "A1", Settled: ts, Amount: 25}
+
+	out, err := MarshalStructToCSV(r, ",")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToCSV failed: %v", err)
+	}
+
+	if out != "A1,20210304,150607,25" {
+		t.Fatalf("expected date and time split into columns, got %q", out)
+	}
+
+	r2 := &rec{}
+
+	if err := UnmarshalCSVToStruct(r2, out, ",", nil); err != nil {
+		t.Fatalf("UnmarshalCSVToStruct failed: %v", err)
+	}
+
+	if r2.Settled.Format("20060102150405") != "20210304150607" || r2.ID != "A1" || r2.Amount != 25 {
+		t.Fatalf("round trip mismatch, got %+v", *r2)
+	}
+}
+
+func TestSetNumericOverflowMode_Int8Receives300(t *testing.T) {
+	type rec struct {
+		Small int8
+	}
+
+	defer SetNumericOverflowMode(NumericOverflowSkip)
+
+	cases := []struct {
+		mode    string
+		value   string
+		want    int8
+		wantErr bool
+	}{
+		{NumericOverflowSkip, "300", 7, false},
+		{NumericOverflowClamp, "300", 127, false},
+		{NumericOverflowClamp, "-300", -128, false},
+		{NumericOverflowError, "300", 7, true},
+	}
+
+	for _, c := range cases {
+		if err := SetNumericOverflowMode(c.mode); err != nil {
+			t.Fatalf("SetNumericOverflowMode(%s) failed: %v", c.mode, err)
+		}
+
+		r := &rec{Small: 7}
+		err := ReflectStringToField(reflect.ValueOf(r).Elem().Field(0), c.value, "")
+
+		if (err != nil) != c.wantErr || r.Small != c.want {
+			t.Fatalf("mode %s value %s: got %d, %v, want %d, error %v", c.mode, c.value, r.Small, err, c.want, c.wantErr)
+		}
+	}
+
+	if err := SetNumericOverflowMode("wrap"); err == nil {
+		t.Fatalf("expected error for unknown overflow mode")
+	}
+}
+
+func TestMarshalStructToQueryString_RoundTripsSpecialCharacters(t *testing.T) {
+	type rec struct {
+		A string `json:"a"`
+		B string `json:"b"`
+		C string `json:"c"`
+		D string `json:"d"`
+		E string `json:"e"`
+		F string `json:"f"`
+	}
+
+	r := &rec{A: "x&y", B: "k=v", C: "1+1", D: "50%", E: "a b", F: "日本#語"}
+
+	qs, err := MarshalStructToQueryString(r, "json", "")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToQueryString failed: %v", err)
+	}
+
+	values, err := url.ParseQuery(qs)
+
+	if err != nil {
+		t.Fatalf("ParseQuery of %q failed: %v", qs, err)
+	}
+
+	want := map[string]string{"a": r.A, "b": r.B, "c": r.C, "d": r.D, "e": r.E, "f": r.F}
+
+	if len(values) != len(want) {
+		t.Fatalf("expected %d params, got %d from %q", len(want), len(values), qs)
+	}
+
+	for k, v := range want {
+		if got := values.Get(k); got != v {
+			t.Fatalf("param %s expected %q, got %q from %q", k, v, got, qs)
+		}
+	}
+}
+
+func TestMarshalStructToTOML_StringIntBoolAndTime(t *testing.T) {
+	type rec struct {
+		Name    string    `json:"name"`
+		Count   int       `json:"count"`
+		Enabled bool      `json:"enabled"`
+		Created time.Time `json:"created"`
+		Note    string    `json:"note" skipblank:"true"`
+	}
+
+	r := &rec{Name: `a "b"`, Count: 3, Enabled: true, Created: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)}
+
+	out, err := MarshalStructToTOML(r, "json", "")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToTOML failed: %v", err)
+	}
+
+	want := "name = \"a \\\"b\\\"\"\ncount = 3\nenabled = true\ncreated = 2021-03-04T05:06:07Z\n"
+
+	if out != want {
+		t.Fatalf("unexpected toml output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestRequireTypedPositions_FlagsUntypedField(t *testing.T) {
+	type rec struct {
+		Code   string `pos:"0" type:"a"`
+		Amount string `pos:"1"`
+		Note   string `pos:"-" setter:"SetNote"`
+		Memo   string
+	}
+
+	errs := RequireTypedPositions(&rec{})
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "Amount") {
+		t.Fatalf("expected single error for untyped Amount, got %v", errs)
+	}
+}
+
+func TestMarshalStructToCSVWithWarnings_TruncationWarns(t *testing.T) {
+	type rec struct {
+		Code string `pos:"0" type:"a" size:"0..3"`
+		Name string `pos:"1"`
+	}
+
+	out, warnings, err := MarshalStructToCSVWithWarnings(&rec{Code: "ABCDEF", Name: "x"}, ",")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToCSVWithWarnings failed: %v", err)
+	}
+
+	if out != "ABC,x" {
+		t.Fatalf("expected truncated csv, got %q", out)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Code") || !strings.Contains(warnings[0], "Truncated") {
+		t.Fatalf("expected truncation warning for Code, got %v", warnings)
+	}
+
+	if _, warnings, _ = MarshalStructToCSVWithWarnings(&rec{Code: "AB", Name: "x"}, ","); len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+}
+
+func TestMarshalStructToQueryParams_PointerSkipZeroMatrix(t *testing.T) {
+	type rec struct {
+		S *string    `json:"s" skipzero:"true"`
+		I *int       `json:"i" skipzero:"true"`
+		B *bool      `json:"b" skipzero:"true" booltrue:"Y" boolfalse:"N"`
+		T *time.Time `json:"t" skipzero:"true" timeformat:"20060102"`
+		K string     `json:"k"`
+	}
+
+	out, err := MarshalStructToQueryParams(&rec{K: "x"}, "json", "")
+
+	if err != nil || out != "k=x" {
+		t.Fatalf("nil pointers expected omitted, got %q, %v", out, err)
+	}
+
+	s, i, b, ts := "abc", 12, true, time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	out, err = MarshalStructToQueryParams(&rec{S: &s, I: &i, B: &b, T: &ts, K: "x"}, "json", "")
+
+	if err != nil || out != "s=abc&i=12&b=Y&t=20210304&k=x" {
+		t.Fatalf("non nil pointers expected dereferenced, got %q, %v", out, err)
+	}
+
+	b = false
+	out, err = MarshalStructToQueryParams(&rec{B: &b, K: "x"}, "json", "")
+
+	if err != nil || out != "b=N&k=x" {
+		t.Fatalf("pointer to false expected boolfalse literal, got %q, %v", out, err)
+	}
+}
+
+func TestGetStructFieldByPath_NestedField(t *testing.T) {
+	type zip struct {
+		Code string `json:"code"`
+	}
+
+	type address struct {
+		City string `json:"city"`
+		Zip  *zip   `json:"zip"`
+	}
+
+	type customer struct {
+		Name    string  `json:"name"`
+		Address address `json:"address"`
+	}
+
+	c := &customer{Name: "a", Address: address{City: "Reno", Zip: &zip{Code: "89501"}}}
+
+	if v, err := GetStructFieldByPath(c, "address.zip.code", "json"); err != nil || v != "89501" {
+		t.Fatalf("expected 89501, got %q, %v", v, err)
+	}
+
+	if v, err := GetStructFieldByPath(c, "address.city", "json"); err != nil || v != "Reno" {
+		t.Fatalf("expected Reno, got %q, %v", v, err)
+	}
+
+	if _, err := GetStructFieldByPath(c, "address.state", "json"); err == nil {
+		t.Fatalf("expected error for unknown path segment")
+	}
+
+	c.Address.Zip = nil
+
+	if v, err := GetStructFieldByPath(c, "address.zip.code", "json"); err != nil || v != "" || c.Address.Zip != nil {
+		t.Fatalf("expected blank without allocating nil pointer, got %q, %v", v, err)
+	}
+}
+
+func TestMarshalStructToJsonFunc_TransformUppercasesField(t *testing.T) {
+	type rec struct {
+		Name string `json:"name"`
+		City string `json:"city"`
+	}
+
+	seen := map[string]string{}
+
+	out, err := MarshalStructToJsonFunc(&rec{Name: "ann", City: "reno"}, "json", "", func(fieldName string, tagName string, value string) string {
+		seen[fieldName] = tagName
+
+		if fieldName == "Name" {
+			return strings.ToUpper(value)
+		}
+
+		return value
+	})
+
+	if err != nil {
+		t.Fatalf("MarshalStructToJsonFunc failed: %v", err)
+	}
+
+	if out != `{"name":"ANN", "city":"reno"}` {
+		t.Fatalf("expected name uppercased only, got %s", out)
+	}
+
+	if seen["Name"] != "name" || seen["City"] != "city" {
+		t.Fatalf("transform expected field and tag names, got %v", seen)
+	}
+}
+
+func TestUnmarshalCSVToStruct_RequiredGroupOnShortLine(t *testing.T) {
+	type rec struct {
+		ID    string `pos:"0"`
+		Email string `pos:"1" reqgroup:"contact"`
+		Phone string `pos:"2" reqgroup:"contact"`
+	}
+
+	SetValidateRequiredGroupsOnUnmarshal(true)
+	defer SetValidateRequiredGroupsOnUnmarshal(false)
+
+	for _, line := range []string{"a,,", "a"} {
+		if err := UnmarshalCSVToStruct(&rec{}, line, ",", nil); err == nil || !strings.Contains(err.Error(), "contact") {
+			t.Fatalf("line %q expected required group contact error, got %v", line, err)
+		}
+	}
+
+	r := &rec{}
+
+	if err := UnmarshalCSVToStruct(r, "a,x@y.z", ",", nil); err != nil || r.Email != "x@y.z" {
+		t.Fatalf("short line with group member set expected success, got %+v, %v", *r, err)
+	}
+}
+
+func TestUnmarshalCSVToStruct_ModuloFailureIsFieldErrorAndClears(t *testing.T) {
+	type rec struct {
+		Name string `pos:"0"`
+		Code string `pos:"1" type:"an" size:"0..8+%4"`
+	}
+
+	r := &rec{Name: "keep"}
+	err := UnmarshalCSVToStruct(r, "abc,ABCDEF", ",", nil)
+
+	var fe *FieldError
+
+	if !errors.As(err, &fe) || fe.Field != "Code" || fe.Rule != "size" {
+		t.Fatalf("expected *FieldError for Code size, got %v", err)
+	}
+
+	if r.Name != "" || r.Code != "" {
+		t.Fatalf("expected struct cleared on modulo failure, got %+v", *r)
+	}
+
+	type numRec struct {
+		Name  string `pos:"0"`
+		Count int8   `pos:"1" type:"n"`
+		Flag  string `pos:"2" type:"a" size:"1..1"`
+	}
+
+	SetNumericOverflowMode(NumericOverflowError)
+	defer SetNumericOverflowMode(NumericOverflowSkip)
+
+	n := &numRec{}
+	err = UnmarshalCSVToStructAggregate(n, "abc,300,ZZ", ",", nil)
+
+	var ue *FieldUnmarshalError
+
+	if !errors.As(err, &ue) || ue.FieldName != "Count" {
+		t.Fatalf("expected FieldUnmarshalError for Count, got %v", err)
+	}
+
+	if n.Name != "" {
+		t.Fatalf("expected struct cleared on aggregate conversion error, got %+v", *n)
+	}
+}
+
+func TestUnmarshalCSVToStruct_Base64FailureIsFieldError(t *testing.T) {
+	type rec struct {
+		Name string `pos:"0"`
+		Blob string `pos:"1" type:"b64" req:"true"`
+	}
+
+	r := &rec{}
+	err := UnmarshalCSVToStruct(r, "abc,QUJD=", ",", nil)
+
+	var fe *FieldError
+
+	if !errors.As(err, &fe) || fe.Field != "Blob" || fe.Rule != "type" {
+		t.Fatalf("expected *FieldError for Blob type, got %v", err)
+	}
+
+	err = UnmarshalCSVToStructAggregate(r, "abc,QUJD=", ",", nil)
+
+	var ve ValidationErrors
+
+	if !errors.As(err, &ve) || len(ve) != 1 || ve[0].Field != "Blob" || r.Name != "" {
+		t.Fatalf("expected aggregated Blob error with struct cleared, got %v, %+v", err, *r)
+	}
+}
+
+func TestMarshalStructToSparseCSV_PosTimeKeepsTimeColumn(t *testing.T) {
+	type rec struct {
+		ID string    `pos:"0"`
+		At time.Time `pos:"1" timeformat:"20060102" postime:"2" timeformat2:"150405"`
+		N  int       `pos:"3"`
+	}
+
+	sparse, err := MarshalStructToSparseCSV(&rec{At: time.Date(2021, 3, 4, 15, 6, 7, 0, time.UTC)})
+
+	if err != nil {
+		t.Fatalf("MarshalStructToSparseCSV failed: %v", err)
+	}
+
+	if len(sparse) != 2 || sparse[1] != "20210304" || sparse[2] != "150607" {
+		t.Fatalf("expected date and time columns, got %v", sparse)
+	}
+
+	r := &rec{ID: "keep"}
+
+	if err := UnmarshalSparseCSVToStruct(r, sparse); err != nil {
+		t.Fatalf("UnmarshalSparseCSVToStruct failed: %v", err)
+	}
+
+	if r.At.Format("20060102150405") != "20210304150607" || r.ID != "keep" {
+		t.Fatalf("sparse round trip lost time of day, got %+v", *r)
+	}
+
+	type fixed struct {
+		At time.Time `pos:"0" width:"8" timeformat:"20060102" postime:"1" timeformat2:"150405"`
+	}
+
+	if _, err := MarshalStructToFixedWidth(&fixed{At: time.Now()}); err == nil || !strings.Contains(err.Error(), "postime") {
+		t.Fatalf("expected fixed width postime rejected, got %v", err)
+	}
+}
+
+func TestValidateKnownTags_MisspelledTag(t *testing.T) {
+	type rec struct {
+		Name string `json:"name" skipblnk:"true"`
+		Code string `json:"code" skipblank:"true"`
+	}
+
+	errs := ValidateKnownTags(&rec{}, "json")
+
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "skipblnk") || !strings.Contains(errs[0].Error(), "Name") {
+		t.Fatalf("expected single error flagging skipblnk on Name, got %v", errs)
+	}
+}
+
+func TestStructToCSVValues_OrderAndTypes(t *testing.T) {
+	type rec struct {
+		N    int       `pos:"3"`
+		ID   string    `pos:"0"`
+		At   time.Time `pos:"1" timeformat:"20060102" postime:"2" timeformat2:"150405" csvheader:"At"`
+		Rate float64   `pos:"4"`
+		OK   bool      `pos:"5"`
+		Ptr  *string   `pos:"6"`
+	}
+
+	at := time.Date(2021, 3, 4, 15, 6, 7, 0, time.UTC)
+	r := &rec{N: 7, ID: "A1", At: at, Rate: 1.5, OK: true}
+
+	values, err := StructToCSVValues(r)
+
+	if err != nil {
+		t.Fatalf("StructToCSVValues failed: %v", err)
+	}
+
+	header, err := GetCSVHeaderFromStruct(r, ",")
+
+	if err != nil {
+		t.Fatalf("GetCSVHeaderFromStruct failed: %v", err)
+	}
+
+	if cols := strings.Split(header, ","); len(cols) != len(values) || cols[2] != "At Time" {
+		t.Fatalf("header %q not aligned with %d values", header, len(values))
+	}
+
+	want := []interface{}{"A1", at, "150607", 7, 1.5, true, nil}
+
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("expected %#v, got %#v", want, values)
+	}
+}
+
+func TestMarshalStructToSparseCSV_TwoOfFiveFieldsSet(t *testing.T) {
+	type rec struct {
+		A string `pos:"0"`
+		B int    `pos:"1"`
+		C string `pos:"2" def:"x"`
+		D string `pos:"3"`
+		E bool   `pos:"4"`
+	}
+
+	sparse, err := MarshalStructToSparseCSV(&rec{B: 5, C: "x", D: "d"})
+
+	if err != nil {
+		t.Fatalf("MarshalStructToSparseCSV failed: %v", err)
+	}
+
+	if len(sparse) != 2 || sparse[1] != "5" || sparse[3] != "d" {
+		t.Fatalf("expected pos 1 and 3 only, got %v", sparse)
+	}
+}
+
+func TestUnmarshalSparseCSVToStruct_UnspecifiedFieldsRetained(t *testing.T) {
+	type rec struct {
+		A string `pos:"0"`
+		B int    `pos:"1"`
+		C string `pos:"2" def:"dflt"`
+		D string `pos:"3"`
+	}
+
+	r := &rec{A: "a", B: 1, C: "c", D: "d"}
+
+	if err := UnmarshalSparseCSVToStruct(r, map[int]string{1: "42", 3: "new"}); err != nil {
+		t.Fatalf("UnmarshalSparseCSVToStruct failed: %v", err)
+	}
+
+	if r.A != "a" || r.B != 42 || r.C != "c" || r.D != "new" {
+		t.Fatalf("expected only pos 1 and 3 updated, got %+v", *r)
+	}
+}
+
+func TestStructFingerprint_NoHashFieldIgnored(t *testing.T) {
+	type rec struct {
+		ID      string    `json:"id"`
+		Amount  int       `json:"amount"`
+		Created time.Time `json:"created" nohash:"true"`
+	}
+
+	a := &rec{ID: "A1", Amount: 5, Created: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := &rec{ID: "A1", Amount: 5, Created: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	fa, err := StructFingerprint(a, "json")
+
+	if err != nil {
+		t.Fatalf("StructFingerprint failed: %v", err)
+	}
+
+	if fb, err := StructFingerprint(b, "json"); err != nil || fa != fb {
+		t.Fatalf("expected same fingerprint for structs differing in nohash field, got %s and %s, %v", fa, fb, err)
+	}
+
+	b.Amount = 6
+
+	if fb, _ := StructFingerprint(b, "json"); fa == fb {
+		t.Fatalf("expected different fingerprint when hashed field differs")
+	}
+}
+
+func TestMarshalChangedSince_OnlyMutatedKeyEmitted(t *testing.T) {
+	type rec struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Amount int    `json:"amount"`
+	}
+
+	r := &rec{ID: "A1", Name: "ann", Amount: 5}
+	snap, err := Snapshot(r, "json")
+
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	if out, err := MarshalChangedSince(r, snap, "json", ""); err != nil || out != "{}" {
+		t.Fatalf("expected no change, got %s, %v", out, err)
+	}
+
+	r.Amount = 9
+
+	if out, err := MarshalChangedSince(r, snap, "json", ""); err != nil || out != `{"amount":"9"}` {
+		t.Fatalf("expected only amount emitted, got %s, %v", out, err)
+	}
+}
+
+func TestMarshalStruct_ExclusiveGroupConflict(t *testing.T) {
+	type rec struct {
+		ID    string `json:"id" pos:"0"`
+		Card  string `json:"card" pos:"1" exclusive:"tender"`
+		Check string `json:"check" pos:"2" exclusive:"tender"`
+	}
+
+	conflict := &rec{ID: "A1", Card: "4111", Check: "1001"}
+
+	if _, err := MarshalStructToJson(conflict, "json", ""); err == nil || !strings.Contains(err.Error(), "tender") || !strings.Contains(err.Error(), "Card, Check") {
+		t.Fatalf("json expected exclusive group conflict error, got %v", err)
+	}
+
+	if _, err := MarshalStructToCSV(conflict, ","); err == nil || !strings.Contains(err.Error(), "tender") {
+		t.Fatalf("csv expected exclusive group conflict error, got %v", err)
+	}
+
+	if out, err := MarshalStructToCSV(&rec{ID: "A1", Card: "4111"}, ","); err != nil || out != "A1,4111," {
+		t.Fatalf("single group member expected marshaled, got %q, %v", out, err)
+	}
+}
+
+type fillNode struct {
+	Name string
+	Next *fillNode
+}
+
+// fillFlatA, fillFlatB and fillFlatC dereference a pointer cycle into non-pointer fields, which cannot reuse a prior copy
+type fillFlatA struct {
+	Name string
+	Next fillFlatB
+}
+
+type fillFlatB struct {
+	Name string
+	Next *fillFlatC
+}
+
+type fillFlatC struct {
+	Name string
+	Next fillFlatB
+}
+
+func TestFillDeep_IndependentCopyAndCycleGuard(t *testing.T) {
+	type inner struct {
+		Tags []string
+	}
+
+	type rec struct {
+		Items []int
+		Count *int
+		Attrs map[string]string
+		In    *inner
+	}
+
+	n := 5
+	src := &rec{Items: []int{1, 2}, Count: &n, Attrs: map[string]string{"a": "1"}, In: &inner{Tags: []string{"x"}}}
+	dst := &rec{}
+
+	if err := FillDeep(src, dst); err != nil {
+		t.Fatalf("FillDeep failed: %v", err)
+	}
+
+	dst.Items[0] = 99
+	*dst.Count = 7
+	dst.Attrs["a"] = "2"
+	dst.In.Tags[0] = "y"
+
+	if src.Items[0] != 1 || n != 5 || src.Attrs["a"] != "1" || src.In.Tags[0] != "x" {
+		t.Fatalf("mutating dst affected src: %+v, %+v", *src, *src.In)
+	}
+
+	cyclic := &fillNode{Name: "a"}
+	cyclic.Next = cyclic
+
+	if err := FillDeep(cyclic, &fillFlatA{}); err == nil || !strings.Contains(err.Error(), "Cyclic Reference") {
+		t.Fatalf("expected cyclic reference error, got %v", err)
+	}
+}
+
+func TestFillDeep_SelfAndTwoNodeCycles(t *testing.T) {
+	self := &fillNode{Name: "self"}
+	self.Next = self
+
+	var d fillNode
+
+	if err := FillDeep(self, &d); err != nil {
+		t.Fatalf("FillDeep self cycle failed: %v", err)
+	}
+
+	if d.Name != "self" || d.Next != &d || d.Next == self {
+		t.Fatalf("self cycle expected to point back to dst copy")
+	}
+
+	a := &fillNode{Name: "a"}
+	b := &fillNode{Name: "b", Next: a}
+	a.Next = b
+
+	var c fillNode
+
+	if err := FillDeep(a, &c); err != nil {
+		t.Fatalf("FillDeep two node cycle failed: %v", err)
+	}
+
+	if c.Name != "a" || c.Next == nil || c.Next.Name != "b" || c.Next.Next != &c || c.Next == b {
+		t.Fatalf("two node cycle expected preserved in dst copy")
+	}
+
+	if err := DeepFill(a, &c, "json"); err != nil || c.Next.Next != &c {
+		t.Fatalf("DeepFill two node cycle failed: %v", err)
+	}
+}
+
+func TestSetStructFieldByPath_TwoLevelAllocatesPointer(t *testing.T) {
+	type address struct {
+		Zip  string `json:"zip"`
+		Unit int    `json:"unit"`
+	}
+
+	type customer struct {
+		Name    string   `json:"name"`
+		Address *address `json:"address"`
+	}
+
+	c := &customer{Name: "ann"}
+
+	if err := SetStructFieldByPath(c, "address.zip", "90210", "json"); err != nil {
+		t.Fatalf("SetStructFieldByPath failed: %v", err)
+	}
+
+	if c.Address == nil || c.Address.Zip != "90210" || c.Name != "ann" {
+		t.Fatalf("expected nil address allocated and zip set, got %+v", c)
+	}
+
+	if err := SetStructFieldByPath(c, "address.unit", "12", "json"); err != nil || c.Address.Unit != 12 || c.Address.Zip != "90210" {
+		t.Fatalf("expected unit set on existing address, got %+v, %v", c.Address, err)
+	}
+
+	if err := SetStructFieldByPath(c, "address.state", "NV", "json"); err == nil {
+		t.Fatalf("expected error for unknown path segment")
+	}
+}
+
+func TestSetStructFieldDefaultValues_DefEnvAcrossEnvironments(t *testing.T) {
+	type config struct {
+		Host    string `defenv:"prod=api.example.com;staging=stg.example.com;default=localhost"`
+		Retries int    `defenv:"prod=5;staging=2" def:"1"`
+	}
+
+	defer SetEnvironment("")
+
+	cases := []struct {
+		env     string
+		host    string
+		retries int
+	}{
+		{"prod", "api.example.com", 5},
+		{"STAGING", "stg.example.com", 2},
+		{"dev", "localhost", 1},
+	}
+
+	for _, c := range cases {
+		SetEnvironment(c.env)
+
+		cfg := &config{}
+		SetStructFieldDefaultValues(cfg)
+
+		if cfg.Host != c.host || cfg.Retries != c.retries {
+			t.Fatalf("environment %s expected %s, %d, got %+v", c.env, c.host, c.retries, *cfg)
+		}
+	}
+
+	SetEnvironment("prod")
+
+	cfg := &config{Host: "set"}
+	SetStructFieldDefaultValues(cfg)
+
+	if cfg.Host != "set" || cfg.Retries != 5 {
+		t.Fatalf("set field expected to keep its value, got %+v", *cfg)
+	}
+}
+
+func TestMarshalStructsToExcelCSV_BOMAndCRLF(t *testing.T) {
+	type rec struct {
+		Name string `pos:"0"`
+		City string `pos:"1"`
+	}
+
+	out, err := MarshalStructsToExcelCSV([]interface{}{&rec{Name: "José", City: "Reno"}, &rec{Name: "Zoë", City: "Elko"}}, ",")
+
+	if err != nil {
+		t.Fatalf("MarshalStructsToExcelCSV failed: %v", err)
+	}
+
+	if !strings.HasPrefix(out, "\xEF\xBB\xBF") {
+		t.Fatalf("expected utf-8 bom prefix, got % x", []byte(out)[:3])
+	}
+
+	if out[3:] != "José,Reno\r\nZoë,Elko\r\n" {
+		t.Fatalf("expected crlf terminated lines, got %q", out[3:])
+	}
+
+	if strings.Count(out, "\n") != strings.Count(out, "\r\n") {
+		t.Fatalf("expected no bare lf terminators, got %q", out)
+	}
+}
+
+type stringerLevel int
+
+func (l stringerLevel) String() string {
+	return fmt.Sprintf("L%d", int(l))
+}
+
+type stringerPoint struct {
+	X, Y int
+}
+
+func (p *stringerPoint) String() string {
+	return fmt.Sprintf("%d:%d", p.X, p.Y)
+}
+
+func TestReflectValueToString_UseStringer(t *testing.T) {
+	if v, _, err := ReflectValueToString(reflect.ValueOf(stringerLevel(3)), "", "", false, false, "", false, true); err != nil || v != "L3" {
+		t.Fatalf("expected L3 via String, got %q, %v", v, err)
+	}
+
+	if v, _, err := ReflectValueToString(reflect.ValueOf(stringerLevel(3)), "", "", false, false, "", false); err != nil || v != "3" {
+		t.Fatalf("expected 3 without usestringer, got %q, %v", v, err)
+	}
+
+	type rec struct {
+		Level stringerLevel `json:"level" usestringer:"true"`
+		Point stringerPoint `json:"point" usestringer:"true"`
+		Raw   stringerLevel `json:"raw"`
+	}
+
+	out, err := MarshalStructToJson(&rec{Level: 2, Point: stringerPoint{X: 1, Y: 5}, Raw: 4}, "json", "")
+
+	if err != nil {
+		t.Fatalf("MarshalStructToJson failed: %v", err)
+	}
+
+	if out != `{"level":"L2", "point":"1:5", "raw":"4"}` {
+		t.Fatalf("unexpected stringer output %s", out)
+	}
+}
+
+func TestMarshalStructToJsonGroup_SummaryVsFull(t *testing.T) {
+	type rec struct {
+		ID      string `json:"id"`
+		Name    string `json:"name" group:"summary,full"`
+		Address string `json:"address" group:"full"`
+		Notes   string `json:"notes" group:"Full"`
+	}
+
+	r := &rec{ID: "A1", Name: "ann", Address: "1 Main", Notes: "vip"}
+
+	if out, err := MarshalStructToJsonGroup(r, "json", "", "summary"); err != nil || out != `{"id":"A1", "name":"ann"}` {
+		t.Fatalf("summary view got %s, %v", out, err)
+	}
+
+	if out, err := MarshalStructToJsonGroup(r, "json", "", "full"); err != nil || out != `{"id":"A1", "name":"ann", "address":"1 Main", "notes":"vip"}` {
+		t.Fatalf("full view got %s, %v", out, err)
+	}
+}
+
+func TestExampleJSONAndCSV_ContainDefValues(t *testing.T) {
+	type rec struct {
+		Code   string `json:"code" pos:"0" def:"USD"`
+		Amount int    `json:"amount" pos:"1" def:"100"`
+		Name   string `json:"name" pos:"2"`
+	}
+
+	js, err := ExampleJSON(reflect.TypeOf(rec{}), "json")
+
+	if err != nil {
+		t.Fatalf("ExampleJSON failed: %v", err)
+	}
+
+	if !strings.Contains(js, `"code":"USD"`) || !strings.Contains(js, `"amount":"100"`) || !strings.Contains(js, `"name":`) {
+		t.Fatalf("example json expected def values, got %s", js)
+	}
+
+	csv, err := ExampleCSV(reflect.TypeOf(&rec{}))
+
+	if err != nil {
+		t.Fatalf("ExampleCSV failed: %v", err)
+	}
+
+	if parts := strings.Split(csv, ","); len(parts) != 3 || parts[0] != "USD" || parts[1] != "100" || len(parts[2]) == 0 {
+		t.Fatalf("example csv expected def values and placeholder, got %s", csv)
+	}
+}
+
+func TestMarshalStructToCSV_SubDelimIntSliceRoundTrip(t *testing.T) {
+	type rec struct {
+		ID    string `pos:"0"`
+		Codes []int  `pos:"1" subdelim:";"`
+		N     int    `pos:"2"`
+	}
+
+	r := &rec{ID: "A1", Codes: []int{3, 1, 42}, N: 9}
+	out, err := MarshalStructToCSV(r, ",")
+
+	if err != nil || out != "A1,3;1;42,9" {
+		t.Fatalf("expected joined slice cell, got %q, %v", out, err)
+	}
+
+	r2 := &rec{}
+
+	if err := UnmarshalCSVToStruct(r2, out, ",", nil); err != nil || !reflect.DeepEqual(r2, r) {
+		t.Fatalf("round trip mismatch, got %+v, %v", *r2, err)
+	}
+
+	if out, err = MarshalStructToCSV(&rec{ID: "A2", N: 1}, ","); err != nil || out != "A2,,1" {
+		t.Fatalf("expected blank cell for empty slice, got %q, %v", out, err)
+	}
+
+	if err := UnmarshalCSVToStruct(r2, out, ",", nil); err != nil || r2.Codes != nil {
+		t.Fatalf("expected nil slice for blank cell, got %+v, %v", *r2, err)
+	}
+}
+
+// cancelAfterLinesReader returns one line per Read, and cancels ctx once the given number of lines has been read
+type cancelAfterLinesReader struct {
+	lines  []string
+	after  int
+	read   int
+	cancel context.CancelFunc
+}
+
+func (r *cancelAfterLinesReader) Read(p []byte) (int, error) {
+	if r.read >= len(r.lines) {
+		return 0, io.EOF
+	}
+
+	if r.read == r.after {
+		r.cancel()
+	}
+
+	n := copy(p, r.lines[r.read])
+	r.read++
+	return n, nil
+}
+
+func TestUnmarshalToSliceWithContext_CancelMidStream(t *testing.T) {
+	type rec struct {
+		ID string `json:"id" pos:"0"`
+	}
+
+	csvLines := []string{"a\n", "b\n", "c\n", "d\n", "e\n"}
+	jsonLines := []string{`{"id":"a"}` + "\n", `{"id":"b"}` + "\n", `{"id":"c"}` + "\n", `{"id":"d"}` + "\n", `{"id":"e"}` + "\n"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var csvOut []*rec
+	err := UnmarshalCSVToSliceWithContext(ctx, &cancelAfterLinesReader{lines: csvLines, after: 2, cancel: cancel}, &csvOut, ",", nil)
+
+	if !errors.Is(err, context.Canceled) || len(csvOut) < 2 || len(csvOut) >= len(csvLines) {
+		t.Fatalf("csv expected cancel mid stream, got %d records, %v", len(csvOut), err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	var jsonOut []rec
+	err = UnmarshalNDJSONToSliceWithContext(ctx, &cancelAfterLinesReader{lines: jsonLines, after: 2, cancel: cancel}, &jsonOut, "json", "")
+
+	if !errors.Is(err, context.Canceled) || len(jsonOut) < 2 || len(jsonOut) >= len(jsonLines) {
+		t.Fatalf("ndjson expected cancel mid stream, got %d records, %v", len(jsonOut), err)
+	}
+}
+
+func TestMarshalStructToJson_EscapingRoundTrip(t *testing.T) {
+	type rec struct {
+		Text string `json:"text"`
+	}
+
+	values := []string{
+		`say "hi"`,
+		`C:\path\to\file`,
+		"line1\nline2\r\n\ttabbed",
+		"bell\x07 nul\x00 esc\x1b us\x1f",
+		"it's 😀 ünïcode",
+		`\"already escaped\"`,
+	}
+
+	for _, v := range values {
+		out, err := MarshalStructToJson(&rec{Text: v}, "json", "")
+
+		if err != nil {
+			t.Fatalf("MarshalStructToJson %q failed: %v", v, err)
+		}
+
+		var m map[string]string
+
+		if err := json.Unmarshal([]byte(out), &m); err != nil || m["text"] != v {
+			t.Fatalf("encoding/json parse of %s got %q, %v, want %q", out, m["text"], err, v)
+		}
+
+		r := &rec{}
+
+		if err := UnmarshalJsonToStruct(r, out, "json", ""); err != nil || r.Text != v {
+			t.Fatalf("UnmarshalJsonToStruct of %s got %q, %v, want %q", out, r.Text, err, v)
+		}
+	}
+}
+
+func TestBoolTag_FormatScopedLiterals(t *testing.T) {
+	type rec struct {
+		ID     string `json:"id" pos:"0"`
+		Active bool   `json:"active" pos:"1" booltrue.csv:"Y" boolfalse.csv:"N" booltrue.json:"yes" boolfalse.json:"no"`
+		Legacy bool   `json:"legacy" pos:"2" booltrue:"1" boolfalse:"0" booltrue.json:"on"`
+	}
+
+	for _, c := range []struct {
+		active, legacy bool
+		csv, json      string
+	}{
+		{true, true, "a,Y,1", `{"id":"a", "active":"yes", "legacy":"on"}`},
+		{false, false, "a,N,0", `{"id":"a", "active":"no", "legacy":"0"}`},
+	} {
+		r := &rec{ID: "a", Active: c.active, Legacy: c.legacy}
+
+		csv, err := MarshalStructToCSV(r, ",")
+
+		if err != nil || csv != c.csv {
+			t.Fatalf("MarshalStructToCSV got %q, %v, want %q", csv, err, c.csv)
+		}
+
+		js, err := MarshalStructToJson(r, "json", "")
+
+		if err != nil || js != c.json {
+			t.Fatalf("MarshalStructToJson got %s, %v, want %s", js, err, c.json)
+		}
+
+		fromCSV := &rec{Active: !c.active, Legacy: !c.legacy}
+
+		if err := UnmarshalCSVToStruct(fromCSV, csv, ",", nil); err != nil || fromCSV.Active != c.active || fromCSV.Legacy != c.legacy {
+			t.Fatalf("UnmarshalCSVToStruct(%q) got %+v, %v", csv, fromCSV, err)
+		}
+
+		fromJson := &rec{Active: !c.active, Legacy: !c.legacy}
+
+		if err := UnmarshalJsonToStruct(fromJson, js, "json", ""); err != nil || fromJson.Active != c.active || fromJson.Legacy != c.legacy {
+			t.Fatalf("UnmarshalJsonToStruct(%s) got %+v, %v", js, fromJson, err)
+		}
+	}
+}
+
+func TestUnmarshalCSVByHeader_ReorderedAndMissingColumn(t *testing.T) {
+	type rec struct {
+		ID     string  `pos:"0" col:"Order ID"`
+		Name   string  `pos:"1"`
+		Qty    int     `pos:"2" col:"Quantity"`
+		Status string  `pos:"3" def:"NEW"`
+		Price  float64 `pos:"4"`
+	}
+
+	r := &rec{}
+
+	// header order differs from pos order, status column is absent, extra column is ignored
+	if err := UnmarshalCSVByHeader(r, "price,QUANTITY,Extra,name,order id", "9.5,3,zzz,Widget,A100", ","); err != nil {
+		t.Fatalf("UnmarshalCSVByHeader failed: %v", err)
+	}
+
+	if r.ID != "A100" || r.Name != "Widget" || r.Qty != 3 || r.Price != 9.5 {
+		t.Fatalf("columns not matched by header, got %+v", r)
+	}
+
+	if r.Status != "NEW" {
+		t.Fatalf("missing column should keep def value NEW, got %q", r.Status)
+	}
+}
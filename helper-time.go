@@ -227,9 +227,17 @@ func ParseDateTime24Hr(s string) time.Time {
 }
 
 // ParseDateTimeCustom will parse a date time value in s string, based on the f format
-// f format is 2006 01 02 15:04:05 / 03:04:05 PM
-func ParseDateTimeCustom(s string, f string) time.Time {
-	t, err := time.Parse(f, strings.TrimSpace(s))
+// f format is 2006 01 02 15:04:05 / 03:04:05 PM,
+// loc is optional, if specified, s without zone info is parsed as time in loc (via time.ParseInLocation)
+func ParseDateTimeCustom(s string, f string, loc ...*time.Location) time.Time {
+	var t time.Time
+	var err error
+
+	if len(loc) > 0 && loc[0] != nil {
+		t, err = time.ParseInLocation(f, strings.TrimSpace(s), loc[0])
+	} else {
+		t, err = time.Parse(f, strings.TrimSpace(s))
+	}
 
 	if err != nil {
 		return time.Time{}
@@ -131,7 +131,7 @@ func GenerateRandomChar() string {
 	}
 
 	// convert decimal ascii to char
-	return string(r)
+	return string(rune(r))
 }
 
 // GenerateNewUniqueInt32 will take in old value and return new unique value with randomized seed and negated
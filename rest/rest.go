@@ -18,6 +18,7 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
 	"github.com/aldelo/common/tlsconfig"
@@ -26,6 +27,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // server ca pems stores list of self-signed CAs for client tls config
@@ -157,11 +159,50 @@ func GET(url string, headers []*HeaderKeyValue) (statusCode int, body string, er
 // JSON Content-Type Header:
 //		Content-Type: application/json
 func POST(url string, headers []*HeaderKeyValue, requestBody string) (statusCode int, responseBody string, err error) {
+	return POSTWithTimeout(url, headers, requestBody, 0)
+}
+
+//
+// POSTWithTimeout sends url post request to host and retrieve the body response in string,
+// the http client will abort the request once timeout is reached (timeout 0 = no timeout)
+//
+// Default Header = Content-Type: application/x-www-form-urlencoded
+//
+// JSON Content-Type Header:
+//		Content-Type: application/json
+func POSTWithTimeout(url string, headers []*HeaderKeyValue, requestBody string, timeout time.Duration) (statusCode int, responseBody string, err error) {
+	return postWithContext(context.Background(), url, headers, requestBody, timeout)
+}
+
+//
+// POSTWithContext sends url post request to host and retrieve the body response in string,
+// the http request is aborted once ctx is cancelled or its deadline is reached
+//
+// Default Header = Content-Type: application/x-www-form-urlencoded
+//
+// JSON Content-Type Header:
+//		Content-Type: application/json
+func POSTWithContext(ctx context.Context, url string, headers []*HeaderKeyValue, requestBody string) (statusCode int, responseBody string, err error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return postWithContext(ctx, url, headers, requestBody, 0)
+}
+
+// postWithContext performs the http post request bound to ctx, with optional http client timeout (0 = no timeout)
+func postWithContext(ctx context.Context, url string, headers []*HeaderKeyValue, requestBody string, timeout time.Duration) (statusCode int, responseBody string, err error) {
 	// create http client
 	var client *http.Client
 
+	if timeout < 0 {
+		timeout = 0
+	}
+
 	if clientTlsConfig == nil {
-		client = &http.Client{}
+		client = &http.Client{
+			Timeout: timeout,
+		}
 	} else {
 		tr := &http.Transport{
 			TLSClientConfig: clientTlsConfig,
@@ -169,13 +210,14 @@ func POST(url string, headers []*HeaderKeyValue, requestBody string) (statusCode
 
 		client = &http.Client{
 			Transport: tr,
+			Timeout:   timeout,
 		}
 	}
 
 	// create http request from client
 	var req *http.Request
 
-	if req, err = http.NewRequest("POST", url, bytes.NewBuffer([]byte(requestBody))); err != nil {
+	if req, err = http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer([]byte(requestBody))); err != nil {
 		return 0, "", errors.New("Create New Http Post Request Failed: " + err.Error())
 	}
 